@@ -0,0 +1,230 @@
+package circuit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseBLIF parses a combinational circuit in BLIF format from r,
+// such as the ones written by Yosys or ABC.
+//
+// Only the first model is parsed.
+// Logic functions given by .names are decomposed into multiplexers,
+// and gates given by .gate or .subckt should be Yosys internal gates, such as $_AND_.
+// Inputs and outputs whose names are of the form name[i] are grouped into buses,
+// ordered from the least significant bit.
+func ParseBLIF(r io.Reader) (Circuit, error) {
+	n := newNetlist()
+	var inputNames, outputNames []string
+
+	lines, err := blifLines(r)
+	if err != nil {
+		return Circuit{}, err
+	}
+
+	modelCount := 0
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		errorf := func(format string, args ...any) error {
+			return fmt.Errorf("blif: line %d: %s", line.number, fmt.Sprintf(format, args...))
+		}
+
+		switch line.fields[0] {
+		case ".model":
+			modelCount++
+		case ".end":
+			if modelCount > 0 {
+				i = len(lines)
+			}
+		case ".inputs":
+			inputNames = append(inputNames, line.fields[1:]...)
+		case ".outputs":
+			outputNames = append(outputNames, line.fields[1:]...)
+		case ".names":
+			if len(line.fields) < 2 {
+				return Circuit{}, errorf("invalid .names")
+			}
+			if len(line.fields) > 17 {
+				return Circuit{}, errorf("too many inputs in .names")
+			}
+
+			in := make([]int, len(line.fields)-2)
+			for j, name := range line.fields[1 : len(line.fields)-1] {
+				in[j] = n.net(name)
+			}
+			out := n.net(line.fields[len(line.fields)-1])
+
+			var cover []blifLine
+			for i+1 < len(lines) && !strings.HasPrefix(lines[i+1].fields[0], ".") {
+				i++
+				cover = append(cover, lines[i])
+			}
+
+			tt, err := blifTruthTable(len(in), cover)
+			if err != nil {
+				return Circuit{}, err
+			}
+			if err := n.addTruthTable(out, in, tt); err != nil {
+				return Circuit{}, errorf("%v", err)
+			}
+		case ".gate", ".subckt":
+			if len(line.fields) < 2 {
+				return Circuit{}, errorf("invalid %s", line.fields[0])
+			}
+
+			pins := make(map[string]int)
+			for _, f := range line.fields[2:] {
+				pin, net, ok := strings.Cut(f, "=")
+				if !ok {
+					return Circuit{}, errorf("invalid pin %q", f)
+				}
+				pins[pin] = n.net(net)
+			}
+			if err := n.addCell(line.fields[1], pins); err != nil {
+				return Circuit{}, errorf("%v", err)
+			}
+		case ".conn":
+			if len(line.fields) != 3 {
+				return Circuit{}, errorf("invalid .conn")
+			}
+			if err := n.addGate(GateEQW, n.net(line.fields[2]), n.net(line.fields[1])); err != nil {
+				return Circuit{}, errorf("%v", err)
+			}
+		case ".latch":
+			return Circuit{}, errorf("sequential circuits are not supported")
+		default:
+			if strings.HasPrefix(line.fields[0], ".") {
+				// Ignore other directives, such as .attr and .cname.
+				continue
+			}
+			return Circuit{}, errorf("unexpected line")
+		}
+	}
+
+	busNames, buses := groupBuses(inputNames)
+	for i, bus := range buses {
+		nets := make([]int, len(bus))
+		for j, name := range bus {
+			nets[j] = n.net(name)
+		}
+		if err := n.addInput(busNames[i], nets); err != nil {
+			return Circuit{}, fmt.Errorf("blif: %v", err)
+		}
+	}
+
+	busNames, buses = groupBuses(outputNames)
+	for i, bus := range buses {
+		nets := make([]int, len(bus))
+		for j, name := range bus {
+			nets[j] = n.net(name)
+		}
+		n.addOutput(busNames[i], nets)
+	}
+
+	c, err := n.build()
+	if err != nil {
+		return Circuit{}, fmt.Errorf("blif: %v", err)
+	}
+	return c, nil
+}
+
+// blifLine is a logical line of a BLIF file.
+type blifLine struct {
+	number int
+	fields []string
+}
+
+// blifLines reads the non-empty logical lines of a BLIF file,
+// with comments removed and continued lines joined.
+func blifLines(r io.Reader) ([]blifLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	var lines []blifLine
+	var fields []string
+	number, start := 0, 0
+	for scanner.Scan() {
+		number++
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+
+		if len(fields) == 0 {
+			start = number
+		}
+		continued := strings.HasSuffix(strings.TrimSpace(text), "\\")
+		if continued {
+			text = strings.TrimSuffix(strings.TrimSpace(text), "\\")
+		}
+		fields = append(fields, strings.Fields(text)...)
+
+		if !continued && len(fields) > 0 {
+			lines = append(lines, blifLine{number: start, fields: fields})
+			fields = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		lines = append(lines, blifLine{number: start, fields: fields})
+	}
+	return lines, nil
+}
+
+// blifTruthTable returns the truth table of a .names cover with inputCount inputs.
+func blifTruthTable(inputCount int, cover []blifLine) ([]bool, error) {
+	tt := make([]bool, 1<<inputCount)
+	if len(cover) == 0 {
+		return tt, nil
+	}
+
+	onSet := true
+	for k, line := range cover {
+		var plane, value string
+		switch {
+		case inputCount == 0 && len(line.fields) == 1:
+			value = line.fields[0]
+		case inputCount > 0 && len(line.fields) == 2 && len(line.fields[0]) == inputCount:
+			plane, value = line.fields[0], line.fields[1]
+		default:
+			return nil, fmt.Errorf("blif: line %d: invalid cover", line.number)
+		}
+
+		if value != "0" && value != "1" {
+			return nil, fmt.Errorf("blif: line %d: invalid output %q", line.number, value)
+		}
+		if k == 0 {
+			onSet = value == "1"
+		} else if onSet != (value == "1") {
+			return nil, fmt.Errorf("blif: line %d: mixed on-set and off-set", line.number)
+		}
+
+		for m := range tt {
+			match := true
+			for j := 0; j < inputCount; j++ {
+				bit := m>>j&1 == 1
+				switch plane[j] {
+				case '0':
+					match = match && !bit
+				case '1':
+					match = match && bit
+				case '-':
+				default:
+					return nil, fmt.Errorf("blif: line %d: invalid cover %q", line.number, plane)
+				}
+			}
+			tt[m] = tt[m] || match
+		}
+	}
+
+	if !onSet {
+		for m := range tt {
+			tt[m] = !tt[m]
+		}
+	}
+	return tt, nil
+}
@@ -0,0 +1,203 @@
+package circuit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseBristol parses a circuit in Bristol Fashion format from r.
+//
+// Supported gates are XOR, AND, INV, EQ, EQW and MAND.
+// MAND gates are split into AND gates.
+// See https://nigelsmart.github.io/MPC-Circuits/ for the format.
+func ParseBristol(r io.Reader) (Circuit, error) {
+	p := bristolParser{scanner: bufio.NewScanner(r)}
+	p.scanner.Buffer(nil, 1<<20)
+
+	header, err := p.nextInts()
+	if err != nil {
+		return Circuit{}, err
+	}
+	if len(header) != 2 {
+		return Circuit{}, p.errorf("invalid header")
+	}
+	gateCount, wireCount := header[0], header[1]
+
+	inputSizes, err := p.nextSizes()
+	if err != nil {
+		return Circuit{}, err
+	}
+	outputSizes, err := p.nextSizes()
+	if err != nil {
+		return Circuit{}, err
+	}
+
+	c := Circuit{
+		WireCount:   wireCount,
+		InputSizes:  inputSizes,
+		OutputSizes: outputSizes,
+	}
+
+	if c.InputWireCount() > wireCount || c.OutputWireCount() > wireCount {
+		return Circuit{}, p.errorf("too many input or output wires")
+	}
+
+	// WireCount and gateCount come from the header, so they are not trusted
+	// for allocation until the gates are actually read.
+	defined := make(map[int]bool)
+	for i := 0; i < c.InputWireCount(); i++ {
+		defined[i] = true
+	}
+
+	for i := 0; i < gateCount; i++ {
+		fields, err := p.nextFields()
+		if err == io.ErrUnexpectedEOF {
+			return Circuit{}, p.errorf("expected %d gates, got %d", gateCount, i)
+		}
+		if err != nil {
+			return Circuit{}, err
+		}
+		if len(fields) < 3 {
+			return Circuit{}, p.errorf("invalid gate")
+		}
+
+		inputCount, err0 := strconv.Atoi(fields[0])
+		outputCount, err1 := strconv.Atoi(fields[1])
+		if err0 != nil || err1 != nil || inputCount < 0 || outputCount < 0 || len(fields) != inputCount+outputCount+3 {
+			return Circuit{}, p.errorf("invalid gate")
+		}
+		gateType := fields[len(fields)-1]
+
+		wires := make([]int, inputCount+outputCount)
+		for j := range wires {
+			if wires[j], err = strconv.Atoi(fields[j+2]); err != nil {
+				return Circuit{}, p.errorf("invalid wire %q", fields[j+2])
+			}
+			// Inputs of EQ gates are constants, not wires.
+			if (gateType != "EQ" || j >= inputCount) && (wires[j] < 0 || wires[j] >= wireCount) {
+				return Circuit{}, p.errorf("invalid wire %d", wires[j])
+			}
+		}
+		input, output := wires[:inputCount], wires[inputCount:]
+
+		var gates []Gate
+		switch {
+		case gateType == "XOR" && inputCount == 2 && outputCount == 1:
+			gates = []Gate{{Type: GateXOR, Input: input, Output: output[0]}}
+		case gateType == "AND" && inputCount == 2 && outputCount == 1:
+			gates = []Gate{{Type: GateAND, Input: input, Output: output[0]}}
+		case gateType == "INV" && inputCount == 1 && outputCount == 1:
+			gates = []Gate{{Type: GateINV, Input: input, Output: output[0]}}
+		case gateType == "EQW" && inputCount == 1 && outputCount == 1:
+			gates = []Gate{{Type: GateEQW, Input: input, Output: output[0]}}
+		case gateType == "EQ" && inputCount == 1 && outputCount == 1:
+			if input[0] != 0 && input[0] != 1 {
+				return Circuit{}, p.errorf("invalid constant %d", input[0])
+			}
+			gates = []Gate{{Type: GateEQ, Input: input, Output: output[0]}}
+		case gateType == "MAND" && inputCount == 2*outputCount:
+			gates = make([]Gate, outputCount)
+			for j := range gates {
+				gates[j] = Gate{Type: GateAND, Input: []int{input[j], input[j+outputCount]}, Output: output[j]}
+			}
+		default:
+			return Circuit{}, p.errorf("unsupported gate %s with %d inputs and %d outputs", gateType, inputCount, outputCount)
+		}
+
+		for _, g := range gates {
+			if g.Type != GateEQ {
+				for _, w := range g.Input {
+					if !defined[w] {
+						return Circuit{}, p.errorf("undefined wire %d", w)
+					}
+				}
+			}
+			if g.Output < c.InputWireCount() {
+				return Circuit{}, p.errorf("gate output %d is an input wire", g.Output)
+			}
+			if defined[g.Output] {
+				return Circuit{}, p.errorf("wire %d assigned twice", g.Output)
+			}
+			defined[g.Output] = true
+		}
+		c.Gates = append(c.Gates, gates...)
+	}
+
+	if _, err := p.nextFields(); err != io.ErrUnexpectedEOF {
+		if err != nil {
+			return Circuit{}, err
+		}
+		return Circuit{}, p.errorf("more gates than %d", gateCount)
+	}
+
+	// Every wire is either an input or the output of exactly one gate,
+	// so larger wire counts would only allocate unused wires in Compile.
+	if wireCount > c.InputWireCount()+len(c.Gates) {
+		return Circuit{}, fmt.Errorf("bristol: wire count %d larger than %d inputs and gate outputs", wireCount, c.InputWireCount()+len(c.Gates))
+	}
+
+	for i := wireCount - c.OutputWireCount(); i < wireCount; i++ {
+		if !defined[i] {
+			return Circuit{}, fmt.Errorf("bristol: undefined output wire %d", i)
+		}
+	}
+
+	return c, nil
+}
+
+// bristolParser reads a Bristol Fashion file line by line.
+type bristolParser struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// errorf returns a parse error at the current line.
+func (p *bristolParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("bristol: line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// nextFields returns the fields of the next non-empty line.
+func (p *bristolParser) nextFields() ([]string, error) {
+	for p.scanner.Scan() {
+		p.line++
+		if fields := strings.Fields(p.scanner.Text()); len(fields) > 0 {
+			return fields, nil
+		}
+	}
+	if err := p.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// nextInts returns the integers in the next non-empty line.
+func (p *bristolParser) nextInts() ([]int, error) {
+	fields, err := p.nextFields()
+	if err != nil {
+		return nil, err
+	}
+
+	ints := make([]int, len(fields))
+	for i, f := range fields {
+		if ints[i], err = strconv.Atoi(f); err != nil || ints[i] < 0 {
+			return nil, p.errorf("invalid integer %q", f)
+		}
+	}
+	return ints, nil
+}
+
+// nextSizes returns the sizes in the next non-empty line,
+// which starts with the number of sizes.
+func (p *bristolParser) nextSizes() ([]int, error) {
+	ints, err := p.nextInts()
+	if err != nil {
+		return nil, err
+	}
+	if len(ints) == 0 || len(ints) != ints[0]+1 {
+		return nil, p.errorf("invalid sizes")
+	}
+	return ints[1:], nil
+}
@@ -0,0 +1,275 @@
+// Package circuit implements evaluation of boolean circuits
+// on top of the binary TFHE scheme.
+//
+// Circuits can be parsed from netlist formats such as Bristol Fashion, BLIF and structural Verilog,
+// and evaluated gate-by-gate using [tfhe.BinaryEvaluator].
+// For faster evaluation, circuits can be compiled into a [Program] using [Compile],
+// which runs on both [tfhe.BinaryEvaluator] and [mktfhe.BinaryEvaluator].
+package circuit
+
+// GateType is an enum type for the type of a gate.
+type GateType int
+
+const (
+	// GateAND is a two-input AND gate.
+	GateAND GateType = iota
+	// GateNAND is a two-input NAND gate.
+	GateNAND
+	// GateOR is a two-input OR gate.
+	GateOR
+	// GateNOR is a two-input NOR gate.
+	GateNOR
+	// GateXOR is a two-input XOR gate.
+	GateXOR
+	// GateXNOR is a two-input XNOR gate.
+	GateXNOR
+	// GateANDNY is a two-input gate computing (NOT in0) AND in1.
+	GateANDNY
+	// GateANDYN is a two-input gate computing in0 AND (NOT in1).
+	GateANDYN
+	// GateORNY is a two-input gate computing (NOT in0) OR in1.
+	GateORNY
+	// GateORYN is a two-input gate computing in0 OR (NOT in1).
+	GateORYN
+	// GateMUX is a three-input gate computing in0 ? in1 : in2.
+	GateMUX
+	// GateMAJ is a three-input gate computing the majority of in0, in1 and in2.
+	GateMAJ
+	// GateINV is a one-input NOT gate.
+	GateINV
+	// GateEQW is a one-input gate that copies its input wire.
+	GateEQW
+	// GateEQ is a gate that outputs a constant.
+	// Its only input is the constant 0 or 1, not a wire.
+	GateEQ
+)
+
+// gateTypeNames is the names of gate types.
+var gateTypeNames = [...]string{
+	GateAND:   "AND",
+	GateNAND:  "NAND",
+	GateOR:    "OR",
+	GateNOR:   "NOR",
+	GateXOR:   "XOR",
+	GateXNOR:  "XNOR",
+	GateANDNY: "ANDNY",
+	GateANDYN: "ANDYN",
+	GateORNY:  "ORNY",
+	GateORYN:  "ORYN",
+	GateMUX:   "MUX",
+	GateMAJ:   "MAJ",
+	GateINV:   "INV",
+	GateEQW:   "EQW",
+	GateEQ:    "EQ",
+}
+
+// String implements the [fmt.Stringer] interface.
+func (t GateType) String() string {
+	if t < 0 || int(t) >= len(gateTypeNames) {
+		return "UNKNOWN"
+	}
+	return gateTypeNames[t]
+}
+
+// InputCount returns the number of inputs of this gate type.
+func (t GateType) InputCount() int {
+	switch t {
+	case GateMUX, GateMAJ:
+		return 3
+	case GateINV, GateEQW, GateEQ:
+		return 1
+	}
+	return 2
+}
+
+// IsFree returns true if this gate type can be evaluated without bootstrapping.
+func (t GateType) IsFree() bool {
+	switch t {
+	case GateINV, GateEQW, GateEQ:
+		return true
+	}
+	return false
+}
+
+// EvaluateBool evaluates this gate type on plaintext inputs.
+// For GateEQ, the input is the constant.
+func (t GateType) EvaluateBool(in ...bool) bool {
+	switch t {
+	case GateAND:
+		return in[0] && in[1]
+	case GateNAND:
+		return !(in[0] && in[1])
+	case GateOR:
+		return in[0] || in[1]
+	case GateNOR:
+		return !(in[0] || in[1])
+	case GateXOR:
+		return in[0] != in[1]
+	case GateXNOR:
+		return in[0] == in[1]
+	case GateANDNY:
+		return !in[0] && in[1]
+	case GateANDYN:
+		return in[0] && !in[1]
+	case GateORNY:
+		return !in[0] || in[1]
+	case GateORYN:
+		return in[0] || !in[1]
+	case GateMUX:
+		if in[0] {
+			return in[1]
+		}
+		return in[2]
+	case GateMAJ:
+		return in[0] && in[1] || in[1] && in[2] || in[2] && in[0]
+	case GateINV:
+		return !in[0]
+	case GateEQW, GateEQ:
+		return in[0]
+	}
+	panic("invalid gate type")
+}
+
+// Gate is a gate in a boolean circuit.
+type Gate struct {
+	// Type is the type of this gate.
+	Type GateType
+	// Input is the input wires of this gate.
+	// For GateEQ, Input[0] is the constant 0 or 1.
+	Input []int
+	// Output is the output wire of this gate.
+	Output int
+}
+
+// Circuit is a boolean circuit.
+//
+// Wires are numbered from 0 to WireCount - 1.
+// The input wires come first, in the order of InputSizes,
+// and the output wires come last, in the order of OutputSizes.
+// Gates are sorted topologically, so that every input wire of a gate
+// is either an input wire of the circuit or an output wire of a previous gate.
+type Circuit struct {
+	// WireCount is the number of wires.
+	WireCount int
+	// InputSizes is the number of wires of each input.
+	InputSizes []int
+	// OutputSizes is the number of wires of each output.
+	OutputSizes []int
+	// Gates is the gates of this circuit.
+	Gates []Gate
+
+	// InputNames is the names of each input.
+	// It is nil if the inputs are not named.
+	InputNames []string
+	// OutputNames is the names of each output.
+	// It is nil if the outputs are not named.
+	OutputNames []string
+}
+
+// InputWireCount returns the total number of input wires.
+func (c Circuit) InputWireCount() int {
+	return sum(c.InputSizes)
+}
+
+// OutputWireCount returns the total number of output wires.
+func (c Circuit) OutputWireCount() int {
+	return sum(c.OutputSizes)
+}
+
+// BootstrapCount returns the number of gates that need bootstrapping.
+func (c Circuit) BootstrapCount() int {
+	count := 0
+	for _, g := range c.Gates {
+		if !g.Type.IsFree() {
+			count++
+		}
+	}
+	return count
+}
+
+// Layers returns the gate indices of c grouped by their depth.
+// The depth of a gate is the number of bootstrapping gates
+// on the longest path from the inputs of c to it, including itself.
+//
+// Gates in a layer are sorted topologically.
+// Bootstrapping gates in the same layer are independent of each other,
+// but free gates may depend on previous gates in the same layer.
+func (c Circuit) Layers() [][]int {
+	wireDepth := make([]int, c.WireCount)
+	gateDepth := make([]int, len(c.Gates))
+	maxDepth := 0
+	for i, g := range c.Gates {
+		depth := 0
+		if g.Type != GateEQ {
+			for _, w := range g.Input {
+				if wireDepth[w] > depth {
+					depth = wireDepth[w]
+				}
+			}
+		}
+		if !g.Type.IsFree() {
+			depth++
+		}
+
+		wireDepth[g.Output] = depth
+		gateDepth[i] = depth
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+
+	layers := make([][]int, maxDepth+1)
+	for i, depth := range gateDepth {
+		layers[depth] = append(layers[depth], i)
+	}
+	return layers
+}
+
+// EvaluateBool evaluates c on plaintext inputs.
+//
+// Panics if the input sizes do not match.
+func (c Circuit) EvaluateBool(inputs [][]bool) [][]bool {
+	if len(inputs) != len(c.InputSizes) {
+		panic("Input count mismatch")
+	}
+
+	wires := make([]bool, c.WireCount)
+	offset := 0
+	for i, input := range inputs {
+		if len(input) != c.InputSizes[i] {
+			panic("Input size mismatch")
+		}
+		copy(wires[offset:], input)
+		offset += len(input)
+	}
+
+	in := make([]bool, 3)
+	for _, g := range c.Gates {
+		if g.Type == GateEQ {
+			wires[g.Output] = g.Input[0] == 1
+			continue
+		}
+		for j, w := range g.Input {
+			in[j] = wires[w]
+		}
+		wires[g.Output] = g.Type.EvaluateBool(in[:len(g.Input)]...)
+	}
+
+	outputs := make([][]bool, len(c.OutputSizes))
+	offset = c.WireCount - c.OutputWireCount()
+	for i, size := range c.OutputSizes {
+		outputs[i] = make([]bool, size)
+		copy(outputs[i], wires[offset:offset+size])
+		offset += size
+	}
+	return outputs
+}
+
+// sum returns the sum of v.
+func sum(v []int) int {
+	s := 0
+	for _, x := range v {
+		s += x
+	}
+	return s
+}
@@ -0,0 +1,476 @@
+package circuit_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/mktfhe"
+	"github.com/sp301415/tfhe-go/tfhe"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	params = tfhe.ParamsBinary.Compile()
+	enc    = tfhe.NewBinaryEncryptor(params)
+	eval   = circuit.NewEvaluator(params, enc.GenEvalKeyParallel())
+)
+
+// majCircuit returns a circuit with inputs a, b, c and outputs
+// MAJ(~a, ~b, ~c), MAJ(a, ~b, c), MAJ(a, a, b) and MAJ(a, ~a, b).
+func majCircuit() circuit.Circuit {
+	return circuit.Circuit{
+		WireCount:   10,
+		InputSizes:  []int{1, 1, 1},
+		OutputSizes: []int{4},
+		Gates: []circuit.Gate{
+			{Type: circuit.GateINV, Input: []int{0}, Output: 3},
+			{Type: circuit.GateINV, Input: []int{1}, Output: 4},
+			{Type: circuit.GateINV, Input: []int{2}, Output: 5},
+			{Type: circuit.GateMAJ, Input: []int{3, 4, 5}, Output: 6},
+			{Type: circuit.GateMAJ, Input: []int{0, 4, 2}, Output: 7},
+			{Type: circuit.GateMAJ, Input: []int{0, 0, 1}, Output: 8},
+			{Type: circuit.GateMAJ, Input: []int{0, 3, 1}, Output: 9},
+		},
+	}
+}
+
+// adderBristol returns a Bristol Fashion circuit
+// that outputs the sum of two n-bit inputs and the negation of the first bit.
+func adderBristol(n int) string {
+	var gates []string
+	wire := 2 * n
+
+	carry := wire
+	gates = append(gates, fmt.Sprintf("1 1 0 %d EQ", carry))
+	wire++
+
+	sum := make([]int, n)
+	for i := 0; i < n; i++ {
+		t, s, u, v, c := wire, wire+1, wire+2, wire+3, wire+4
+		wire += 5
+		gates = append(gates, fmt.Sprintf("2 1 %d %d %d XOR", i, n+i, t))
+		gates = append(gates, fmt.Sprintf("2 1 %d %d %d XOR", t, carry, s))
+		gates = append(gates, fmt.Sprintf("4 2 %d %d %d %d %d %d MAND", i, t, n+i, carry, u, v))
+		gates = append(gates, fmt.Sprintf("2 1 %d %d %d XOR", u, v, c))
+		sum[i], carry = s, c
+	}
+
+	for i := 0; i < n; i++ {
+		gates = append(gates, fmt.Sprintf("1 1 %d %d EQW", sum[i], wire+i))
+	}
+	gates = append(gates, fmt.Sprintf("1 1 0 %d INV", wire+n))
+	wire += n + 1
+
+	return fmt.Sprintf("%d %d\n2 %d %d\n2 %d 1\n\n%s\n", len(gates), wire, n, n, n, strings.Join(gates, "\n"))
+}
+
+func TestParseBristol(t *testing.T) {
+	t.Run("Adder", func(t *testing.T) {
+		c, err := circuit.ParseBristol(strings.NewReader(adderBristol(4)))
+		assert.NoError(t, err)
+		assert.Equal(t, []int{4, 4}, c.InputSizes)
+		assert.Equal(t, []int{4, 1}, c.OutputSizes)
+		assert.Equal(t, 4*5, c.BootstrapCount())
+		assert.Equal(t, 1+4*5+4+1, len(c.Gates))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"1 3\n1 2\n1 1\n2 1 0 1 2 OR\n",
+			"1 3\n1 2\n1 1\n2 1 0 5 2 AND\n",
+			"1 4\n1 2\n1 1\n2 1 0 2 3 AND\n",
+			"2 3\n1 2\n1 1\n2 1 0 1 2 AND\n",
+			"9000000000000 3\n1 2\n1 1\n2 1 0 1 2 AND\n",
+			"1 4\n1 2\n1 1\n-1 4 0 1 2 AND\n",
+			"1 3\n1 2\n1 1\n2 1 0 -1 2 AND\n",
+			"1 3\n1 2\n1 1\n2 1 0 1 2 AND\n2 1 0 1 2 AND\n",
+			"1 100000000000000\n1 1\n1 1\n1 1 0 99999999999999 INV\n",
+			"2 3\n1 2\n1 1\n2 1 0 1 0 AND\n2 1 0 1 2 AND\n",
+			"2 3\n1 2\n1 1\n2 1 0 1 2 AND\n2 1 0 1 2 AND\n",
+		} {
+			_, err := circuit.ParseBristol(strings.NewReader(s))
+			assert.Error(t, err)
+		}
+	})
+}
+
+const fullAdderBLIF = `# 2-bit adder
+.model adder
+.inputs a[0] a[1] b[0] b[1]
+.outputs s[0] s[1] c
+.names a[0] b[0] s[0]
+10 1
+01 1
+.subckt $_AND_ A=a[0] B=b[0] Y=c0
+.names a[1] b[1] c0 s[1]
+100 1
+010 1
+001 1
+111 1
+.names a[1] b[1] c0 \
+c
+11- 1
+1-1 1
+-11 1
+.end
+`
+
+const fullAdderVerilog = `/* Generated by Yosys */
+module adder(a, b, s, c);
+  input [1:0] a;
+  input [1:0] b;
+  output [1:0] s;
+  output c;
+  wire c0, t;
+  (* src = "adder.v:1" *)
+  \$_AND_  _0_ (.A(a[0]), .B(b[0]), .Y(c0));
+  xor g0 (s[0], a[0], b[0]);
+  assign t = a[1] ^ b[1];
+  assign s[1] = ~(t ~^ c0);
+  assign c = t ? c0 : a[1] & 1'b1;
+endmodule
+`
+
+func TestParseBLIF(t *testing.T) {
+	t.Run("Adder", func(t *testing.T) {
+		c, err := circuit.ParseBLIF(strings.NewReader(fullAdderBLIF))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, c.InputNames)
+		assert.Equal(t, []int{2, 2}, c.InputSizes)
+		assert.Equal(t, []string{"s", "c"}, c.OutputNames)
+		assert.Equal(t, []int{2, 1}, c.OutputSizes)
+
+		for m0 := 0; m0 < 4; m0++ {
+			for m1 := 0; m1 < 4; m1++ {
+				out := c.EvaluateBool([][]bool{toBits(m0, 2), toBits(m1, 2)})
+				assert.Equal(t, m0+m1, fromBits(append(out[0], out[1]...)))
+			}
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		for _, s := range []string{
+			".model m\n.inputs a\n.outputs y\n.end\n",
+			".model m\n.inputs a\n.outputs y\n.names a y\n1 1\n.names a y\n0 1\n.end\n",
+			".model m\n.inputs a\n.outputs y\n.names a z y\n11 1\n.names y z\n1 1\n.end\n",
+			".model m\n.inputs a\n.outputs y\n.latch a y\n.end\n",
+		} {
+			_, err := circuit.ParseBLIF(strings.NewReader(s))
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestParseVerilog(t *testing.T) {
+	t.Run("Adder", func(t *testing.T) {
+		c, err := circuit.ParseVerilog(strings.NewReader(fullAdderVerilog))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, c.InputNames)
+		assert.Equal(t, []int{2, 2}, c.InputSizes)
+		assert.Equal(t, []string{"s", "c"}, c.OutputNames)
+		assert.Equal(t, []int{2, 1}, c.OutputSizes)
+
+		for m0 := 0; m0 < 4; m0++ {
+			for m1 := 0; m1 < 4; m1++ {
+				out := c.EvaluateBool([][]bool{toBits(m0, 2), toBits(m1, 2)})
+				assert.Equal(t, m0+m1, fromBits(append(out[0], out[1]...)))
+			}
+		}
+	})
+
+	t.Run("ANSI", func(t *testing.T) {
+		c, err := circuit.ParseVerilog(strings.NewReader(`
+module m(input [3:0] x, input y, output [3:0] z);
+  assign z = {x[2:0], y} ^ {4{y}};
+endmodule
+`))
+		assert.NoError(t, err)
+		for m := 0; m < 16; m++ {
+			for _, y := range []bool{false, true} {
+				want := (m << 1) & 0xf
+				if y {
+					want = (want | 1) ^ 0xf
+				}
+				out := c.EvaluateBool([][]bool{toBits(m, 4), {y}})
+				assert.Equal(t, want, fromBits(out[0]))
+			}
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		for _, s := range []string{
+			"module m(a, y); input a; output y; endmodule",
+			"module m(a, y); input a; output y; assign y = a; assign y = ~a; endmodule",
+			"module m(a, y); input a; output y; wire t; assign t = y & a; assign y = t; endmodule",
+			"module m(a, y); input a; output y; always @(a) y = a; endmodule",
+			"module m(a); input a",
+			"module m(a, y); input a; output y; assign y = {1",
+		} {
+			_, err := circuit.ParseVerilog(strings.NewReader(s))
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestCompile(t *testing.T) {
+	c, _ := circuit.ParseBristol(strings.NewReader(adderBristol(8)))
+	p := circuit.Compile(c)
+
+	t.Run("GateCount", func(t *testing.T) {
+		assert.LessOrEqual(t, p.GateCount(), c.BootstrapCount())
+		assert.LessOrEqual(t, p.Depth(), len(c.Layers()))
+	})
+
+	t.Run("EvaluateBool", func(t *testing.T) {
+		for i := 0; i < 16; i++ {
+			in := [][]bool{toBits(rand.Intn(256), 8), toBits(rand.Intn(256), 8)}
+			assert.Equal(t, c.EvaluateBool(in), p.EvaluateBool(in))
+		}
+	})
+
+	t.Run("Constant", func(t *testing.T) {
+		c, _ := circuit.ParseVerilog(strings.NewReader(`
+module m(input a, input b, output [3:0] y);
+  assign y[0] = a & ~a;
+  assign y[1] = ~(a | 1'b0);
+  assign y[2] = a ^ a ^ b;
+  assign y[3] = 1'b1 ? b : a;
+endmodule
+`))
+		p := circuit.Compile(c)
+		assert.Equal(t, 0, p.GateCount())
+		for m := 0; m < 4; m++ {
+			in := [][]bool{{m&1 == 1}, {m&2 == 2}}
+			assert.Equal(t, c.EvaluateBool(in), p.EvaluateBool(in))
+		}
+	})
+
+	t.Run("MUX", func(t *testing.T) {
+		c, _ := circuit.ParseVerilog(strings.NewReader(`
+module m(input s, input a, input b, output [1:0] y);
+  assign y[0] = ~s ? ~a : b;
+  assign y[1] = s ? a & b : ~(a | b);
+endmodule
+`))
+		p := circuit.Compile(c)
+		for m := 0; m < 8; m++ {
+			in := [][]bool{{m&1 == 1}, {m&2 == 2}, {m&4 == 4}}
+			assert.Equal(t, c.EvaluateBool(in), p.EvaluateBool(in))
+		}
+	})
+
+	t.Run("MAJ", func(t *testing.T) {
+		c := majCircuit()
+		p := circuit.Compile(c)
+		assert.Equal(t, 2, p.GateCount())
+		for m := 0; m < 8; m++ {
+			in := [][]bool{{m&1 == 1}, {m&2 == 2}, {m&4 == 4}}
+			assert.Equal(t, c.EvaluateBool(in), p.EvaluateBool(in))
+		}
+	})
+}
+
+func TestCircuit(t *testing.T) {
+	c, _ := circuit.ParseBristol(strings.NewReader(adderBristol(4)))
+
+	t.Run("Layers", func(t *testing.T) {
+		layers := c.Layers()
+		count := 0
+		for _, layer := range layers {
+			count += len(layer)
+		}
+		assert.Equal(t, len(c.Gates), count)
+		assert.Equal(t, 2*4+2, len(layers))
+	})
+
+	t.Run("EvaluateBool", func(t *testing.T) {
+		for i := 0; i < 8; i++ {
+			m0, m1 := rand.Intn(16), rand.Intn(16)
+			out := c.EvaluateBool([][]bool{toBits(m0, 4), toBits(m1, 4)})
+			assert.Equal(t, (m0+m1)%16, fromBits(out[0]))
+			assert.Equal(t, m0&1 == 0, out[1][0])
+		}
+	})
+}
+
+func TestEvaluator(t *testing.T) {
+	bits := 4
+	c, _ := circuit.ParseBristol(strings.NewReader(adderBristol(bits)))
+
+	m0, m1 := rand.Intn(1<<bits), rand.Intn(1<<bits)
+	ct0 := enc.EncryptLWEBits(m0, bits)
+	ct1 := enc.EncryptLWEBits(m1, bits)
+
+	t.Run("Evaluate", func(t *testing.T) {
+		ctOut := eval.Evaluate(c, [][]tfhe.LWECiphertext[uint32]{ct0, ct1})
+		assert.Equal(t, (m0+m1)%(1<<bits), enc.DecryptLWEBits(ctOut[0]))
+		assert.Equal(t, m0&1 == 0, enc.DecryptLWEBool(ctOut[1][0]))
+	})
+
+	t.Run("EvaluateParallel", func(t *testing.T) {
+		ctOut := eval.EvaluateParallel(c, [][]tfhe.LWECiphertext[uint32]{ct0, ct1})
+		assert.Equal(t, (m0+m1)%(1<<bits), enc.DecryptLWEBits(ctOut[0]))
+		assert.Equal(t, m0&1 == 0, enc.DecryptLWEBool(ctOut[1][0]))
+	})
+}
+
+func TestEvaluatorProgram(t *testing.T) {
+	bits := 4
+	c, _ := circuit.ParseBristol(strings.NewReader(adderBristol(bits)))
+	p := circuit.Compile(c)
+
+	m0, m1 := rand.Intn(1<<bits), rand.Intn(1<<bits)
+	ct0 := enc.EncryptLWEBits(m0, bits)
+	ct1 := enc.EncryptLWEBits(m1, bits)
+
+	t.Run("EvaluateProgram", func(t *testing.T) {
+		ctOut := eval.EvaluateProgram(p, [][]tfhe.LWECiphertext[uint32]{ct0, ct1})
+		assert.Equal(t, (m0+m1)%(1<<bits), enc.DecryptLWEBits(ctOut[0]))
+		assert.Equal(t, m0&1 == 0, enc.DecryptLWEBool(ctOut[1][0]))
+	})
+
+	t.Run("EvaluateProgramParallel", func(t *testing.T) {
+		ctOut := eval.EvaluateProgramParallel(p, [][]tfhe.LWECiphertext[uint32]{ct0, ct1})
+		assert.Equal(t, (m0+m1)%(1<<bits), enc.DecryptLWEBits(ctOut[0]))
+		assert.Equal(t, m0&1 == 0, enc.DecryptLWEBool(ctOut[1][0]))
+	})
+
+	t.Run("MUX", func(t *testing.T) {
+		c, _ := circuit.ParseVerilog(strings.NewReader(`
+module m(input s, input a, input b, output y);
+  assign y = ~s ? ~a : b;
+endmodule
+`))
+		p := circuit.Compile(c)
+		for m := 0; m < 8; m++ {
+			in := [][]bool{{m&1 == 1}, {m&2 == 2}, {m&4 == 4}}
+			ctIn := [][]tfhe.LWECiphertext[uint32]{
+				{enc.EncryptLWEBool(in[0][0])},
+				{enc.EncryptLWEBool(in[1][0])},
+				{enc.EncryptLWEBool(in[2][0])},
+			}
+			ctOut := eval.EvaluateProgram(p, ctIn)
+			assert.Equal(t, c.EvaluateBool(in)[0][0], enc.DecryptLWEBool(ctOut[0][0]))
+		}
+	})
+
+	t.Run("MAJ", func(t *testing.T) {
+		c := majCircuit()
+		p := circuit.Compile(c)
+		for m := 0; m < 8; m++ {
+			in := [][]bool{{m&1 == 1}, {m&2 == 2}, {m&4 == 4}}
+			ctIn := [][]tfhe.LWECiphertext[uint32]{
+				{enc.EncryptLWEBool(in[0][0])},
+				{enc.EncryptLWEBool(in[1][0])},
+				{enc.EncryptLWEBool(in[2][0])},
+			}
+			ctOut := eval.EvaluateProgram(p, ctIn)
+			for i, b := range c.EvaluateBool(in)[0] {
+				assert.Equal(t, b, enc.DecryptLWEBool(ctOut[0][i]))
+			}
+		}
+	})
+}
+
+func TestMultiKeyEvaluator(t *testing.T) {
+	params := mktfhe.ParamsBinaryParty2.Compile()
+	enc := []*mktfhe.BinaryEncryptor[uint64]{
+		mktfhe.NewBinaryEncryptor(params, 0, nil),
+		mktfhe.NewBinaryEncryptor(params, 1, nil),
+	}
+	eval := circuit.NewMultiKeyEvaluator(params, map[int]mktfhe.EvaluationKey[uint64]{
+		0: enc[0].GenEvalKeyParallel(),
+		1: enc[1].GenEvalKeyParallel(),
+	})
+	dec := mktfhe.NewBinaryDecryptor(params, map[int]tfhe.SecretKey[uint64]{
+		0: enc[0].Encryptor.SecretKey,
+		1: enc[1].Encryptor.SecretKey,
+	})
+
+	bits := 2
+	c, _ := circuit.ParseBristol(strings.NewReader(adderBristol(bits)))
+	p := circuit.Compile(c)
+
+	m0, m1 := rand.Intn(1<<bits), rand.Intn(1<<bits)
+	ctIn := [][]mktfhe.LWECiphertext[uint64]{make([]mktfhe.LWECiphertext[uint64], bits), make([]mktfhe.LWECiphertext[uint64], bits)}
+	for i := 0; i < bits; i++ {
+		ctIn[0][i] = enc[0].EncryptLWEBool(m0>>i&1 == 1)
+		ctIn[1][i] = enc[1].EncryptLWEBool(m1>>i&1 == 1)
+	}
+
+	ctOut := eval.EvaluateProgramParallel(p, ctIn)
+	out := make([]bool, bits)
+	for i := range out {
+		out[i] = dec.DecryptLWEBool(ctOut[0][i])
+	}
+	assert.Equal(t, (m0+m1)%(1<<bits), fromBits(out))
+	assert.Equal(t, m0&1 == 0, dec.DecryptLWEBool(ctOut[1][0]))
+}
+
+func BenchmarkEvaluateParallel(b *testing.B) {
+	bits := 16
+	c, _ := circuit.ParseBristol(strings.NewReader(adderBristol(bits)))
+
+	ct0 := enc.EncryptLWEBits(rand.Int(), bits)
+	ct1 := enc.EncryptLWEBits(rand.Int(), bits)
+	ctOut := [][]tfhe.LWECiphertext[uint32]{enc.EncryptLWEBits(0, bits), enc.EncryptLWEBits(0, 1)}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		eval.EvaluateParallelTo(ctOut, c, [][]tfhe.LWECiphertext[uint32]{ct0, ct1})
+	}
+}
+
+func ExampleEvaluator() {
+	params := tfhe.ParamsBinary.Compile()
+
+	enc := tfhe.NewBinaryEncryptor(params)
+
+	// A circuit computing (a AND b) XOR (NOT c).
+	c, err := circuit.ParseBristol(strings.NewReader(`3 6
+3 1 1 1
+1 1
+
+2 1 0 1 3 AND
+1 1 2 4 INV
+2 1 3 4 5 XOR
+`))
+	if err != nil {
+		panic(err)
+	}
+
+	eval := circuit.NewEvaluator(params, enc.GenEvalKeyParallel())
+
+	inputs := [][]tfhe.LWECiphertext[uint32]{
+		{enc.EncryptLWEBool(true)},
+		{enc.EncryptLWEBool(true)},
+		{enc.EncryptLWEBool(true)},
+	}
+	ctOut := eval.Evaluate(c, inputs)
+
+	fmt.Println(enc.DecryptLWEBool(ctOut[0][0]))
+	// Output:
+	// true
+}
+
+func toBits(m, n int) []bool {
+	bits := make([]bool, n)
+	for i := range bits {
+		bits[i] = m>>i&1 == 1
+	}
+	return bits
+}
+
+func fromBits(bits []bool) int {
+	m := 0
+	for i, b := range bits {
+		if b {
+			m |= 1 << i
+		}
+	}
+	return m
+}
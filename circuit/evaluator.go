@@ -0,0 +1,334 @@
+package circuit
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// Evaluator evaluates boolean circuits on binary TFHE ciphertexts.
+// All LWE ciphertexts should be encrypted with [tfhe.BinaryEncryptor].
+// This is meant to be public, usually for servers.
+//
+// Evaluator is not safe for concurrent use.
+// Use [Evaluator.SafeCopy] to get a safe copy.
+type Evaluator[T tfhe.TorusInt] struct {
+	// BinaryEvaluator is a BinaryEvaluator for this Evaluator.
+	BinaryEvaluator *tfhe.BinaryEvaluator[T]
+	// Params is the parameter set for this Evaluator.
+	Params tfhe.Parameters[T]
+
+	// evaluatorPool is a pool of BinaryEvaluators for parallel evaluation.
+	// It is allocated on the first parallel evaluation.
+	evaluatorPool []*tfhe.BinaryEvaluator[T]
+}
+
+// NewEvaluator creates a new [Evaluator].
+// This does not copy evaluation keys, since they are large.
+func NewEvaluator[T tfhe.TorusInt](params tfhe.Parameters[T], evk tfhe.EvaluationKey[T]) *Evaluator[T] {
+	return &Evaluator[T]{
+		BinaryEvaluator: tfhe.NewBinaryEvaluator(params, evk),
+		Params:          params,
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *Evaluator[T]) SafeCopy() *Evaluator[T] {
+	return &Evaluator[T]{
+		BinaryEvaluator: e.BinaryEvaluator.SafeCopy(),
+		Params:          e.Params,
+	}
+}
+
+// Evaluate evaluates c on inputs and returns the outputs.
+//
+// Panics if the input sizes do not match.
+func (e *Evaluator[T]) Evaluate(c Circuit, inputs [][]tfhe.LWECiphertext[T]) [][]tfhe.LWECiphertext[T] {
+	outputs := e.newCiphertexts(c.OutputSizes)
+	e.EvaluateTo(outputs, c, inputs)
+	return outputs
+}
+
+// EvaluateTo evaluates c on inputs and writes the outputs to ctOut.
+//
+// Panics if the input or output sizes do not match.
+func (e *Evaluator[T]) EvaluateTo(ctOut [][]tfhe.LWECiphertext[T], c Circuit, inputs [][]tfhe.LWECiphertext[T]) {
+	wires := e.newWires(c, inputs)
+	for i := range c.Gates {
+		e.evaluateGateTo(e.BinaryEvaluator, wires, c.Gates[i])
+	}
+	e.copyOutputs(ctOut, c, wires)
+}
+
+// EvaluateParallel evaluates c on inputs in parallel and returns the outputs.
+//
+// Panics if the input sizes do not match.
+func (e *Evaluator[T]) EvaluateParallel(c Circuit, inputs [][]tfhe.LWECiphertext[T]) [][]tfhe.LWECiphertext[T] {
+	outputs := e.newCiphertexts(c.OutputSizes)
+	e.EvaluateParallelTo(outputs, c, inputs)
+	return outputs
+}
+
+// EvaluateParallelTo evaluates c on inputs in parallel and writes the outputs to ctOut.
+//
+// Gates are evaluated layer by layer, as returned by [Circuit.Layers].
+// Bootstrapping gates of each layer are distributed across copies of the BinaryEvaluator,
+// and then free gates are evaluated sequentially.
+//
+// Panics if the input or output sizes do not match.
+func (e *Evaluator[T]) EvaluateParallelTo(ctOut [][]tfhe.LWECiphertext[T], c Circuit, inputs [][]tfhe.LWECiphertext[T]) {
+	wires := e.newWires(c, inputs)
+	for _, layer := range c.Layers() {
+		var gates, freeGates []Gate
+		for _, i := range layer {
+			if c.Gates[i].Type.IsFree() {
+				freeGates = append(freeGates, c.Gates[i])
+			} else {
+				gates = append(gates, c.Gates[i])
+			}
+		}
+
+		e.evaluateGatesParallel(wires, gates)
+		for _, g := range freeGates {
+			e.evaluateGateTo(e.BinaryEvaluator, wires, g)
+		}
+	}
+	e.copyOutputs(ctOut, c, wires)
+}
+
+// EvaluateProgram evaluates p on inputs and returns the outputs.
+//
+// Panics if the input sizes do not match.
+func (e *Evaluator[T]) EvaluateProgram(p *Program, inputs [][]tfhe.LWECiphertext[T]) [][]tfhe.LWECiphertext[T] {
+	outputs := e.newCiphertexts(p.OutputSizes)
+	e.EvaluateProgramTo(outputs, p, inputs)
+	return outputs
+}
+
+// EvaluateProgramTo evaluates p on inputs and writes the outputs to ctOut.
+//
+// Panics if the input or output sizes do not match.
+func (e *Evaluator[T]) EvaluateProgramTo(ctOut [][]tfhe.LWECiphertext[T], p *Program, inputs [][]tfhe.LWECiphertext[T]) {
+	wires := e.newProgramWires(p, inputs)
+	for _, level := range p.Levels {
+		for _, g := range level.Gates {
+			e.evaluateGateTo(e.BinaryEvaluator, wires, g)
+		}
+		for _, g := range level.FreeGates {
+			e.evaluateGateTo(e.BinaryEvaluator, wires, g)
+		}
+	}
+	copyProgramOutputs(ctOut, p, wires)
+}
+
+// EvaluateProgramParallel evaluates p on inputs in parallel and returns the outputs.
+//
+// Panics if the input sizes do not match.
+func (e *Evaluator[T]) EvaluateProgramParallel(p *Program, inputs [][]tfhe.LWECiphertext[T]) [][]tfhe.LWECiphertext[T] {
+	outputs := e.newCiphertexts(p.OutputSizes)
+	e.EvaluateProgramParallelTo(outputs, p, inputs)
+	return outputs
+}
+
+// EvaluateProgramParallelTo evaluates p on inputs in parallel and writes the outputs to ctOut.
+//
+// Bootstrapping gates of each level are distributed across copies of the BinaryEvaluator,
+// and then free gates are evaluated sequentially.
+//
+// Panics if the input or output sizes do not match.
+func (e *Evaluator[T]) EvaluateProgramParallelTo(ctOut [][]tfhe.LWECiphertext[T], p *Program, inputs [][]tfhe.LWECiphertext[T]) {
+	wires := e.newProgramWires(p, inputs)
+	for _, level := range p.Levels {
+		e.evaluateGatesParallel(wires, level.Gates)
+		for _, g := range level.FreeGates {
+			e.evaluateGateTo(e.BinaryEvaluator, wires, g)
+		}
+	}
+	copyProgramOutputs(ctOut, p, wires)
+}
+
+// evaluateGatesParallel evaluates independent gates in parallel
+// using the pool of BinaryEvaluators.
+func (e *Evaluator[T]) evaluateGatesParallel(wires []tfhe.LWECiphertext[T], gates []Gate) {
+	if e.evaluatorPool == nil {
+		e.evaluatorPool = make([]*tfhe.BinaryEvaluator[T], runtime.NumCPU())
+		for i := range e.evaluatorPool {
+			e.evaluatorPool[i] = e.BinaryEvaluator.SafeCopy()
+		}
+	}
+
+	parallelFor(len(e.evaluatorPool), len(gates), func(worker, i int) {
+		e.evaluateGateTo(e.evaluatorPool[worker], wires, gates[i])
+	})
+}
+
+// parallelFor calls f(worker, i) for i in [0, jobCount) using at most workerCount goroutines,
+// where worker is the index of the goroutine.
+func parallelFor(workerCount, jobCount int, f func(worker, i int)) {
+	if jobCount < workerCount {
+		workerCount = jobCount
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := 0; i < jobCount; i++ {
+			jobs <- i
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workerCount)
+	for i := 0; i < workerCount; i++ {
+		go func(worker int) {
+			for i := range jobs {
+				f(worker, i)
+			}
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+}
+
+// newCiphertexts allocates ciphertexts of given sizes.
+func (e *Evaluator[T]) newCiphertexts(sizes []int) [][]tfhe.LWECiphertext[T] {
+	cts := make([][]tfhe.LWECiphertext[T], len(sizes))
+	for i, size := range sizes {
+		cts[i] = make([]tfhe.LWECiphertext[T], size)
+		for j := range cts[i] {
+			cts[i][j] = tfhe.NewLWECiphertext(e.Params)
+		}
+	}
+	return cts
+}
+
+// newWires allocates the wires of c and copies inputs to the input wires.
+// Other wires are allocated when a gate writes to them.
+func (e *Evaluator[T]) newWires(c Circuit, inputs [][]tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	if len(inputs) != len(c.InputSizes) {
+		panic("Input count mismatch")
+	}
+
+	wires := make([]tfhe.LWECiphertext[T], c.WireCount)
+	offset := 0
+	for i, input := range inputs {
+		if len(input) != c.InputSizes[i] {
+			panic("Input size mismatch")
+		}
+		for _, ct := range input {
+			wires[offset] = ct.Copy()
+			offset++
+		}
+	}
+
+	for _, g := range c.Gates {
+		if wires[g.Output].Value == nil {
+			wires[g.Output] = tfhe.NewLWECiphertext(e.Params)
+		}
+	}
+	return wires
+}
+
+// newProgramWires allocates the wires of p.
+// The input wires are not copied, since programs never write to them.
+func (e *Evaluator[T]) newProgramWires(p *Program, inputs [][]tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	wires := make([]tfhe.LWECiphertext[T], p.WireCount)
+	setProgramInputs(wires, p, inputs)
+	for i := p.InputWireCount(); i < p.WireCount; i++ {
+		wires[i] = tfhe.NewLWECiphertext(e.Params)
+	}
+	return wires
+}
+
+// setProgramInputs sets the input wires of p to inputs.
+func setProgramInputs[C any](wires []C, p *Program, inputs [][]C) {
+	if len(inputs) != len(p.InputSizes) {
+		panic("Input count mismatch")
+	}
+
+	offset := 0
+	for i, input := range inputs {
+		if len(input) != p.InputSizes[i] {
+			panic("Input size mismatch")
+		}
+		offset += copy(wires[offset:], input)
+	}
+}
+
+// copyProgramOutputs copies the output wires of p to ctOut.
+func copyProgramOutputs[C any, PC interface {
+	*C
+	CopyFrom(C)
+}](ctOut [][]C, p *Program, wires []C) {
+	if len(ctOut) != len(p.OutputSizes) {
+		panic("Output count mismatch")
+	}
+
+	offset := 0
+	for i, size := range p.OutputSizes {
+		if len(ctOut[i]) != size {
+			panic("Output size mismatch")
+		}
+		for j := 0; j < size; j++ {
+			PC(&ctOut[i][j]).CopyFrom(wires[p.Outputs[offset]])
+			offset++
+		}
+	}
+}
+
+// copyOutputs copies the output wires of c to ctOut.
+func (e *Evaluator[T]) copyOutputs(ctOut [][]tfhe.LWECiphertext[T], c Circuit, wires []tfhe.LWECiphertext[T]) {
+	if len(ctOut) != len(c.OutputSizes) {
+		panic("Output count mismatch")
+	}
+
+	offset := c.WireCount - c.OutputWireCount()
+	for i, size := range c.OutputSizes {
+		if len(ctOut[i]) != size {
+			panic("Output size mismatch")
+		}
+		for j := 0; j < size; j++ {
+			ctOut[i][j].CopyFrom(wires[offset])
+			offset++
+		}
+	}
+}
+
+// evaluateGateTo evaluates g using eval and writes the output to wires.
+func (e *Evaluator[T]) evaluateGateTo(eval *tfhe.BinaryEvaluator[T], wires []tfhe.LWECiphertext[T], g Gate) {
+	ctOut := wires[g.Output]
+	switch g.Type {
+	case GateAND:
+		eval.ANDTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateNAND:
+		eval.NANDTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateOR:
+		eval.ORTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateNOR:
+		eval.NORTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateXOR:
+		eval.XORTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateXNOR:
+		eval.XNORTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateANDNY:
+		eval.ANDNYTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateANDYN:
+		eval.ANDYNTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateORNY:
+		eval.ORNYTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateORYN:
+		eval.ORYNTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateMUX:
+		eval.MUXTo(ctOut, wires[g.Input[0]], wires[g.Input[1]], wires[g.Input[2]])
+	case GateMAJ:
+		eval.MAJTo(ctOut, wires[g.Input[0]], wires[g.Input[1]], wires[g.Input[2]])
+	case GateINV:
+		eval.NOTTo(ctOut, wires[g.Input[0]])
+	case GateEQW:
+		ctOut.CopyFrom(wires[g.Input[0]])
+	case GateEQ:
+		ctOut.Clear()
+		ctOut.Value[0] = eval.EncodeLWEBool(g.Input[0] == 1).Value
+	}
+}
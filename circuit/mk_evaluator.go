@@ -0,0 +1,164 @@
+package circuit
+
+import (
+	"runtime"
+
+	"github.com/sp301415/tfhe-go/mktfhe"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// MultiKeyEvaluator evaluates compiled programs on multi-key binary TFHE ciphertexts.
+// All LWE ciphertexts should be encrypted with [mktfhe.BinaryEncryptor].
+// This is meant to be public, usually for servers.
+//
+// MultiKeyEvaluator is not safe for concurrent use.
+// Use [MultiKeyEvaluator.SafeCopy] to get a safe copy.
+type MultiKeyEvaluator[T tfhe.TorusInt] struct {
+	// BinaryEvaluator is a BinaryEvaluator for this MultiKeyEvaluator.
+	BinaryEvaluator *mktfhe.BinaryEvaluator[T]
+	// Params is the parameter set for this MultiKeyEvaluator.
+	Params mktfhe.Parameters[T]
+
+	// evaluatorPool is a pool of BinaryEvaluators for parallel evaluation.
+	// It is allocated on the first parallel evaluation.
+	evaluatorPool []*mktfhe.BinaryEvaluator[T]
+}
+
+// NewMultiKeyEvaluator creates a new [MultiKeyEvaluator].
+// This does not copy evaluation keys, since they are large.
+func NewMultiKeyEvaluator[T tfhe.TorusInt](params mktfhe.Parameters[T], evk map[int]mktfhe.EvaluationKey[T]) *MultiKeyEvaluator[T] {
+	return &MultiKeyEvaluator[T]{
+		BinaryEvaluator: mktfhe.NewBinaryEvaluator(params, evk),
+		Params:          params,
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *MultiKeyEvaluator[T]) SafeCopy() *MultiKeyEvaluator[T] {
+	return &MultiKeyEvaluator[T]{
+		BinaryEvaluator: e.BinaryEvaluator.SafeCopy(),
+		Params:          e.Params,
+	}
+}
+
+// EvaluateProgram evaluates p on inputs and returns the outputs.
+//
+// Panics if the input sizes do not match.
+func (e *MultiKeyEvaluator[T]) EvaluateProgram(p *Program, inputs [][]mktfhe.LWECiphertext[T]) [][]mktfhe.LWECiphertext[T] {
+	outputs := e.newCiphertexts(p.OutputSizes)
+	e.EvaluateProgramTo(outputs, p, inputs)
+	return outputs
+}
+
+// EvaluateProgramTo evaluates p on inputs and writes the outputs to ctOut.
+//
+// Panics if the input or output sizes do not match.
+func (e *MultiKeyEvaluator[T]) EvaluateProgramTo(ctOut [][]mktfhe.LWECiphertext[T], p *Program, inputs [][]mktfhe.LWECiphertext[T]) {
+	wires := e.newProgramWires(p, inputs)
+	for _, level := range p.Levels {
+		for _, g := range level.Gates {
+			e.evaluateGateTo(e.BinaryEvaluator, wires, g)
+		}
+		for _, g := range level.FreeGates {
+			e.evaluateGateTo(e.BinaryEvaluator, wires, g)
+		}
+	}
+	copyProgramOutputs(ctOut, p, wires)
+}
+
+// EvaluateProgramParallel evaluates p on inputs in parallel and returns the outputs.
+//
+// Panics if the input sizes do not match.
+func (e *MultiKeyEvaluator[T]) EvaluateProgramParallel(p *Program, inputs [][]mktfhe.LWECiphertext[T]) [][]mktfhe.LWECiphertext[T] {
+	outputs := e.newCiphertexts(p.OutputSizes)
+	e.EvaluateProgramParallelTo(outputs, p, inputs)
+	return outputs
+}
+
+// EvaluateProgramParallelTo evaluates p on inputs in parallel and writes the outputs to ctOut.
+//
+// Bootstrapping gates of each level are distributed across copies of the BinaryEvaluator,
+// and then free gates are evaluated sequentially.
+//
+// Panics if the input or output sizes do not match.
+func (e *MultiKeyEvaluator[T]) EvaluateProgramParallelTo(ctOut [][]mktfhe.LWECiphertext[T], p *Program, inputs [][]mktfhe.LWECiphertext[T]) {
+	if e.evaluatorPool == nil {
+		e.evaluatorPool = make([]*mktfhe.BinaryEvaluator[T], runtime.NumCPU())
+		for i := range e.evaluatorPool {
+			e.evaluatorPool[i] = e.BinaryEvaluator.SafeCopy()
+		}
+	}
+
+	wires := e.newProgramWires(p, inputs)
+	for _, level := range p.Levels {
+		gates := level.Gates
+		parallelFor(len(e.evaluatorPool), len(gates), func(worker, i int) {
+			e.evaluateGateTo(e.evaluatorPool[worker], wires, gates[i])
+		})
+		for _, g := range level.FreeGates {
+			e.evaluateGateTo(e.BinaryEvaluator, wires, g)
+		}
+	}
+	copyProgramOutputs(ctOut, p, wires)
+}
+
+// newCiphertexts allocates ciphertexts of given sizes.
+func (e *MultiKeyEvaluator[T]) newCiphertexts(sizes []int) [][]mktfhe.LWECiphertext[T] {
+	cts := make([][]mktfhe.LWECiphertext[T], len(sizes))
+	for i, size := range sizes {
+		cts[i] = make([]mktfhe.LWECiphertext[T], size)
+		for j := range cts[i] {
+			cts[i][j] = mktfhe.NewLWECiphertext(e.Params)
+		}
+	}
+	return cts
+}
+
+// newProgramWires allocates the wires of p.
+// The input wires are not copied, since programs never write to them.
+func (e *MultiKeyEvaluator[T]) newProgramWires(p *Program, inputs [][]mktfhe.LWECiphertext[T]) []mktfhe.LWECiphertext[T] {
+	wires := make([]mktfhe.LWECiphertext[T], p.WireCount)
+	setProgramInputs(wires, p, inputs)
+	for i := p.InputWireCount(); i < p.WireCount; i++ {
+		wires[i] = mktfhe.NewLWECiphertext(e.Params)
+	}
+	return wires
+}
+
+// evaluateGateTo evaluates g using eval and writes the output to wires.
+func (e *MultiKeyEvaluator[T]) evaluateGateTo(eval *mktfhe.BinaryEvaluator[T], wires []mktfhe.LWECiphertext[T], g Gate) {
+	ctOut := wires[g.Output]
+	switch g.Type {
+	case GateAND:
+		eval.ANDTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateNAND:
+		eval.NANDTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateOR:
+		eval.ORTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateNOR:
+		eval.NORTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateXOR:
+		eval.XORTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateXNOR:
+		eval.XNORTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateANDNY:
+		eval.ANDNYTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateANDYN:
+		eval.ANDYNTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateORNY:
+		eval.ORNYTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateORYN:
+		eval.ORYNTo(ctOut, wires[g.Input[0]], wires[g.Input[1]])
+	case GateMUX:
+		eval.MUXTo(ctOut, wires[g.Input[0]], wires[g.Input[1]], wires[g.Input[2]])
+	case GateMAJ:
+		eval.MAJTo(ctOut, wires[g.Input[0]], wires[g.Input[1]], wires[g.Input[2]])
+	case GateINV:
+		eval.NOTTo(ctOut, wires[g.Input[0]])
+	case GateEQW:
+		ctOut.CopyFrom(wires[g.Input[0]])
+	case GateEQ:
+		ctOut.Clear()
+		ctOut.Value[0] = eval.EncodeLWEBool(g.Input[0] == 1).Value
+	}
+}
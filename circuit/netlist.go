@@ -0,0 +1,350 @@
+package circuit
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// netlist builds a [Circuit] from named nets,
+// where gates may be defined in any order.
+type netlist struct {
+	// nets maps the name of a net to its index.
+	nets map[string]int
+	// netNames is the names of nets.
+	netNames []string
+	// driver is the index of the gate driving each net.
+	// It is -1 if the net is not driven, and -2 if the net is an input.
+	driver []int
+	// gates is the gates, where wires are net indices.
+	gates []Gate
+
+	// inputs is the nets of each input.
+	inputs [][]int
+	// inputNames is the names of each input.
+	inputNames []string
+	// outputs is the nets of each output.
+	outputs [][]int
+	// outputNames is the names of each output.
+	outputNames []string
+}
+
+// newNetlist creates a new empty netlist.
+func newNetlist() *netlist {
+	return &netlist{nets: make(map[string]int)}
+}
+
+// net returns the index of the net with the given name,
+// creating it if it does not exist.
+func (n *netlist) net(name string) int {
+	if i, ok := n.nets[name]; ok {
+		return i
+	}
+	i := n.newNet()
+	n.nets[name] = i
+	n.netNames[i] = name
+	return i
+}
+
+// newNet creates a new anonymous net.
+func (n *netlist) newNet() int {
+	n.netNames = append(n.netNames, "")
+	n.driver = append(n.driver, -1)
+	return len(n.driver) - 1
+}
+
+// netName returns a printable name of the net.
+func (n *netlist) netName(i int) string {
+	if n.netNames[i] == "" {
+		return fmt.Sprintf("<net %d>", i)
+	}
+	return n.netNames[i]
+}
+
+// addGate adds a gate driving net out.
+func (n *netlist) addGate(gateType GateType, out int, in ...int) error {
+	if n.driver[out] != -1 {
+		return fmt.Errorf("net %s has multiple drivers", n.netName(out))
+	}
+	n.driver[out] = len(n.gates)
+	n.gates = append(n.gates, Gate{Type: gateType, Input: in, Output: out})
+	return nil
+}
+
+// addConst adds a constant gate driving net out.
+func (n *netlist) addConst(out int, value bool) error {
+	if value {
+		return n.addGate(GateEQ, out, 1)
+	}
+	return n.addGate(GateEQ, out, 0)
+}
+
+// addTruthTable adds gates computing a boolean function driving net out.
+// The i-th bit of the index of tt is the value of in[i].
+func (n *netlist) addTruthTable(out int, in []int, tt []bool) error {
+	return n.addGate(GateEQW, out, n.shannon(in, tt))
+}
+
+// shannon returns a net computing the boolean function tt of in,
+// using the Shannon expansion on the last input.
+func (n *netlist) shannon(in []int, tt []bool) int {
+	if len(in) == 0 {
+		out := n.newNet()
+		n.addConst(out, tt[0])
+		return out
+	}
+
+	k := len(in) - 1
+	tt0, tt1 := tt[:len(tt)/2], tt[len(tt)/2:]
+
+	equal := true
+	for i := range tt0 {
+		if tt0[i] != tt1[i] {
+			equal = false
+			break
+		}
+	}
+	if equal {
+		return n.shannon(in[:k], tt0)
+	}
+
+	out := n.newNet()
+	n.addGate(GateMUX, out, in[k], n.shannon(in[:k], tt1), n.shannon(in[:k], tt0))
+	return out
+}
+
+// addCell adds a Yosys internal gate with given pin connections.
+func (n *netlist) addCell(cell string, pins map[string]int) error {
+	pinNames := map[string][]string{
+		"$_BUF_":    {"A", "Y"},
+		"$_NOT_":    {"A", "Y"},
+		"$_AND_":    {"A", "B", "Y"},
+		"$_NAND_":   {"A", "B", "Y"},
+		"$_OR_":     {"A", "B", "Y"},
+		"$_NOR_":    {"A", "B", "Y"},
+		"$_XOR_":    {"A", "B", "Y"},
+		"$_XNOR_":   {"A", "B", "Y"},
+		"$_ANDNOT_": {"A", "B", "Y"},
+		"$_ORNOT_":  {"A", "B", "Y"},
+		"$_MUX_":    {"A", "B", "S", "Y"},
+		"$_NMUX_":   {"A", "B", "S", "Y"},
+		"$_AOI3_":   {"A", "B", "C", "Y"},
+		"$_OAI3_":   {"A", "B", "C", "Y"},
+		"$_AOI4_":   {"A", "B", "C", "D", "Y"},
+		"$_OAI4_":   {"A", "B", "C", "D", "Y"},
+	}[cell]
+	if pinNames == nil {
+		return fmt.Errorf("unsupported cell %s", cell)
+	}
+	if len(pins) != len(pinNames) {
+		return fmt.Errorf("invalid pins for cell %s", cell)
+	}
+
+	p := make([]int, len(pinNames))
+	for i, name := range pinNames {
+		net, ok := pins[name]
+		if !ok {
+			return fmt.Errorf("missing pin %s for cell %s", name, cell)
+		}
+		p[i] = net
+	}
+
+	switch cell {
+	case "$_BUF_":
+		return n.addGate(GateEQW, p[1], p[0])
+	case "$_NOT_":
+		return n.addGate(GateINV, p[1], p[0])
+	case "$_AND_":
+		return n.addGate(GateAND, p[2], p[0], p[1])
+	case "$_NAND_":
+		return n.addGate(GateNAND, p[2], p[0], p[1])
+	case "$_OR_":
+		return n.addGate(GateOR, p[2], p[0], p[1])
+	case "$_NOR_":
+		return n.addGate(GateNOR, p[2], p[0], p[1])
+	case "$_XOR_":
+		return n.addGate(GateXOR, p[2], p[0], p[1])
+	case "$_XNOR_":
+		return n.addGate(GateXNOR, p[2], p[0], p[1])
+	case "$_ANDNOT_":
+		return n.addGate(GateANDYN, p[2], p[0], p[1])
+	case "$_ORNOT_":
+		return n.addGate(GateORYN, p[2], p[0], p[1])
+	case "$_MUX_":
+		return n.addGate(GateMUX, p[3], p[2], p[1], p[0])
+	case "$_NMUX_":
+		mux := n.newNet()
+		n.addGate(GateMUX, mux, p[2], p[1], p[0])
+		return n.addGate(GateINV, p[3], mux)
+	case "$_AOI3_", "$_OAI3_":
+		inner, outer := GateAND, GateNOR
+		if cell == "$_OAI3_" {
+			inner, outer = GateOR, GateNAND
+		}
+		t := n.newNet()
+		n.addGate(inner, t, p[0], p[1])
+		return n.addGate(outer, p[3], t, p[2])
+	default:
+		inner, outer := GateAND, GateNOR
+		if cell == "$_OAI4_" {
+			inner, outer = GateOR, GateNAND
+		}
+		t0, t1 := n.newNet(), n.newNet()
+		n.addGate(inner, t0, p[0], p[1])
+		n.addGate(inner, t1, p[2], p[3])
+		return n.addGate(outer, p[4], t0, t1)
+	}
+}
+
+// addInput adds a new input consisting of nets.
+func (n *netlist) addInput(name string, nets []int) error {
+	for _, i := range nets {
+		if n.driver[i] != -1 {
+			return fmt.Errorf("input %s is already driven", n.netName(i))
+		}
+		n.driver[i] = -2
+	}
+	n.inputs = append(n.inputs, nets)
+	n.inputNames = append(n.inputNames, name)
+	return nil
+}
+
+// addOutput adds a new output consisting of nets.
+func (n *netlist) addOutput(name string, nets []int) {
+	n.outputs = append(n.outputs, nets)
+	n.outputNames = append(n.outputNames, name)
+}
+
+// busPattern matches the name of a bit of a bus, such as "a[3]".
+var busPattern = regexp.MustCompile(`^(.*)\[(\d+)\]$`)
+
+// groupBuses groups the names of bits into buses, in the order of first appearance.
+// Bits of a bus are sorted by their index, from the least significant bit.
+func groupBuses(names []string) (busNames []string, buses [][]string) {
+	type bit struct {
+		index int
+		name  string
+	}
+
+	busIndex := make(map[string]int)
+	var bits [][]bit
+	for _, name := range names {
+		busName, index := name, -1
+		if m := busPattern.FindStringSubmatch(name); m != nil {
+			busName = m[1]
+			index, _ = strconv.Atoi(m[2])
+		}
+
+		i, ok := busIndex[busName]
+		if !ok {
+			i = len(busNames)
+			busIndex[busName] = i
+			busNames = append(busNames, busName)
+			bits = append(bits, nil)
+		}
+		bits[i] = append(bits[i], bit{index: index, name: name})
+	}
+
+	buses = make([][]string, len(bits))
+	for i := range bits {
+		sort.SliceStable(bits[i], func(j, k int) bool { return bits[i][j].index < bits[i][k].index })
+		buses[i] = make([]string, len(bits[i]))
+		for j, b := range bits[i] {
+			buses[i][j] = b.name
+		}
+	}
+	return busNames, buses
+}
+
+// build builds a [Circuit] from the netlist.
+// The gates are sorted topologically, and copied to the output wires at the end.
+func (n *netlist) build() (Circuit, error) {
+	c := Circuit{
+		InputSizes:  make([]int, len(n.inputs)),
+		OutputSizes: make([]int, len(n.outputs)),
+		InputNames:  n.inputNames,
+		OutputNames: n.outputNames,
+	}
+
+	wire := make([]int, len(n.driver))
+	for i := range wire {
+		wire[i] = -1
+	}
+
+	wireCount := 0
+	for i, input := range n.inputs {
+		c.InputSizes[i] = len(input)
+		for _, net := range input {
+			wire[net] = wireCount
+			wireCount++
+		}
+	}
+
+	// Sort gates topologically using depth-first search.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(n.gates))
+	var visit func(net int) error
+	visit = func(net int) error {
+		switch g := n.driver[net]; {
+		case g == -1:
+			return fmt.Errorf("net %s is not driven", n.netName(net))
+		case g == -2 || state[g] == visited:
+			return nil
+		case state[g] == visiting:
+			return fmt.Errorf("combinational loop at net %s", n.netName(net))
+		}
+
+		g := n.driver[net]
+		state[g] = visiting
+		gate := n.gates[g]
+		if gate.Type != GateEQ {
+			for _, in := range gate.Input {
+				if err := visit(in); err != nil {
+					return err
+				}
+			}
+		}
+		state[g] = visited
+
+		input := gate.Input
+		if gate.Type != GateEQ {
+			input = make([]int, len(gate.Input))
+			for i, in := range gate.Input {
+				input[i] = wire[in]
+			}
+		}
+		wire[net] = wireCount
+		c.Gates = append(c.Gates, Gate{Type: gate.Type, Input: input, Output: wireCount})
+		wireCount++
+		return nil
+	}
+
+	for _, output := range n.outputs {
+		for _, net := range output {
+			if err := visit(net); err != nil {
+				return Circuit{}, err
+			}
+		}
+	}
+	for _, gate := range n.gates {
+		if err := visit(gate.Output); err != nil {
+			return Circuit{}, err
+		}
+	}
+
+	for i, output := range n.outputs {
+		c.OutputSizes[i] = len(output)
+		for _, net := range output {
+			c.Gates = append(c.Gates, Gate{Type: GateEQW, Input: []int{wire[net]}, Output: wireCount})
+			wireCount++
+		}
+	}
+	c.WireCount = wireCount
+
+	return c, nil
+}
@@ -0,0 +1,579 @@
+package circuit
+
+// Program is a compiled boolean circuit, optimized for [tfhe.BinaryEvaluator].
+//
+// Wires are numbered from 0 to WireCount - 1, and the input wires come first, in the order of InputSizes.
+// Gates are scheduled in levels, so that bootstrapping gates in the same level
+// are independent of each other.
+type Program struct {
+	// WireCount is the number of wires.
+	WireCount int
+	// InputSizes is the number of wires of each input.
+	InputSizes []int
+	// OutputSizes is the number of wires of each output.
+	OutputSizes []int
+	// Outputs is the wires of the outputs, in the order of OutputSizes.
+	// A wire can appear multiple times, and can be an input wire.
+	Outputs []int
+	// Levels is the gates of this program grouped by levels.
+	Levels []Level
+
+	// InputNames is the names of each input.
+	// It is nil if the inputs are not named.
+	InputNames []string
+	// OutputNames is the names of each output.
+	// It is nil if the outputs are not named.
+	OutputNames []string
+}
+
+// Level is a level of a [Program].
+type Level struct {
+	// Gates is the bootstrapping gates of this level,
+	// which are independent of each other.
+	Gates []Gate
+	// FreeGates is the gates without bootstrapping,
+	// which are evaluated after Gates in order.
+	FreeGates []Gate
+}
+
+// InputWireCount returns the total number of input wires.
+func (p *Program) InputWireCount() int {
+	return sum(p.InputSizes)
+}
+
+// Depth returns the number of levels with bootstrapping gates.
+func (p *Program) Depth() int {
+	depth := 0
+	for _, level := range p.Levels {
+		if len(level.Gates) > 0 {
+			depth++
+		}
+	}
+	return depth
+}
+
+// GateCount returns the number of bootstrapping gates.
+func (p *Program) GateCount() int {
+	count := 0
+	for _, level := range p.Levels {
+		count += len(level.Gates)
+	}
+	return count
+}
+
+// EvaluateBool evaluates p on plaintext inputs.
+//
+// Panics if the input sizes do not match.
+func (p *Program) EvaluateBool(inputs [][]bool) [][]bool {
+	if len(inputs) != len(p.InputSizes) {
+		panic("Input count mismatch")
+	}
+
+	wires := make([]bool, p.WireCount)
+	offset := 0
+	for i, input := range inputs {
+		if len(input) != p.InputSizes[i] {
+			panic("Input size mismatch")
+		}
+		copy(wires[offset:], input)
+		offset += len(input)
+	}
+
+	in := make([]bool, 3)
+	evaluate := func(g Gate) {
+		if g.Type == GateEQ {
+			wires[g.Output] = g.Input[0] == 1
+			return
+		}
+		for j, w := range g.Input {
+			in[j] = wires[w]
+		}
+		wires[g.Output] = g.Type.EvaluateBool(in[:len(g.Input)]...)
+	}
+
+	for _, level := range p.Levels {
+		for _, g := range level.Gates {
+			evaluate(g)
+		}
+		for _, g := range level.FreeGates {
+			evaluate(g)
+		}
+	}
+
+	outputs := make([][]bool, len(p.OutputSizes))
+	offset = 0
+	for i, size := range p.OutputSizes {
+		outputs[i] = make([]bool, size)
+		for j := range outputs[i] {
+			outputs[i][j] = wires[p.Outputs[offset]]
+			offset++
+		}
+	}
+	return outputs
+}
+
+// Compile compiles c into a [Program].
+//
+// Gates are mapped to the gate set of [tfhe.BinaryEvaluator] as follows:
+//
+//   - Constants are propagated, and gates with constant or duplicate inputs are simplified.
+//   - NOT and copy gates are removed, by absorbing negations into the gates that use them.
+//   - Identical gates are merged, and gates not affecting the outputs are removed.
+//
+// Then, the remaining gates are scheduled in levels as soon as their inputs are ready.
+func Compile(c Circuit) *Program {
+	b := newProgramBuilder(c.InputWireCount())
+
+	lits := make([]literal, c.WireCount)
+	for i := 0; i < c.InputWireCount(); i++ {
+		lits[i] = b.inputLiteral(i)
+	}
+
+	for _, g := range c.Gates {
+		var in [3]literal
+		if g.Type != GateEQ {
+			for j, w := range g.Input {
+				in[j] = lits[w]
+			}
+		}
+
+		var out literal
+		switch g.Type {
+		case GateAND:
+			out = b.and(in[0], in[1])
+		case GateNAND:
+			out = b.and(in[0], in[1]).not()
+		case GateOR:
+			out = b.and(in[0].not(), in[1].not()).not()
+		case GateNOR:
+			out = b.and(in[0].not(), in[1].not())
+		case GateXOR:
+			out = b.xor(in[0], in[1])
+		case GateXNOR:
+			out = b.xor(in[0], in[1]).not()
+		case GateANDNY:
+			out = b.and(in[0].not(), in[1])
+		case GateANDYN:
+			out = b.and(in[0], in[1].not())
+		case GateORNY:
+			out = b.and(in[0], in[1].not()).not()
+		case GateORYN:
+			out = b.and(in[0].not(), in[1]).not()
+		case GateMUX:
+			out = b.mux(in[0], in[1], in[2])
+		case GateMAJ:
+			out = b.maj(in[0], in[1], in[2])
+		case GateINV:
+			out = in[0].not()
+		case GateEQW:
+			out = in[0]
+		case GateEQ:
+			out = literalFalse
+			if g.Input[0] == 1 {
+				out = literalTrue
+			}
+		}
+		lits[g.Output] = out
+	}
+
+	outputs := lits[c.WireCount-c.OutputWireCount():]
+	p := b.emit(outputs)
+	p.InputSizes = append([]int(nil), c.InputSizes...)
+	p.OutputSizes = append([]int(nil), c.OutputSizes...)
+	p.InputNames = c.InputNames
+	p.OutputNames = c.OutputNames
+	return p
+}
+
+// literal is a possibly negated node of a [programBuilder].
+// The lowest bit is the negation, and the other bits are the node index.
+type literal int
+
+const (
+	// literalFalse is the constant false, which is node 0.
+	literalFalse literal = 0
+	// literalTrue is the constant true.
+	literalTrue literal = 1
+)
+
+// newLiteral returns a literal of the given node.
+func newLiteral(node int, negated bool) literal {
+	if negated {
+		return literal(node<<1 | 1)
+	}
+	return literal(node << 1)
+}
+
+// node returns the node index of the literal.
+func (l literal) node() int {
+	return int(l >> 1)
+}
+
+// negated returns true if the literal is negated.
+func (l literal) negated() bool {
+	return l&1 == 1
+}
+
+// not returns the negation of the literal.
+func (l literal) not() literal {
+	return l ^ 1
+}
+
+// isConst returns true if the literal is a constant.
+func (l literal) isConst() bool {
+	return l.node() == 0
+}
+
+// nodeType is the type of a node of a [programBuilder].
+type nodeType int
+
+const (
+	nodeConst nodeType = iota
+	nodeInput
+	nodeAND
+	nodeXOR
+	nodeMUX
+	nodeMAJ
+)
+
+// node is a node of a [programBuilder].
+type node struct {
+	typ nodeType
+	// in is the input literals.
+	// For nodeInput, in[0] is the input wire.
+	in [3]literal
+}
+
+// programBuilder builds an optimized [Program]
+// from a graph of AND, XOR, MUX and MAJ nodes with negatable edges.
+//
+// Inputs of AND, XOR and MAJ nodes are sorted, and inputs of XOR nodes are never negated.
+// For MUX nodes, the selector and the first input are never negated.
+// For MAJ nodes, at most one input is negated.
+type programBuilder struct {
+	nodes []node
+	// hash maps a node to its index, to merge identical nodes.
+	hash map[node]int
+}
+
+// newProgramBuilder creates a new [programBuilder] with given number of input wires.
+func newProgramBuilder(inputCount int) *programBuilder {
+	b := &programBuilder{
+		nodes: make([]node, inputCount+1),
+		hash:  make(map[node]int),
+	}
+	for i := 0; i < inputCount; i++ {
+		b.nodes[i+1] = node{typ: nodeInput, in: [3]literal{literal(i)}}
+	}
+	return b
+}
+
+// inputLiteral returns the literal of the i-th input wire.
+func (b *programBuilder) inputLiteral(i int) literal {
+	return newLiteral(i+1, false)
+}
+
+// add adds a node if it does not exist, and returns its literal.
+func (b *programBuilder) add(n node) literal {
+	if i, ok := b.hash[n]; ok {
+		return newLiteral(i, false)
+	}
+	b.nodes = append(b.nodes, n)
+	b.hash[n] = len(b.nodes) - 1
+	return newLiteral(len(b.nodes)-1, false)
+}
+
+// and returns the literal of x AND y.
+func (b *programBuilder) and(x, y literal) literal {
+	if x > y {
+		x, y = y, x
+	}
+
+	switch {
+	case x == literalFalse:
+		return literalFalse
+	case x == literalTrue:
+		return y
+	case x == y:
+		return x
+	case x == y.not():
+		return literalFalse
+	}
+	return b.add(node{typ: nodeAND, in: [3]literal{x, y}})
+}
+
+// xor returns the literal of x XOR y.
+func (b *programBuilder) xor(x, y literal) literal {
+	negated := x.negated() != y.negated()
+	x, y = newLiteral(x.node(), false), newLiteral(y.node(), false)
+	if x > y {
+		x, y = y, x
+	}
+
+	var out literal
+	switch {
+	case x == literalFalse:
+		out = y
+	case x == y:
+		out = literalFalse
+	default:
+		out = b.add(node{typ: nodeXOR, in: [3]literal{x, y}})
+	}
+
+	if negated {
+		return out.not()
+	}
+	return out
+}
+
+// mux returns the literal of s ? x : y.
+func (b *programBuilder) mux(s, x, y literal) literal {
+	if s.isConst() {
+		if s == literalTrue {
+			return x
+		}
+		return y
+	}
+	if s.negated() {
+		s, x, y = s.not(), y, x
+	}
+
+	switch {
+	case x == y:
+		return x
+	case x == y.not():
+		return b.xor(s, y)
+	case x == literalFalse, x == s.not():
+		return b.and(s.not(), y)
+	case x == literalTrue, x == s:
+		return b.and(s.not(), y.not()).not()
+	case y == literalFalse, y == s:
+		return b.and(s, x)
+	case y == literalTrue, y == s.not():
+		return b.and(s, x.not()).not()
+	}
+
+	if x.negated() {
+		return b.add(node{typ: nodeMUX, in: [3]literal{s, x.not(), y.not()}}).not()
+	}
+	return b.add(node{typ: nodeMUX, in: [3]literal{s, x, y}})
+}
+
+// maj returns the literal of the majority of x, y and z.
+func (b *programBuilder) maj(x, y, z literal) literal {
+	// Sort x <= y <= z.
+	if x > y {
+		x, y = y, x
+	}
+	if y > z {
+		y, z = z, y
+	}
+	if x > y {
+		x, y = y, x
+	}
+
+	switch {
+	case x == literalFalse:
+		return b.and(y, z)
+	case x == literalTrue:
+		return b.and(y.not(), z.not()).not()
+	case x == y:
+		return x
+	case y == z:
+		return y
+	case x == y.not():
+		return z
+	case y == z.not():
+		return x
+	}
+
+	// Majority is self-dual, so negating all inputs negates the output.
+	if boolIndex(x.negated())+boolIndex(y.negated())+boolIndex(z.negated()) >= 2 {
+		return b.maj(x.not(), y.not(), z.not()).not()
+	}
+	return b.add(node{typ: nodeMAJ, in: [3]literal{x, y, z}})
+}
+
+// emit schedules the nodes needed for outputs, and returns a [Program].
+func (b *programBuilder) emit(outputs []literal) *Program {
+	// Find live nodes and their references.
+	live := make([]bool, len(b.nodes))
+	for _, l := range outputs {
+		live[l.node()] = true
+	}
+	for i := len(b.nodes) - 1; i > 0; i-- {
+		if live[i] && b.nodes[i].typ != nodeInput {
+			for _, l := range b.inputs(i) {
+				live[l.node()] = true
+			}
+		}
+	}
+
+	// A node is flipped, i.e. its wire holds its negation,
+	// if it is an AND or XOR node and every reference to it prefers the negation.
+	// References from XOR gates and selectors of MUX gates have no preference,
+	// since their negations are absorbed into the gates.
+	positiveRef := make([]bool, len(b.nodes))
+	negativeRef := make([]bool, len(b.nodes))
+	ref := func(l literal) {
+		if l.negated() {
+			negativeRef[l.node()] = true
+		} else {
+			positiveRef[l.node()] = true
+		}
+	}
+	for _, l := range outputs {
+		ref(l)
+	}
+	for i, n := range b.nodes {
+		if !live[i] {
+			continue
+		}
+		switch n.typ {
+		case nodeAND:
+			ref(n.in[0])
+			ref(n.in[1])
+		case nodeMUX:
+			ref(n.in[1])
+			ref(n.in[2])
+		case nodeMAJ:
+			ref(n.in[0])
+			ref(n.in[1])
+			ref(n.in[2])
+		}
+	}
+	flipped := make([]bool, len(b.nodes))
+	for i, n := range b.nodes {
+		flipped[i] = live[i] && (n.typ == nodeAND || n.typ == nodeXOR) && negativeRef[i] && !positiveRef[i]
+	}
+
+	p := &Program{Levels: []Level{{}}}
+
+	wire := make([]int, len(b.nodes))
+	level := make([]int, len(b.nodes))
+	for i, n := range b.nodes {
+		if n.typ == nodeInput {
+			wire[i] = int(n.in[0])
+			p.WireCount++
+		}
+	}
+
+	// notWire caches the wires holding negations of wires.
+	notWire := make(map[int]int)
+	// constWire caches the wires holding constants.
+	constWire := make(map[bool]int)
+
+	// wireOf returns the wire of l and whether it holds the negation of l.
+	wireOf := func(l literal) (int, bool) {
+		return wire[l.node()], l.negated() != flipped[l.node()]
+	}
+
+	// positiveWire returns a wire holding l, adding a NOT gate if needed.
+	positiveWire := func(l literal) int {
+		if l.isConst() {
+			v := l == literalTrue
+			if w, ok := constWire[v]; ok {
+				return w
+			}
+			w := p.WireCount
+			p.WireCount++
+			constWire[v] = w
+			c := 0
+			if v {
+				c = 1
+			}
+			p.Levels[0].FreeGates = append(p.Levels[0].FreeGates, Gate{Type: GateEQ, Input: []int{c}, Output: w})
+			return w
+		}
+
+		w, negated := wireOf(l)
+		if !negated {
+			return w
+		}
+		if wNot, ok := notWire[w]; ok {
+			return wNot
+		}
+		wNot := p.WireCount
+		p.WireCount++
+		notWire[w] = wNot
+		lv := level[l.node()]
+		p.Levels[lv].FreeGates = append(p.Levels[lv].FreeGates, Gate{Type: GateINV, Input: []int{w}, Output: wNot})
+		return wNot
+	}
+
+	for i, n := range b.nodes {
+		if !live[i] || n.typ <= nodeInput {
+			continue
+		}
+
+		lv := 0
+		for _, l := range b.inputs(i) {
+			if level[l.node()] > lv {
+				lv = level[l.node()]
+			}
+		}
+		lv++
+		level[i] = lv
+		if lv == len(p.Levels) {
+			p.Levels = append(p.Levels, Level{})
+		}
+
+		var g Gate
+		switch n.typ {
+		case nodeAND:
+			w0, n0 := wireOf(n.in[0])
+			w1, n1 := wireOf(n.in[1])
+			gateTypes := [2][2][2]GateType{
+				{{GateAND, GateANDYN}, {GateANDNY, GateNOR}},
+				{{GateNAND, GateORNY}, {GateORYN, GateOR}},
+			}
+			g = Gate{Type: gateTypes[boolIndex(flipped[i])][boolIndex(n0)][boolIndex(n1)], Input: []int{w0, w1}}
+		case nodeXOR:
+			w0, n0 := wireOf(n.in[0])
+			w1, n1 := wireOf(n.in[1])
+			g = Gate{Type: GateXOR, Input: []int{w0, w1}}
+			if n0 != n1 != flipped[i] {
+				g.Type = GateXNOR
+			}
+		case nodeMUX:
+			s, x, y := n.in[0], n.in[1], n.in[2]
+			if _, negated := wireOf(s); negated {
+				s, x, y = s.not(), y, x
+			}
+			g = Gate{Type: GateMUX, Input: []int{positiveWire(s), positiveWire(x), positiveWire(y)}}
+		case nodeMAJ:
+			g = Gate{Type: GateMAJ, Input: []int{positiveWire(n.in[0]), positiveWire(n.in[1]), positiveWire(n.in[2])}}
+		}
+
+		wire[i] = p.WireCount
+		p.WireCount++
+		g.Output = wire[i]
+		p.Levels[lv].Gates = append(p.Levels[lv].Gates, g)
+	}
+
+	p.Outputs = make([]int, len(outputs))
+	for i, l := range outputs {
+		p.Outputs[i] = positiveWire(l)
+	}
+
+	return p
+}
+
+// inputs returns the input literals of the i-th node.
+func (b *programBuilder) inputs(i int) []literal {
+	switch b.nodes[i].typ {
+	case nodeAND, nodeXOR:
+		return b.nodes[i].in[:2]
+	case nodeMUX, nodeMAJ:
+		return b.nodes[i].in[:3]
+	}
+	return nil
+}
+
+// boolIndex returns 1 if b is true, and 0 otherwise.
+func boolIndex(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
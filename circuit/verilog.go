@@ -0,0 +1,950 @@
+package circuit
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseVerilog parses a combinational circuit in structural Verilog from r,
+// such as the ones written by Yosys.
+//
+// Only the first module is parsed.
+// Supported statements are declarations of inputs, outputs and wires,
+// continuous assignments with bitwise operators (~, &, |, ^, ~^) and conditional operators,
+// gate primitives (and, nand, or, nor, xor, xnor, not, buf),
+// and instances of Yosys internal gates, such as $_AND_.
+// Inputs and outputs are ordered by their declarations, and buses are ordered from the least significant bit.
+func ParseVerilog(r io.Reader) (Circuit, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return Circuit{}, err
+	}
+
+	tokens, err := verilogTokenize(string(src))
+	if err != nil {
+		return Circuit{}, err
+	}
+
+	p := &verilogParser{
+		tokens:  tokens,
+		netlist: newNetlist(),
+		signals: make(map[string]verilogSignal),
+	}
+	if err := p.parseModule(); err != nil {
+		return Circuit{}, err
+	}
+
+	for _, name := range p.inputs {
+		if err := p.netlist.addInput(name, p.signalNets(name)); err != nil {
+			return Circuit{}, fmt.Errorf("verilog: %v", err)
+		}
+	}
+	for _, name := range p.outputs {
+		p.netlist.addOutput(name, p.signalNets(name))
+	}
+
+	c, err := p.netlist.build()
+	if err != nil {
+		return Circuit{}, fmt.Errorf("verilog: %v", err)
+	}
+	return c, nil
+}
+
+// verilogTokenKind is the kind of a Verilog token.
+type verilogTokenKind int
+
+const (
+	tokenIdent verilogTokenKind = iota
+	tokenNumber
+	tokenSymbol
+	tokenEOF
+)
+
+// verilogToken is a Verilog token.
+type verilogToken struct {
+	kind verilogTokenKind
+	text string
+	line int
+}
+
+// verilogTokenize splits src into tokens, removing comments and attributes.
+func verilogTokenize(src string) ([]verilogToken, error) {
+	var tokens []verilogToken
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"), strings.HasPrefix(src[i:], "(*"):
+			end := "*/"
+			if c == '(' {
+				end = "*)"
+			}
+			j := strings.Index(src[i+2:], end)
+			if j < 0 {
+				return nil, fmt.Errorf("verilog: line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+j], "\n")
+			i += j + 4
+		case c == '\\':
+			j := i + 1
+			for j < len(src) && !strings.ContainsRune(" \t\r\n", rune(src[j])) {
+				j++
+			}
+			tokens = append(tokens, verilogToken{kind: tokenIdent, text: src[i+1 : j], line: line})
+			i = j
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(src) && (isIdentStart(src[j]) || isDigit(src[j]) || src[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, verilogToken{kind: tokenIdent, text: src[i:j], line: line})
+			i = j
+		case isDigit(c) || c == '\'':
+			j := i
+			for j < len(src) && (isDigit(src[j]) || src[j] == '_') {
+				j++
+			}
+			if j < len(src) && src[j] == '\'' {
+				j++
+				if j < len(src) && (src[j] == 's' || src[j] == 'S') {
+					j++
+				}
+				if j < len(src) {
+					j++
+				}
+				for j < len(src) && (isDigit(src[j]) || isIdentStart(src[j]) || src[j] == '?') {
+					j++
+				}
+			}
+			tokens = append(tokens, verilogToken{kind: tokenNumber, text: src[i:j], line: line})
+			i = j
+		default:
+			text := string(c)
+			for _, op := range []string{"~^", "^~"} {
+				if strings.HasPrefix(src[i:], op) {
+					text = op
+				}
+			}
+			if !strings.Contains("()[]{},;:.=~&|^?!#", string(c)) {
+				return nil, fmt.Errorf("verilog: line %d: unexpected character %q", line, c)
+			}
+			tokens = append(tokens, verilogToken{kind: tokenSymbol, text: text, line: line})
+			i += len(text)
+		}
+	}
+	tokens = append(tokens, verilogToken{kind: tokenEOF, line: line})
+	return tokens, nil
+}
+
+// isIdentStart returns true if c can start an identifier.
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isDigit returns true if c is a decimal digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// verilogSignal is a declared signal.
+type verilogSignal struct {
+	// msb and lsb are the range of the signal.
+	msb, lsb int
+	// scalar is true if the signal has no range.
+	scalar bool
+}
+
+// width returns the width of the signal.
+func (s verilogSignal) width() int {
+	if s.msb >= s.lsb {
+		return s.msb - s.lsb + 1
+	}
+	return s.lsb - s.msb + 1
+}
+
+// index returns the index of the j-th bit from the least significant bit.
+func (s verilogSignal) index(j int) int {
+	if s.msb >= s.lsb {
+		return s.lsb + j
+	}
+	return s.lsb - j
+}
+
+// verilogParser is a recursive descent parser for structural Verilog.
+type verilogParser struct {
+	tokens []verilogToken
+	pos    int
+
+	netlist *netlist
+	signals map[string]verilogSignal
+	inputs  []string
+	outputs []string
+
+	// constNets is the nets of the constants 0 and 1, created on demand.
+	constNets [2]int
+	hasConst  [2]bool
+}
+
+// peek returns the current token.
+func (p *verilogParser) peek() verilogToken {
+	return p.tokens[p.pos]
+}
+
+// peekAt returns the k-th token after the current token,
+// or the EOF token if it is past the end.
+func (p *verilogParser) peekAt(k int) verilogToken {
+	if p.pos+k >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+k]
+}
+
+// next returns the current token and advances.
+func (p *verilogParser) next() verilogToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept advances if the current token is text, and returns true if so.
+func (p *verilogParser) accept(text string) bool {
+	if t := p.peek(); t.kind != tokenNumber && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expect advances if the current token is text, and returns an error otherwise.
+func (p *verilogParser) expect(text string) error {
+	if !p.accept(text) {
+		return p.errorf("expected %q", text)
+	}
+	return nil
+}
+
+// ident returns the current token if it is an identifier.
+func (p *verilogParser) ident() (string, error) {
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return "", p.errorf("expected identifier")
+	}
+	p.pos++
+	return t.text, nil
+}
+
+// errorf returns a parse error at the current token.
+func (p *verilogParser) errorf(format string, args ...any) error {
+	t := p.peek()
+	near := t.text
+	if t.kind == tokenEOF {
+		near = "EOF"
+	}
+	return fmt.Errorf("verilog: line %d: near %q: %s", t.line, near, fmt.Sprintf(format, args...))
+}
+
+// parseModule parses the first module.
+func (p *verilogParser) parseModule() error {
+	if err := p.expect("module"); err != nil {
+		return err
+	}
+	if _, err := p.ident(); err != nil {
+		return err
+	}
+	if p.accept("#") {
+		if err := p.skipParens(); err != nil {
+			return err
+		}
+	}
+
+	if p.accept("(") {
+		if !p.accept(")") {
+			for {
+				if t := p.peek(); t.text == "input" || t.text == "output" {
+					p.next()
+					if err := p.parseDeclaration(t.text); err != nil {
+						return err
+					}
+				} else if _, err := p.ident(); err != nil {
+					return err
+				}
+				if p.accept(")") {
+					break
+				}
+				if err := p.expect(","); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := p.expect(";"); err != nil {
+		return err
+	}
+
+	for !p.accept("endmodule") {
+		if err := p.parseItem(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipParens skips a parenthesized token sequence.
+func (p *verilogParser) skipParens() error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		switch t := p.next(); {
+		case t.kind == tokenEOF:
+			return p.errorf("unbalanced parentheses")
+		case t.text == "(":
+			depth++
+		case t.text == ")":
+			depth--
+		}
+	}
+	return nil
+}
+
+// parseItem parses a module item.
+func (p *verilogParser) parseItem() error {
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return p.errorf("unexpected token")
+	}
+
+	switch t.text {
+	case "input", "output", "wire":
+		p.next()
+		if err := p.parseDeclaration(t.text); err != nil {
+			return err
+		}
+		return p.expect(";")
+	case "reg", "always", "initial":
+		return p.errorf("sequential circuits are not supported")
+	case "assign":
+		p.next()
+		for {
+			lhs, err := p.parseLValue()
+			if err != nil {
+				return err
+			}
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			rhs, err := p.parseExpr()
+			if err != nil {
+				return err
+			}
+			if err := p.assign(lhs, rhs); err != nil {
+				return err
+			}
+			if !p.accept(",") {
+				break
+			}
+		}
+		return p.expect(";")
+	case "and", "nand", "or", "nor", "xor", "xnor", "not", "buf":
+		p.next()
+		return p.parsePrimitive(t.text)
+	default:
+		p.next()
+		return p.parseCell(t.text)
+	}
+}
+
+// parseDeclaration parses a declaration of signals after its keyword.
+func (p *verilogParser) parseDeclaration(kind string) error {
+	p.accept("wire")
+	p.accept("signed")
+
+	s := verilogSignal{scalar: true}
+	if p.accept("[") {
+		msb, err := p.parseInt()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		lsb, err := p.parseInt()
+		if err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+		s = verilogSignal{msb: msb, lsb: lsb}
+	}
+
+	for {
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+
+		if old, ok := p.signals[name]; ok && old != s {
+			return p.errorf("conflicting declaration of %s", name)
+		}
+		p.signals[name] = s
+
+		switch kind {
+		case "input":
+			p.inputs = append(p.inputs, name)
+		case "output":
+			p.outputs = append(p.outputs, name)
+		}
+
+		if t := p.peekAt(1); !(p.peek().text == "," && t.kind == tokenIdent && t.text != "input" && t.text != "output") {
+			return nil
+		}
+		p.next()
+	}
+}
+
+// parseInt parses a decimal integer.
+func (p *verilogParser) parseInt() (int, error) {
+	t := p.peek()
+	if t.kind != tokenNumber {
+		return 0, p.errorf("expected integer")
+	}
+	v, err := strconv.Atoi(strings.ReplaceAll(t.text, "_", ""))
+	if err != nil {
+		return 0, p.errorf("expected integer")
+	}
+	p.pos++
+	return v, nil
+}
+
+// signalNets returns the nets of a signal, from the least significant bit.
+func (p *verilogParser) signalNets(name string) []int {
+	s := p.signals[name]
+	nets := make([]int, s.width())
+	for j := range nets {
+		nets[j] = p.bitNet(name, s, s.index(j))
+	}
+	return nets
+}
+
+// bitNet returns the net of the bit of a signal with the given index.
+func (p *verilogParser) bitNet(name string, s verilogSignal, index int) int {
+	if s.scalar {
+		return p.netlist.net(name)
+	}
+	return p.netlist.net(fmt.Sprintf("%s[%d]", name, index))
+}
+
+// constNet returns the net of a constant.
+func (p *verilogParser) constNet(v bool) int {
+	i := 0
+	if v {
+		i = 1
+	}
+	if !p.hasConst[i] {
+		p.constNets[i] = p.netlist.newNet()
+		p.netlist.addConst(p.constNets[i], v)
+		p.hasConst[i] = true
+	}
+	return p.constNets[i]
+}
+
+// parseSelect parses an identifier with an optional bit or part select,
+// and returns its nets from the least significant bit.
+func (p *verilogParser) parseSelect() ([]int, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := p.signals[name]
+	if !ok {
+		// Implicitly declared scalar net.
+		s = verilogSignal{scalar: true}
+		p.signals[name] = s
+	}
+
+	if !p.accept("[") {
+		return p.signalNets(name), nil
+	}
+
+	hi, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	lo := hi
+	if p.accept(":") {
+		if lo, err = p.parseInt(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+
+	inRange := func(i int) bool {
+		return !s.scalar && (s.msb >= s.lsb && i >= s.lsb && i <= s.msb || s.msb < s.lsb && i >= s.msb && i <= s.lsb)
+	}
+	if !inRange(hi) || !inRange(lo) {
+		return nil, p.errorf("index out of range for %s", name)
+	}
+
+	step := 1
+	if hi < lo {
+		step = -1
+	}
+	nets := make([]int, 0, (hi-lo)*step+1)
+	for i := lo; ; i += step {
+		nets = append(nets, p.bitNet(name, s, i))
+		if i == hi {
+			break
+		}
+	}
+	return nets, nil
+}
+
+// parseLValue parses the left hand side of an assignment.
+func (p *verilogParser) parseLValue() ([]int, error) {
+	if !p.accept("{") {
+		return p.parseSelect()
+	}
+
+	var parts [][]int
+	for {
+		part, err := p.parseLValue()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		if p.accept("}") {
+			break
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+	return concat(parts), nil
+}
+
+// concat concatenates parts, where the first part is the most significant.
+func concat(parts [][]int) []int {
+	var nets []int
+	for i := len(parts) - 1; i >= 0; i-- {
+		nets = append(nets, parts[i]...)
+	}
+	return nets
+}
+
+// resize zero-extends or truncates nets to the given width.
+func (p *verilogParser) resize(nets []int, width int) []int {
+	if len(nets) >= width {
+		return nets[:width]
+	}
+	out := make([]int, width)
+	copy(out, nets)
+	for i := len(nets); i < width; i++ {
+		out[i] = p.constNet(false)
+	}
+	return out
+}
+
+// assign drives lhs with rhs.
+func (p *verilogParser) assign(lhs, rhs []int) error {
+	rhs = p.resize(rhs, len(lhs))
+	for i := range lhs {
+		if err := p.netlist.addGate(GateEQW, lhs[i], rhs[i]); err != nil {
+			return p.errorf("%v", err)
+		}
+	}
+	return nil
+}
+
+// parseExpr parses an expression, and returns its nets from the least significant bit.
+func (p *verilogParser) parseExpr() ([]int, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("?") {
+		return cond, nil
+	}
+
+	if len(cond) != 1 {
+		return nil, p.errorf("condition should be one bit")
+	}
+	x, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	y, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	width := len(x)
+	if len(y) > width {
+		width = len(y)
+	}
+	x, y = p.resize(x, width), p.resize(y, width)
+	out := make([]int, width)
+	for i := range out {
+		out[i] = p.netlist.newNet()
+		p.netlist.addGate(GateMUX, out[i], cond[0], x[i], y[i])
+	}
+	return out, nil
+}
+
+// verilogBinaryOps is the binary operators, from the lowest precedence.
+var verilogBinaryOps = []map[string]GateType{
+	{"|": GateOR},
+	{"^": GateXOR, "~^": GateXNOR, "^~": GateXNOR},
+	{"&": GateAND},
+}
+
+// parseBinary parses a binary expression with given precedence level.
+func (p *verilogParser) parseBinary(level int) ([]int, error) {
+	if level == len(verilogBinaryOps) {
+		return p.parseUnary()
+	}
+
+	x, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		gateType, ok := verilogBinaryOps[level][p.peek().text]
+		if !ok || p.peek().kind != tokenSymbol {
+			return x, nil
+		}
+		p.next()
+
+		y, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		width := len(x)
+		if len(y) > width {
+			width = len(y)
+		}
+		x, y = p.resize(x, width), p.resize(y, width)
+		out := make([]int, width)
+		for i := range out {
+			out[i] = p.netlist.newNet()
+			p.netlist.addGate(gateType, out[i], x[i], y[i])
+		}
+		x = out
+	}
+}
+
+// parseUnary parses a unary expression.
+func (p *verilogParser) parseUnary() ([]int, error) {
+	if p.accept("~") || p.accept("!") {
+		negate := p.tokens[p.pos-1].text == "!"
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if negate && len(x) != 1 {
+			return nil, p.errorf("logical negation should be applied to one bit")
+		}
+
+		out := make([]int, len(x))
+		for i := range out {
+			out[i] = p.netlist.newNet()
+			p.netlist.addGate(GateINV, out[i], x[i])
+		}
+		return out, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a primary expression.
+func (p *verilogParser) parsePrimary() ([]int, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenNumber:
+		p.next()
+		bits, err := parseVerilogNumber(t.text)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		nets := make([]int, len(bits))
+		for i, b := range bits {
+			nets[i] = p.constNet(b)
+		}
+		return nets, nil
+	case t.kind == tokenIdent:
+		return p.parseSelect()
+	case p.accept("("):
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(")")
+	case p.accept("{"):
+		if p.peek().kind == tokenNumber && p.peekAt(1).text == "{" {
+			count, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			p.next()
+			x, err := p.parseConcat()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("}"); err != nil {
+				return nil, err
+			}
+
+			var nets []int
+			for i := 0; i < count; i++ {
+				nets = append(nets, x...)
+			}
+			return nets, nil
+		}
+		return p.parseConcat()
+	}
+	return nil, p.errorf("unexpected token")
+}
+
+// parseConcat parses the rest of a concatenation after "{".
+func (p *verilogParser) parseConcat() ([]int, error) {
+	var parts [][]int
+	for {
+		part, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		if p.accept("}") {
+			break
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+	return concat(parts), nil
+}
+
+// parseVerilogNumber parses a Verilog number literal,
+// and returns its bits from the least significant bit.
+func parseVerilogNumber(text string) ([]bool, error) {
+	text = strings.ReplaceAll(text, "_", "")
+
+	q := strings.IndexByte(text, '\'')
+	if q < 0 {
+		v, err := strconv.ParseUint(text, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", text)
+		}
+		return uintBits(v, 32), nil
+	}
+
+	width := 32
+	if q > 0 {
+		w, err := strconv.Atoi(text[:q])
+		if err != nil || w <= 0 {
+			return nil, fmt.Errorf("invalid number %s", text)
+		}
+		width = w
+	}
+
+	rest := strings.TrimLeft(text[q+1:], "sS")
+	if len(rest) < 2 {
+		return nil, fmt.Errorf("invalid number %s", text)
+	}
+
+	logBase := 0
+	switch rest[0] {
+	case 'b', 'B':
+		logBase = 1
+	case 'o', 'O':
+		logBase = 3
+	case 'h', 'H':
+		logBase = 4
+	case 'd', 'D':
+		v, err := strconv.ParseUint(rest[1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", text)
+		}
+		return uintBits(v, width), nil
+	default:
+		return nil, fmt.Errorf("invalid number %s", text)
+	}
+
+	var bits []bool
+	digits := rest[1:]
+	for i := len(digits) - 1; i >= 0; i-- {
+		d, err := strconv.ParseUint(digits[i:i+1], 1<<logBase, 8)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported number %s", text)
+		}
+		for j := 0; j < logBase; j++ {
+			bits = append(bits, d>>j&1 == 1)
+		}
+	}
+
+	for len(bits) < width {
+		bits = append(bits, false)
+	}
+	return bits[:width], nil
+}
+
+// uintBits returns the lowest width bits of v, from the least significant bit.
+func uintBits(v uint64, width int) []bool {
+	bits := make([]bool, width)
+	for i := 0; i < width && i < 64; i++ {
+		bits[i] = v>>i&1 == 1
+	}
+	return bits
+}
+
+// parsePrimitive parses a gate primitive instance after its keyword.
+func (p *verilogParser) parsePrimitive(kind string) error {
+	if p.peek().kind == tokenIdent {
+		p.next()
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+
+	out, err := p.parseLValue()
+	if err != nil {
+		return err
+	}
+	if len(out) != 1 {
+		return p.errorf("output of primitive should be one bit")
+	}
+
+	var in []int
+	for p.accept(",") {
+		x, err := p.parseExpr()
+		if err != nil {
+			return err
+		}
+		if len(x) != 1 {
+			return p.errorf("input of primitive should be one bit")
+		}
+		in = append(in, x[0])
+	}
+	if err := p.expect(")"); err != nil {
+		return err
+	}
+	if err := p.expect(";"); err != nil {
+		return err
+	}
+
+	switch kind {
+	case "not", "buf":
+		if len(in) != 1 {
+			return p.errorf("%s should have one input", kind)
+		}
+		gateType := GateEQW
+		if kind == "not" {
+			gateType = GateINV
+		}
+		if err := p.netlist.addGate(gateType, out[0], in[0]); err != nil {
+			return p.errorf("%v", err)
+		}
+		return nil
+	}
+
+	if len(in) < 2 {
+		return p.errorf("%s should have at least two inputs", kind)
+	}
+
+	gateType := map[string]GateType{"and": GateAND, "nand": GateAND, "or": GateOR, "nor": GateOR, "xor": GateXOR, "xnor": GateXOR}[kind]
+	acc := in[0]
+	for _, x := range in[1:] {
+		next := p.netlist.newNet()
+		p.netlist.addGate(gateType, next, acc, x)
+		acc = next
+	}
+
+	gateType = GateEQW
+	if kind == "nand" || kind == "nor" || kind == "xnor" {
+		gateType = GateINV
+	}
+	if err := p.netlist.addGate(gateType, out[0], acc); err != nil {
+		return p.errorf("%v", err)
+	}
+	return nil
+}
+
+// parseCell parses an instance of a Yosys internal gate after its cell name.
+func (p *verilogParser) parseCell(cell string) error {
+	if p.accept("#") {
+		if err := p.skipParens(); err != nil {
+			return err
+		}
+	}
+	if _, err := p.ident(); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+
+	pins := make(map[string]int)
+	for !p.accept(")") {
+		if len(pins) > 0 {
+			if err := p.expect(","); err != nil {
+				return err
+			}
+		}
+		if err := p.expect("."); err != nil {
+			return err
+		}
+		pin, err := p.ident()
+		if err != nil {
+			return err
+		}
+		if err := p.expect("("); err != nil {
+			return err
+		}
+
+		var x []int
+		if pin == "Y" {
+			x, err = p.parseLValue()
+		} else {
+			x, err = p.parseExpr()
+		}
+		if err != nil {
+			return err
+		}
+		if len(x) != 1 {
+			return p.errorf("pin %s should be one bit", pin)
+		}
+		pins[pin] = x[0]
+
+		if err := p.expect(")"); err != nil {
+			return err
+		}
+	}
+	if err := p.expect(";"); err != nil {
+		return err
+	}
+
+	if err := p.netlist.addCell(cell, pins); err != nil {
+		return p.errorf("%v", err)
+	}
+	return nil
+}
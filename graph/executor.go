@@ -0,0 +1,291 @@
+package graph
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sp301415/tfhe-go/math/vec"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// CostModel is the estimated running time of each operation,
+// used in [Graph.EstimateLatency].
+type CostModel struct {
+	// BootstrapLUT is the running time of a programmable bootstrapping.
+	BootstrapLUT time.Duration
+	// KeySwitchLWE is the running time of a keyswitching.
+	KeySwitchLWE time.Duration
+	// Linear is the running time of a linear operation per input ciphertext.
+	Linear time.Duration
+}
+
+// EstimateLatency returns the estimated running time of executing the graph
+// with given number of workers.
+//
+// Nodes are executed level by level, as returned by [Graph.Levels],
+// and nodes in each level are distributed across the workers.
+func (g *Graph[T]) EstimateLatency(cost CostModel, workerCount int) time.Duration {
+	if workerCount < 1 {
+		panic("Worker count not positive")
+	}
+
+	var latency time.Duration
+	loads := make([]time.Duration, workerCount)
+	for _, level := range g.Levels() {
+		costs := make([]time.Duration, len(level))
+		for k, i := range level {
+			switch n := g.Nodes[i]; n.Op {
+			case OpBootstrapLUT:
+				costs[k] = cost.BootstrapLUT
+			case OpKeySwitchLWE:
+				costs[k] = cost.KeySwitchLWE
+			default:
+				costs[k] = cost.Linear * time.Duration(len(n.Inputs))
+			}
+		}
+		sort.Slice(costs, func(i, j int) bool { return costs[i] > costs[j] })
+
+		// Assign each node to the least loaded worker, starting from the most expensive one.
+		for k := range loads {
+			loads[k] = 0
+		}
+		for _, c := range costs {
+			minIdx := 0
+			for k := range loads {
+				if loads[k] < loads[minIdx] {
+					minIdx = k
+				}
+			}
+			loads[minIdx] += c
+		}
+
+		maxLoad := loads[0]
+		for _, l := range loads {
+			if l > maxLoad {
+				maxLoad = l
+			}
+		}
+		latency += maxLoad
+	}
+	return latency
+}
+
+// Executor executes [Graph] using [tfhe.Evaluator].
+// This is meant to be public, usually for servers.
+//
+// Executor is not safe for concurrent use.
+// Use [Executor.SafeCopy] to get a safe copy.
+type Executor[T tfhe.TorusInt] struct {
+	// Evaluator is an Evaluator for this Executor.
+	Evaluator *tfhe.Evaluator[T]
+	// Params is the parameter set for this Executor.
+	Params tfhe.Parameters[T]
+
+	// evaluatorPool is a pool of Evaluators for parallel execution.
+	// It is allocated on the first parallel execution.
+	evaluatorPool []*tfhe.Evaluator[T]
+}
+
+// NewExecutor creates a new [Executor].
+// This does not copy evaluation keys, since they are large.
+func NewExecutor[T tfhe.TorusInt](params tfhe.Parameters[T], evk tfhe.EvaluationKey[T]) *Executor[T] {
+	return &Executor[T]{
+		Evaluator: tfhe.NewEvaluator(params, evk),
+		Params:    params,
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *Executor[T]) SafeCopy() *Executor[T] {
+	return &Executor[T]{
+		Evaluator: e.Evaluator.SafeCopy(),
+		Params:    e.Params,
+	}
+}
+
+// WorkerCount returns the number of workers used in parallel execution.
+func (e *Executor[T]) WorkerCount() int {
+	return runtime.NumCPU()
+}
+
+// MeasureCost measures the running time of each operation on this machine,
+// to be used in [Graph.EstimateLatency].
+func (e *Executor[T]) MeasureCost() CostModel {
+	ct := tfhe.NewLWECiphertext(e.Params)
+	ctOut := tfhe.NewLWECiphertext(e.Params)
+	lut := tfhe.NewLUT(e.Params)
+
+	// The first bootstrapping is excluded, since it includes warming up.
+	e.Evaluator.BootstrapLUTTo(ctOut, ct, lut)
+	now := time.Now()
+	e.Evaluator.BootstrapLUTTo(ctOut, ct, lut)
+	bootstrapCost := time.Since(now)
+
+	ctGLWE := tfhe.NewLWECiphertextCustom[T](e.Params.GLWEDimension())
+	ctLWE := tfhe.NewLWECiphertextCustom[T](e.Params.LWEDimension())
+	now = time.Now()
+	e.Evaluator.DefaultKeySwitchTo(ctLWE, ctGLWE)
+	keySwitchCost := time.Since(now)
+
+	const linearRepeat = 64
+	now = time.Now()
+	for i := 0; i < linearRepeat; i++ {
+		vec.ScalarMulAddTo(ctOut.Value, ct.Value, T(i))
+	}
+	linearCost := time.Since(now) / linearRepeat
+
+	return CostModel{
+		BootstrapLUT: bootstrapCost,
+		KeySwitchLWE: keySwitchCost,
+		Linear:       linearCost,
+	}
+}
+
+// Execute executes g on inputs and returns the outputs.
+//
+// Panics if the number of inputs does not match.
+func (e *Executor[T]) Execute(g *Graph[T], inputs []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newOutputs(g)
+	e.ExecuteTo(ctOut, g, inputs)
+	return ctOut
+}
+
+// ExecuteTo executes g on inputs and writes the outputs to ctOut.
+//
+// Panics if the number of inputs or outputs does not match.
+func (e *Executor[T]) ExecuteTo(ctOut []tfhe.LWECiphertext[T], g *Graph[T], inputs []tfhe.LWECiphertext[T]) {
+	values := e.newValues(g, inputs)
+	for _, level := range g.Levels() {
+		for _, i := range level {
+			e.executeNodeTo(e.Evaluator, values, g, i)
+		}
+	}
+	e.copyOutputs(ctOut, g, values)
+}
+
+// ExecuteParallel executes g on inputs in parallel and returns the outputs.
+//
+// Panics if the number of inputs does not match.
+func (e *Executor[T]) ExecuteParallel(g *Graph[T], inputs []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newOutputs(g)
+	e.ExecuteParallelTo(ctOut, g, inputs)
+	return ctOut
+}
+
+// ExecuteParallelTo executes g on inputs in parallel and writes the outputs to ctOut.
+//
+// Nodes are executed level by level, as returned by [Graph.Levels],
+// and nodes in each level are distributed across copies of the Evaluator.
+//
+// Panics if the number of inputs or outputs does not match.
+func (e *Executor[T]) ExecuteParallelTo(ctOut []tfhe.LWECiphertext[T], g *Graph[T], inputs []tfhe.LWECiphertext[T]) {
+	if e.evaluatorPool == nil {
+		e.evaluatorPool = make([]*tfhe.Evaluator[T], e.WorkerCount())
+		for i := range e.evaluatorPool {
+			e.evaluatorPool[i] = e.Evaluator.SafeCopy()
+		}
+	}
+
+	values := e.newValues(g, inputs)
+	for _, level := range g.Levels() {
+		workerCount := len(e.evaluatorPool)
+		if len(level) < workerCount {
+			workerCount = len(level)
+		}
+
+		jobs := make(chan int)
+		go func() {
+			defer close(jobs)
+			for _, i := range level {
+				jobs <- i
+			}
+		}()
+
+		var wg sync.WaitGroup
+		wg.Add(workerCount)
+		for i := 0; i < workerCount; i++ {
+			go func(eval *tfhe.Evaluator[T]) {
+				for i := range jobs {
+					e.executeNodeTo(eval, values, g, i)
+				}
+				wg.Done()
+			}(e.evaluatorPool[i])
+		}
+		wg.Wait()
+	}
+	e.copyOutputs(ctOut, g, values)
+}
+
+// newOutputs allocates the outputs of g.
+func (e *Executor[T]) newOutputs(g *Graph[T]) []tfhe.LWECiphertext[T] {
+	ctOut := make([]tfhe.LWECiphertext[T], len(g.Outputs))
+	for k, i := range g.Outputs {
+		ctOut[k] = tfhe.NewLWECiphertextCustom[T](g.Nodes[i].LWEDimension)
+	}
+	return ctOut
+}
+
+// newValues allocates the values of the nodes of g.
+// The input nodes are not copied, since they are never written.
+func (e *Executor[T]) newValues(g *Graph[T], inputs []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	if len(inputs) != len(g.Inputs) {
+		panic("Input count mismatch")
+	}
+
+	values := make([]tfhe.LWECiphertext[T], len(g.Nodes))
+	for k, i := range g.Inputs {
+		if len(inputs[k].Value) != g.Nodes[i].LWEDimension+1 {
+			panic("LWE dimension mismatch")
+		}
+		values[i] = inputs[k]
+	}
+
+	live := g.live()
+	for i, n := range g.Nodes {
+		if live[i] && n.Op != OpInput {
+			values[i] = tfhe.NewLWECiphertextCustom[T](n.LWEDimension)
+		}
+	}
+	return values
+}
+
+// copyOutputs copies the values of the output nodes of g to ctOut.
+func (e *Executor[T]) copyOutputs(ctOut []tfhe.LWECiphertext[T], g *Graph[T], values []tfhe.LWECiphertext[T]) {
+	if len(ctOut) != len(g.Outputs) {
+		panic("Output count mismatch")
+	}
+
+	for k, i := range g.Outputs {
+		ctOut[k].CopyFrom(values[i])
+	}
+}
+
+// executeNodeTo executes the i-th node of g using eval and writes the output to values.
+func (e *Executor[T]) executeNodeTo(eval *tfhe.Evaluator[T], values []tfhe.LWECiphertext[T], g *Graph[T], i int) {
+	n := g.Nodes[i]
+	ctOut := values[i]
+	switch n.Op {
+	case OpAddLWE:
+		eval.AddLWETo(ctOut, values[n.Inputs[0]], values[n.Inputs[1]])
+	case OpSubLWE:
+		eval.SubLWETo(ctOut, values[n.Inputs[0]], values[n.Inputs[1]])
+	case OpNegLWE:
+		eval.NegLWETo(ctOut, values[n.Inputs[0]])
+	case OpScalarMulLWE:
+		eval.ScalarMulLWETo(ctOut, values[n.Inputs[0]], n.Coeffs[0])
+	case OpAddPlainLWE:
+		eval.AddPlainLWETo(ctOut, values[n.Inputs[0]], tfhe.LWEPlaintext[T]{Value: n.Constant})
+	case OpLinear:
+		ctOut.Clear()
+		for k, j := range n.Inputs {
+			vec.ScalarMulAddTo(ctOut.Value, values[j].Value, n.Coeffs[k])
+		}
+		ctOut.Value[0] += n.Constant
+	case OpBootstrapLUT:
+		eval.BootstrapLUTTo(ctOut, values[n.Inputs[0]], g.LUTs[n.LUT])
+	case OpKeySwitchLWE:
+		eval.KeySwitchLWETo(ctOut, values[n.Inputs[0]], g.KeySwitchKeys[n.KeySwitchKey])
+	}
+}
@@ -0,0 +1,315 @@
+// Package graph implements lazily built computation graphs
+// of LWE operations on top of [tfhe.Evaluator].
+//
+// A [Graph] records operations instead of running them,
+// so that it can be inspected and optimized before execution.
+// It reports the number of bootstrappings and the estimated latency,
+// and an [Executor] runs it on a pool of evaluators.
+package graph
+
+import (
+	"github.com/sp301415/tfhe-go/math/vec"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// Op is an enum type for the operation of a node.
+type Op int
+
+const (
+	// OpInput is an input ciphertext.
+	OpInput Op = iota
+	// OpAddLWE computes in0 + in1.
+	OpAddLWE
+	// OpSubLWE computes in0 - in1.
+	OpSubLWE
+	// OpNegLWE computes -in0.
+	OpNegLWE
+	// OpScalarMulLWE computes Coeffs[0] * in0.
+	OpScalarMulLWE
+	// OpAddPlainLWE computes in0 + Constant.
+	OpAddPlainLWE
+	// OpLinear computes sum of Coeffs[i] * in[i], plus Constant.
+	// It is created by fusing linear operations in [Graph.Optimize].
+	OpLinear
+	// OpBootstrapLUT bootstraps in0 with respect to LUTs[LUT].
+	OpBootstrapLUT
+	// OpKeySwitchLWE switches key of in0 using KeySwitchKeys[KeySwitchKey].
+	OpKeySwitchLWE
+)
+
+// opNames is the names of each Op.
+var opNames = [...]string{
+	OpInput:        "Input",
+	OpAddLWE:       "AddLWE",
+	OpSubLWE:       "SubLWE",
+	OpNegLWE:       "NegLWE",
+	OpScalarMulLWE: "ScalarMulLWE",
+	OpAddPlainLWE:  "AddPlainLWE",
+	OpLinear:       "Linear",
+	OpBootstrapLUT: "BootstrapLUT",
+	OpKeySwitchLWE: "KeySwitchLWE",
+}
+
+// String returns the name of the operation.
+func (op Op) String() string {
+	if op < 0 || int(op) >= len(opNames) {
+		return "Unknown"
+	}
+	return opNames[op]
+}
+
+// IsLinear returns true if the operation is linear,
+// i.e. it does not bootstrap or switch keys.
+func (op Op) IsLinear() bool {
+	switch op {
+	case OpAddLWE, OpSubLWE, OpNegLWE, OpScalarMulLWE, OpAddPlainLWE, OpLinear:
+		return true
+	}
+	return false
+}
+
+// Node is a node of a [Graph].
+type Node[T tfhe.TorusInt] struct {
+	// Op is the operation of this node.
+	Op Op
+	// Inputs is the indices of the input nodes.
+	Inputs []int
+	// Coeffs is the coefficients of the inputs,
+	// for OpScalarMulLWE and OpLinear.
+	Coeffs []T
+	// Constant is the plaintext added to the output,
+	// for OpAddPlainLWE and OpLinear.
+	Constant T
+	// LUT is the index of the lookup table in Graph.LUTs,
+	// for OpBootstrapLUT.
+	LUT int
+	// KeySwitchKey is the index of the keyswitching key in Graph.KeySwitchKeys,
+	// for OpKeySwitchLWE.
+	KeySwitchKey int
+	// LWEDimension is the dimension of the output ciphertext.
+	LWEDimension int
+}
+
+// Graph is a lazily built computation graph of LWE ciphertexts.
+//
+// Nodes are added by methods such as [Graph.AddLWE], which return the index of the new node.
+// Nodes are always topologically sorted, so inputs of a node have smaller indices.
+type Graph[T tfhe.TorusInt] struct {
+	// Params is the parameters for this Graph.
+	Params tfhe.Parameters[T]
+
+	// Nodes is the nodes of this Graph.
+	Nodes []Node[T]
+	// Inputs is the indices of the input nodes, in the order they were added.
+	Inputs []int
+	// Outputs is the indices of the output nodes, in the order they were added.
+	// A node can appear multiple times.
+	Outputs []int
+
+	// LUTs is the lookup tables used in this Graph.
+	// Identical lookup tables are stored once.
+	LUTs []tfhe.LookUpTable[T]
+	// KeySwitchKeys is the keyswitching keys used in this Graph.
+	KeySwitchKeys []tfhe.LWEKeySwitchKey[T]
+}
+
+// NewGraph creates a new empty [Graph].
+func NewGraph[T tfhe.TorusInt](params tfhe.Parameters[T]) *Graph[T] {
+	return &Graph[T]{
+		Params: params,
+	}
+}
+
+// addNode adds n to the graph and returns its index.
+func (g *Graph[T]) addNode(n Node[T]) int {
+	for _, i := range n.Inputs {
+		if i < 0 || i >= len(g.Nodes) {
+			panic("Node index out of range")
+		}
+	}
+	g.Nodes = append(g.Nodes, n)
+	return len(g.Nodes) - 1
+}
+
+// addLinearNode adds a linear node to the graph and returns its index.
+func (g *Graph[T]) addLinearNode(op Op, coeffs []T, constant T, in ...int) int {
+	for _, i := range in {
+		if i < 0 || i >= len(g.Nodes) {
+			panic("Node index out of range")
+		}
+		if g.Nodes[i].LWEDimension != g.Nodes[in[0]].LWEDimension {
+			panic("LWE dimension mismatch")
+		}
+	}
+	return g.addNode(Node[T]{Op: op, Inputs: in, Coeffs: coeffs, Constant: constant, LWEDimension: g.Nodes[in[0]].LWEDimension})
+}
+
+// Input adds an input ciphertext and returns its index.
+// The input ciphertext should be of the default LWE dimension.
+func (g *Graph[T]) Input() int {
+	i := g.addNode(Node[T]{Op: OpInput, LWEDimension: g.Params.DefaultLWEDimension()})
+	g.Inputs = append(g.Inputs, i)
+	return i
+}
+
+// Output marks the node x as an output.
+func (g *Graph[T]) Output(x int) {
+	if x < 0 || x >= len(g.Nodes) {
+		panic("Node index out of range")
+	}
+	g.Outputs = append(g.Outputs, x)
+}
+
+// AddLWE adds a node computing x + y.
+func (g *Graph[T]) AddLWE(x, y int) int {
+	return g.addLinearNode(OpAddLWE, nil, 0, x, y)
+}
+
+// SubLWE adds a node computing x - y.
+func (g *Graph[T]) SubLWE(x, y int) int {
+	return g.addLinearNode(OpSubLWE, nil, 0, x, y)
+}
+
+// NegLWE adds a node computing -x.
+func (g *Graph[T]) NegLWE(x int) int {
+	return g.addLinearNode(OpNegLWE, nil, 0, x)
+}
+
+// ScalarMulLWE adds a node computing c * x.
+func (g *Graph[T]) ScalarMulLWE(x int, c T) int {
+	return g.addLinearNode(OpScalarMulLWE, []T{c}, 0, x)
+}
+
+// AddPlainLWE adds a node computing x + pt.
+func (g *Graph[T]) AddPlainLWE(x int, pt tfhe.LWEPlaintext[T]) int {
+	return g.addLinearNode(OpAddPlainLWE, nil, pt.Value, x)
+}
+
+// SubPlainLWE adds a node computing x - pt.
+// This is recorded as an OpAddPlainLWE node with -pt.
+func (g *Graph[T]) SubPlainLWE(x int, pt tfhe.LWEPlaintext[T]) int {
+	return g.addLinearNode(OpAddPlainLWE, nil, -pt.Value, x)
+}
+
+// BootstrapLUT adds a node bootstrapping x with respect to lut.
+// The input ciphertext should be of the default LWE dimension.
+func (g *Graph[T]) BootstrapLUT(x int, lut tfhe.LookUpTable[T]) int {
+	if x < 0 || x >= len(g.Nodes) {
+		panic("Node index out of range")
+	}
+	if g.Nodes[x].LWEDimension != g.Params.DefaultLWEDimension() {
+		panic("LWE dimension mismatch")
+	}
+	return g.addNode(Node[T]{Op: OpBootstrapLUT, Inputs: []int{x}, LUT: g.lutIndex(lut), LWEDimension: g.Params.DefaultLWEDimension()})
+}
+
+// lutIndex returns the index of lut in g.LUTs, adding a copy of it if it does not exist.
+func (g *Graph[T]) lutIndex(lut tfhe.LookUpTable[T]) int {
+LUTs:
+	for i, l := range g.LUTs {
+		if len(l.Value) != len(lut.Value) {
+			continue
+		}
+		for j := range l.Value {
+			if !vec.Equals(l.Value[j].Coeffs, lut.Value[j].Coeffs) {
+				continue LUTs
+			}
+		}
+		return i
+	}
+	g.LUTs = append(g.LUTs, lut.Copy())
+	return len(g.LUTs) - 1
+}
+
+// KeySwitchLWE adds a node switching key of x using ksk.
+// The input ciphertext should be of dimension ksk.InputLWEDimension.
+func (g *Graph[T]) KeySwitchLWE(x int, ksk tfhe.LWEKeySwitchKey[T]) int {
+	if x < 0 || x >= len(g.Nodes) {
+		panic("Node index out of range")
+	}
+	if g.Nodes[x].LWEDimension != ksk.InputLWEDimension() {
+		panic("LWE dimension mismatch")
+	}
+
+	idx := len(g.KeySwitchKeys)
+	for i, k := range g.KeySwitchKeys {
+		if &k.Value[0] == &ksk.Value[0] {
+			idx = i
+			break
+		}
+	}
+	if idx == len(g.KeySwitchKeys) {
+		g.KeySwitchKeys = append(g.KeySwitchKeys, ksk)
+	}
+
+	outputDimension := len(ksk.Value[0].Value[0].Value) - 1
+	return g.addNode(Node[T]{Op: OpKeySwitchLWE, Inputs: []int{x}, KeySwitchKey: idx, LWEDimension: outputDimension})
+}
+
+// BootstrapCount returns the number of bootstrappings needed to execute the graph.
+// Only nodes that affect the outputs are counted.
+func (g *Graph[T]) BootstrapCount() int {
+	return g.countOp(OpBootstrapLUT)
+}
+
+// KeySwitchCount returns the number of keyswitchings needed to execute the graph,
+// excluding the ones inside bootstrappings.
+// Only nodes that affect the outputs are counted.
+func (g *Graph[T]) KeySwitchCount() int {
+	return g.countOp(OpKeySwitchLWE)
+}
+
+// countOp returns the number of live nodes with given operation.
+func (g *Graph[T]) countOp(op Op) int {
+	count := 0
+	for i, live := range g.live() {
+		if live && g.Nodes[i].Op == op {
+			count++
+		}
+	}
+	return count
+}
+
+// live returns whether each node affects the outputs.
+func (g *Graph[T]) live() []bool {
+	live := make([]bool, len(g.Nodes))
+	for _, i := range g.Outputs {
+		live[i] = true
+	}
+	for i := len(g.Nodes) - 1; i >= 0; i-- {
+		if live[i] {
+			for _, j := range g.Nodes[i].Inputs {
+				live[j] = true
+			}
+		}
+	}
+	return live
+}
+
+// Levels returns the live non-input nodes grouped by levels,
+// so that nodes in the same level are independent of each other.
+// The level of a node is one plus the maximum level of its inputs,
+// where input nodes have level zero.
+func (g *Graph[T]) Levels() [][]int {
+	live := g.live()
+	level := make([]int, len(g.Nodes))
+	var levels [][]int
+	for i, n := range g.Nodes {
+		if !live[i] || n.Op == OpInput {
+			continue
+		}
+
+		for _, j := range n.Inputs {
+			if level[j] > level[i] {
+				level[i] = level[j]
+			}
+		}
+		level[i]++
+
+		if level[i] > len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[level[i]-1] = append(levels[level[i]-1], i)
+	}
+	return levels
+}
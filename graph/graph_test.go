@@ -0,0 +1,141 @@
+package graph_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sp301415/tfhe-go/graph"
+	"github.com/sp301415/tfhe-go/tfhe"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	params = tfhe.ParamsUint3.Compile()
+	enc    = tfhe.NewEncryptor(params)
+	eval   = tfhe.NewEvaluator(params, enc.GenEvalKeyParallel())
+	exec   = graph.NewExecutor(params, eval.EvalKey)
+)
+
+// testGraph returns a graph computing 2 * ((2 * (x + y) + 1) / 2),
+// with a redundant bootstrapping and a dead node.
+func testGraph() *graph.Graph[uint64] {
+	g := graph.NewGraph(params)
+	x, y := g.Input(), g.Input()
+
+	u := g.AddPlainLWE(g.ScalarMulLWE(g.AddLWE(x, y), 2), enc.EncodeLWE(1))
+	b0 := g.BootstrapLUT(u, eval.GenLUT(func(x int) int { return x / 2 }))
+	b1 := g.BootstrapLUT(u, eval.GenLUT(func(x int) int { return x / 2 }))
+	g.BootstrapLUT(x, eval.GenLUT(func(x int) int { return x }))
+
+	g.Output(g.AddLWE(b0, b1))
+	g.Output(g.NegLWE(g.NegLWE(x)))
+	return g
+}
+
+func TestGraph(t *testing.T) {
+	t.Run("BootstrapCount", func(t *testing.T) {
+		g := testGraph()
+		assert.Equal(t, 2, g.BootstrapCount())
+		assert.Equal(t, 2, len(g.LUTs))
+	})
+
+	t.Run("Optimize", func(t *testing.T) {
+		g := testGraph()
+		g.Optimize()
+
+		assert.Equal(t, 1, g.BootstrapCount())
+		assert.Equal(t, 1, len(g.LUTs))
+		assert.Equal(t, 3, len(g.Levels()))
+		assert.Equal(t, g.Inputs[0], g.Outputs[1])
+
+		for _, n := range g.Nodes {
+			assert.Contains(t, []graph.Op{graph.OpInput, graph.OpLinear, graph.OpBootstrapLUT}, n.Op)
+		}
+	})
+
+	t.Run("EstimateLatency", func(t *testing.T) {
+		g := testGraph()
+		cost := graph.CostModel{BootstrapLUT: time.Second}
+		assert.Equal(t, 2*time.Second, g.EstimateLatency(cost, 1))
+		assert.Equal(t, time.Second, g.EstimateLatency(cost, 2))
+
+		g.Optimize()
+		assert.Equal(t, time.Second, g.EstimateLatency(cost, 1))
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		g := graph.NewGraph(params)
+		assert.Panics(t, func() { g.AddLWE(0, 1) })
+		assert.Panics(t, func() { g.Output(0) })
+	})
+}
+
+func TestExecutor(t *testing.T) {
+	for _, optimize := range []bool{false, true} {
+		g := testGraph()
+		if optimize {
+			g.Optimize()
+		}
+
+		for _, parallel := range []bool{false, true} {
+			t.Run(fmt.Sprintf("Optimize=%v/Parallel=%v", optimize, parallel), func(t *testing.T) {
+				for m0 := 0; m0 < 2; m0++ {
+					for m1 := 0; m1 < 2; m1++ {
+						inputs := []tfhe.LWECiphertext[uint64]{enc.EncryptLWE(m0), enc.EncryptLWE(m1)}
+
+						var ctOut []tfhe.LWECiphertext[uint64]
+						if parallel {
+							ctOut = exec.ExecuteParallel(g, inputs)
+						} else {
+							ctOut = exec.Execute(g, inputs)
+						}
+						assert.Equal(t, 2*(m0+m1), enc.DecryptLWE(ctOut[0]))
+						assert.Equal(t, m0, enc.DecryptLWE(ctOut[1]))
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkExecuteParallel(b *testing.B) {
+	g := graph.NewGraph(params)
+	lut := eval.GenLUT(func(x int) int { return x + 1 })
+	for i := 0; i < 16; i++ {
+		g.Output(g.BootstrapLUT(g.Input(), lut))
+	}
+
+	inputs := make([]tfhe.LWECiphertext[uint64], 16)
+	for i := range inputs {
+		inputs[i] = enc.EncryptLWE(i % 4)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		exec.ExecuteParallel(g, inputs)
+	}
+}
+
+func ExampleGraph() {
+	params := tfhe.ParamsUint3.Compile()
+
+	enc := tfhe.NewEncryptor(params)
+	eval := tfhe.NewEvaluator(params, enc.GenEvalKeyParallel())
+
+	// Build a graph computing (x + y)^2 mod 8.
+	g := graph.NewGraph(params)
+	x, y := g.Input(), g.Input()
+	sq := eval.GenLUT(func(x int) int { return x * x })
+	g.Output(g.BootstrapLUT(g.AddLWE(x, y), sq))
+
+	g.Optimize()
+	fmt.Println(g.BootstrapCount())
+
+	exec := graph.NewExecutor(params, eval.EvalKey)
+	ctOut := exec.ExecuteParallel(g, []tfhe.LWECiphertext[uint64]{enc.EncryptLWE(1), enc.EncryptLWE(2)})
+	fmt.Println(enc.DecryptLWE(ctOut[0]))
+	// Output:
+	// 1
+	// 1
+}
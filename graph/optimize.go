@@ -0,0 +1,217 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// Optimize optimizes the graph in place as follows:
+//
+//   - Consecutive linear operations are fused into a single OpLinear node,
+//     if the intermediate results are not used elsewhere.
+//   - Identical nodes are merged, so that bootstrapping the same ciphertext
+//     with the same lookup table is done only once.
+//   - Nodes not affecting the outputs, and lookup tables and keys they use, are removed.
+//
+// Input nodes are always kept, in the same order.
+// Node indices obtained before optimization are invalidated.
+func (g *Graph[T]) Optimize() {
+	live := g.live()
+	uses := make([]int, len(g.Nodes))
+	for _, i := range g.Outputs {
+		uses[i]++
+	}
+	for i, n := range g.Nodes {
+		if live[i] {
+			for _, j := range n.Inputs {
+				uses[j]++
+			}
+		}
+	}
+
+	// alias[i] is the node that node i is replaced with.
+	alias := make([]int, len(g.Nodes))
+	nodes := make([]Node[T], len(g.Nodes))
+	hash := make(map[string]int)
+	for i, n := range g.Nodes {
+		alias[i] = i
+
+		inputs := make([]int, len(n.Inputs))
+		for k, j := range n.Inputs {
+			inputs[k] = alias[j]
+		}
+		n.Inputs = inputs
+		nodes[i] = n
+
+		if !live[i] || n.Op == OpInput {
+			continue
+		}
+
+		if n.Op.IsLinear() {
+			n = fuseLinear(nodes, uses, n)
+			nodes[i] = n
+			if n.Op == OpLinear && len(n.Inputs) == 1 && n.Coeffs[0] == 1 && n.Constant == 0 {
+				alias[i] = n.Inputs[0]
+				uses[alias[i]] += uses[i] - 1
+				continue
+			}
+		}
+
+		key := fmt.Sprint(n.Op, n.Inputs, n.Coeffs, n.Constant, n.LUT, n.KeySwitchKey, n.LWEDimension)
+		if j, ok := hash[key]; ok {
+			alias[i] = j
+			uses[j] += uses[i]
+			continue
+		}
+		hash[key] = i
+	}
+
+	live = make([]bool, len(nodes))
+	for _, i := range g.Outputs {
+		live[alias[i]] = true
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if live[i] && alias[i] == i {
+			for _, j := range nodes[i].Inputs {
+				live[j] = true
+			}
+		}
+	}
+
+	newIndex := make([]int, len(nodes))
+	lutIndex := make(map[int]int)
+	kskIndex := make(map[int]int)
+	var newNodes []Node[T]
+	var newLUTs []tfhe.LookUpTable[T]
+	var newKeySwitchKeys []tfhe.LWEKeySwitchKey[T]
+	for i, n := range nodes {
+		if alias[i] != i {
+			newIndex[i] = newIndex[alias[i]]
+			continue
+		}
+		if !live[i] && n.Op != OpInput {
+			continue
+		}
+
+		for k, j := range n.Inputs {
+			n.Inputs[k] = newIndex[j]
+		}
+
+		switch n.Op {
+		case OpBootstrapLUT:
+			if _, ok := lutIndex[n.LUT]; !ok {
+				lutIndex[n.LUT] = len(newLUTs)
+				newLUTs = append(newLUTs, g.LUTs[n.LUT])
+			}
+			n.LUT = lutIndex[n.LUT]
+		case OpKeySwitchLWE:
+			if _, ok := kskIndex[n.KeySwitchKey]; !ok {
+				kskIndex[n.KeySwitchKey] = len(newKeySwitchKeys)
+				newKeySwitchKeys = append(newKeySwitchKeys, g.KeySwitchKeys[n.KeySwitchKey])
+			}
+			n.KeySwitchKey = kskIndex[n.KeySwitchKey]
+		}
+
+		newIndex[i] = len(newNodes)
+		newNodes = append(newNodes, n)
+	}
+
+	for k, i := range g.Inputs {
+		g.Inputs[k] = newIndex[i]
+	}
+	for k, i := range g.Outputs {
+		g.Outputs[k] = newIndex[i]
+	}
+	g.Nodes = newNodes
+	g.LUTs = newLUTs
+	g.KeySwitchKeys = newKeySwitchKeys
+}
+
+// fuseLinear returns n as an OpLinear node,
+// inlining the linear inputs of n that are used only once.
+// If nothing is inlined or simplified, n is returned as-is.
+func fuseLinear[T tfhe.TorusInt](nodes []Node[T], uses []int, n Node[T]) Node[T] {
+	fused := Node[T]{Op: OpLinear, LWEDimension: n.LWEDimension}
+	changed := false
+
+	inputs, coeffs, constant := n.linearTerms()
+	fused.Constant = constant
+	for k, j := range inputs {
+		if nodes[j].Op.IsLinear() && uses[j] == 1 {
+			changed = true
+			inputsIn, coeffsIn, constantIn := nodes[j].linearTerms()
+			for kk, jj := range inputsIn {
+				fused.addTerm(jj, coeffs[k]*coeffsIn[kk])
+			}
+			fused.Constant += coeffs[k] * constantIn
+			continue
+		}
+		fused.addTerm(j, coeffs[k])
+	}
+
+	for k := len(fused.Inputs) - 1; k >= 0; k-- {
+		if fused.Coeffs[k] == 0 {
+			fused.Inputs = append(fused.Inputs[:k], fused.Inputs[k+1:]...)
+			fused.Coeffs = append(fused.Coeffs[:k], fused.Coeffs[k+1:]...)
+		}
+	}
+
+	isIdentity := len(fused.Inputs) == 1 && fused.Coeffs[0] == 1 && fused.Constant == 0
+	if !changed && !isIdentity && len(fused.Inputs) == len(inputs) {
+		return n
+	}
+
+	sort.Sort(termSorter[T]{&fused})
+	return fused
+}
+
+// linearTerms returns n as a linear combination of its inputs plus a constant.
+// n should be a linear node.
+func (n Node[T]) linearTerms() (inputs []int, coeffs []T, constant T) {
+	var one T = 1
+	switch n.Op {
+	case OpAddLWE:
+		return n.Inputs, []T{one, one}, 0
+	case OpSubLWE:
+		return n.Inputs, []T{one, -one}, 0
+	case OpNegLWE:
+		return n.Inputs, []T{-one}, 0
+	case OpScalarMulLWE:
+		return n.Inputs, n.Coeffs, 0
+	case OpAddPlainLWE:
+		return n.Inputs, []T{one}, n.Constant
+	}
+	return n.Inputs, n.Coeffs, n.Constant
+}
+
+// addTerm adds c * node j to an OpLinear node.
+func (n *Node[T]) addTerm(j int, c T) {
+	for k, jj := range n.Inputs {
+		if jj == j {
+			n.Coeffs[k] += c
+			return
+		}
+	}
+	n.Inputs = append(n.Inputs, j)
+	n.Coeffs = append(n.Coeffs, c)
+}
+
+// termSorter sorts the terms of an OpLinear node by input index.
+type termSorter[T tfhe.TorusInt] struct {
+	*Node[T]
+}
+
+func (t termSorter[T]) Len() int {
+	return len(t.Inputs)
+}
+
+func (t termSorter[T]) Less(i, j int) bool {
+	return t.Inputs[i] < t.Inputs[j]
+}
+
+func (t termSorter[T]) Swap(i, j int) {
+	t.Inputs[i], t.Inputs[j] = t.Inputs[j], t.Inputs[i]
+	t.Coeffs[i], t.Coeffs[j] = t.Coeffs[j], t.Coeffs[i]
+}
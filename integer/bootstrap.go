@@ -0,0 +1,93 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// GenBivariateLUT generates a lookup table for a bivariate function f.
+// The input of f is two blocks, each smaller than MessageModulus.
+// Output of f is cut by BaseParams.MessageModulus.
+func (e *Evaluator[T]) GenBivariateLUT(f func(x, y int) int) tfhe.LookUpTable[T] {
+	lutOut := tfhe.NewLUT(e.Params.baseParams)
+	e.GenBivariateLUTTo(lutOut, f)
+	return lutOut
+}
+
+// GenBivariateLUTTo generates a lookup table for a bivariate function f and writes it to lutOut.
+// The input of f is two blocks, each smaller than MessageModulus.
+// Output of f is cut by BaseParams.MessageModulus.
+func (e *Evaluator[T]) GenBivariateLUTTo(lutOut tfhe.LookUpTable[T], f func(x, y int) int) {
+	e.Evaluator.GenLUTTo(lutOut, e.bivariate(f))
+}
+
+// bivariate converts a bivariate function f to a function on a packed block.
+func (e *Evaluator[T]) bivariate(f func(x, y int) int) func(int) int {
+	messageModulus := int(e.Params.messageModulus)
+	return func(x int) int {
+		if x >= messageModulus*messageModulus {
+			return 0
+		}
+		return f(x/messageModulus, x%messageModulus)
+	}
+}
+
+// BootstrapBivariateFunc returns a bootstrapped block of f(ct0, ct1).
+// ct0 and ct1 should be blocks smaller than MessageModulus.
+func (e *Evaluator[T]) BootstrapBivariateFunc(ct0, ct1 tfhe.LWECiphertext[T], f func(x, y int) int) tfhe.LWECiphertext[T] {
+	e.GenBivariateLUTTo(e.buf.lut, f)
+	return e.BootstrapBivariateLUT(ct0, ct1, e.buf.lut)
+}
+
+// BootstrapBivariateFuncTo bootstraps f(ct0, ct1) and writes it to ctOut.
+// ct0 and ct1 should be blocks smaller than MessageModulus.
+func (e *Evaluator[T]) BootstrapBivariateFuncTo(ctOut, ct0, ct1 tfhe.LWECiphertext[T], f func(x, y int) int) {
+	e.GenBivariateLUTTo(e.buf.lut, f)
+	e.BootstrapBivariateLUTTo(ctOut, ct0, ct1, e.buf.lut)
+}
+
+// BootstrapBivariateLUT returns a bootstrapped block of two blocks with respect to the given bivariate LUT.
+// ct0 and ct1 should be blocks smaller than MessageModulus.
+func (e *Evaluator[T]) BootstrapBivariateLUT(ct0, ct1 tfhe.LWECiphertext[T], lut tfhe.LookUpTable[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.BootstrapBivariateLUTTo(ctOut, ct0, ct1, lut)
+	return ctOut
+}
+
+// BootstrapBivariateLUTTo bootstraps two blocks with respect to the given bivariate LUT and writes it to ctOut.
+// ct0 and ct1 should be blocks smaller than MessageModulus.
+//
+// The two blocks are packed into one block ct0 * MessageModulus + ct1,
+// which fits into the carry space.
+func (e *Evaluator[T]) BootstrapBivariateLUTTo(ctOut, ct0, ct1 tfhe.LWECiphertext[T], lut tfhe.LookUpTable[T]) {
+	e.packTo(e.buf.ctPack, ct0, ct1)
+	e.Evaluator.BootstrapLUTTo(ctOut, e.buf.ctPack, lut)
+}
+
+// packTo packs two blocks into one block ctOut = ct0 * MessageModulus + ct1.
+func (e *Evaluator[T]) packTo(ctOut, ct0, ct1 tfhe.LWECiphertext[T]) {
+	e.Evaluator.ScalarMulLWETo(ctOut, ct0, e.Params.messageModulus)
+	e.Evaluator.AddLWETo(ctOut, ctOut, ct1)
+}
+
+// genLUTPair generates a LUT pair based on functions f0 and f1.
+func (e *Evaluator[T]) genLUTPair(f0, f1 func(int) int) lutPair[T] {
+	if e.ManyLUTEvaluator != nil {
+		return lutPair[T]{lutMany: e.ManyLUTEvaluator.GenLUT([]func(int) int{f0, f1})}
+	}
+	return lutPair[T]{lut: [2]tfhe.LookUpTable[T]{e.Evaluator.GenLUT(f0), e.Evaluator.GenLUT(f1)}}
+}
+
+// bootstrapLUTPairTo bootstraps ct with respect to the given LUT pair
+// and writes the results to ctOut0 and ctOut1.
+// If many-LUT bootstrapping is used, this takes only one bootstrap.
+// ct should not be ctOut1.
+func (e *Evaluator[T]) bootstrapLUTPairTo(ctOut0, ctOut1, ct tfhe.LWECiphertext[T], lut lutPair[T]) {
+	if e.ManyLUTEvaluator != nil {
+		e.ManyLUTEvaluator.BootstrapLUTTo(e.buf.ctManyLUT, ct, lut.lutMany)
+		ctOut0.CopyFrom(e.buf.ctManyLUT[0])
+		ctOut1.CopyFrom(e.buf.ctManyLUT[1])
+		return
+	}
+	e.Evaluator.BootstrapLUTTo(ctOut1, ct, lut.lut[1])
+	e.Evaluator.BootstrapLUTTo(ctOut0, ct, lut.lut[0])
+}
@@ -0,0 +1,92 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// CRTEncryptor encrypts and decrypts CRT integers.
+// This is meant to be private, only for clients.
+//
+// CRTEncryptor is not safe for concurrent use.
+// Use [CRTEncryptor.SafeCopy] to get a safe copy.
+type CRTEncryptor[T tfhe.TorusInt] struct {
+	// Params is the parameter set for this CRTEncryptor.
+	Params CRTParameters[T]
+	// Encryptor is a generic Encryptor for this CRTEncryptor.
+	Encryptor *tfhe.Encryptor[T]
+}
+
+// NewCRTEncryptor creates a new [CRTEncryptor].
+// It also automatically samples LWE and GLWE key.
+func NewCRTEncryptor[T tfhe.TorusInt](params CRTParameters[T]) *CRTEncryptor[T] {
+	return &CRTEncryptor[T]{
+		Params:    params,
+		Encryptor: tfhe.NewEncryptor(params.baseParams),
+	}
+}
+
+// NewCRTEncryptorWithKey creates a new [CRTEncryptor] with given parameters and key.
+func NewCRTEncryptorWithKey[T tfhe.TorusInt](params CRTParameters[T], sk tfhe.SecretKey[T]) *CRTEncryptor[T] {
+	return &CRTEncryptor[T]{
+		Params:    params,
+		Encryptor: tfhe.NewEncryptorWithKey(params.baseParams, sk),
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *CRTEncryptor[T]) SafeCopy() *CRTEncryptor[T] {
+	return &CRTEncryptor[T]{
+		Params:    e.Params,
+		Encryptor: e.Encryptor.SafeCopy(),
+	}
+}
+
+// EncryptFheUintCRT encrypts an unsigned integer message to FheUintCRT.
+// The message is cut by Modulus.
+func (e *CRTEncryptor[T]) EncryptFheUintCRT(message uint64) FheUintCRT[T] {
+	ctOut := NewFheUintCRT(e.Params)
+	e.EncryptFheUintCRTTo(ctOut, message)
+	return ctOut
+}
+
+// EncryptFheUintCRTTo encrypts an unsigned integer message and writes it to ctOut.
+// The message is cut by Modulus.
+func (e *CRTEncryptor[T]) EncryptFheUintCRTTo(ctOut FheUintCRT[T], message uint64) {
+	for i, q := range e.Params.moduli {
+		pt := e.Encryptor.EncodeLWECustom(int(message%uint64(q)), e.Params.residueMessageModuli[i], e.Params.residueScales[i])
+		e.Encryptor.EncryptLWEPlaintextTo(ctOut.Value[i], pt)
+	}
+}
+
+// DecryptFheUintCRT decrypts FheUintCRT to an unsigned integer message modulo Modulus.
+//
+// The residues are recombined using Garner's algorithm.
+func (e *CRTEncryptor[T]) DecryptFheUintCRT(ct FheUintCRT[T]) uint64 {
+	var message, modulus uint64 = 0, 1
+	for i, q := range e.Params.moduli {
+		pt := e.Encryptor.DecryptLWEPhase(ct.Value[i])
+		r := uint64(e.Encryptor.DecodeLWECustom(pt, e.Params.residueMessageModuli[i], e.Params.residueScales[i])) % uint64(q)
+
+		// Find t such that message + modulus * t = r mod q.
+		d := int64((r + uint64(q) - message%uint64(q)) % uint64(q))
+		t := uint64(d*num.ModInverse(int64(modulus%uint64(q)), int64(q))) % uint64(q)
+
+		message += modulus * t
+		modulus *= uint64(q)
+	}
+	return message
+}
+
+// GenEvalKey samples a new evaluation key for bootstrapping.
+//
+// This can take a long time.
+// Use [CRTEncryptor.GenEvalKeyParallel] for better key generation performance.
+func (e *CRTEncryptor[T]) GenEvalKey() tfhe.EvaluationKey[T] {
+	return e.Encryptor.GenEvalKey()
+}
+
+// GenEvalKeyParallel samples a new evaluation key for bootstrapping in parallel.
+func (e *CRTEncryptor[T]) GenEvalKeyParallel() tfhe.EvaluationKey[T] {
+	return e.Encryptor.GenEvalKeyParallel()
+}
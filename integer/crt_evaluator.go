@@ -0,0 +1,196 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// CRTEvaluator evaluates homomorphic operations on CRT integers.
+// All ciphertexts should be encrypted with [CRTEncryptor].
+// This is meant to be public, usually for servers.
+//
+// Every operation is done residue-wise, with at most one bootstrap per residue,
+// and returns a ciphertext whose residues are reduced.
+//
+// CRTEvaluator is not safe for concurrent use.
+// Use [CRTEvaluator.SafeCopy] to get a safe copy.
+type CRTEvaluator[T tfhe.TorusInt] struct {
+	// Params is the parameter set for this CRTEvaluator.
+	Params CRTParameters[T]
+	// Evaluator is a generic Evaluator for this CRTEvaluator.
+	Evaluator *tfhe.Evaluator[T]
+
+	// lutReduce is a LUT for reducing each residue.
+	lutReduce []tfhe.LookUpTable[T]
+	// lutMul is a bivariate LUT for multiplying each residue.
+	lutMul []tfhe.LookUpTable[T]
+
+	buf crtEvaluatorBuffer[T]
+}
+
+// crtEvaluatorBuffer is a buffer for CRTEvaluator.
+type crtEvaluatorBuffer[T tfhe.TorusInt] struct {
+	// ctPack is a residue packing two residues for bivariate bootstrapping.
+	ctPack tfhe.LWECiphertext[T]
+
+	// lut is an empty lookup table.
+	lut tfhe.LookUpTable[T]
+}
+
+// NewCRTEvaluator creates a new [CRTEvaluator].
+// This does not copy evaluation keys, since they may be large.
+func NewCRTEvaluator[T tfhe.TorusInt](params CRTParameters[T], evk tfhe.EvaluationKey[T]) *CRTEvaluator[T] {
+	eval := &CRTEvaluator[T]{
+		Params:    params,
+		Evaluator: tfhe.NewEvaluator(params.baseParams, evk),
+
+		lutReduce: make([]tfhe.LookUpTable[T], params.ResidueCount()),
+		lutMul:    make([]tfhe.LookUpTable[T], params.ResidueCount()),
+
+		buf: newCRTEvaluatorBuffer(params),
+	}
+
+	for i, q := range params.moduli {
+		q := int(q)
+		eval.lutReduce[i] = eval.genResidueLUT(i, func(x int) int { return x % q })
+		eval.lutMul[i] = eval.genResidueLUT(i, func(x int) int { return ((x / q) * (x % q)) % q })
+	}
+
+	return eval
+}
+
+// newCRTEvaluatorBuffer creates a new [crtEvaluatorBuffer].
+func newCRTEvaluatorBuffer[T tfhe.TorusInt](params CRTParameters[T]) crtEvaluatorBuffer[T] {
+	return crtEvaluatorBuffer[T]{
+		ctPack: tfhe.NewLWECiphertext(params.baseParams),
+
+		lut: tfhe.NewLUT(params.baseParams),
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *CRTEvaluator[T]) SafeCopy() *CRTEvaluator[T] {
+	return &CRTEvaluator[T]{
+		Params:    e.Params,
+		Evaluator: e.Evaluator.SafeCopy(),
+
+		lutReduce: e.lutReduce,
+		lutMul:    e.lutMul,
+
+		buf: newCRTEvaluatorBuffer(e.Params),
+	}
+}
+
+// genResidueLUT generates a LUT for the i-th residue based on function f.
+func (e *CRTEvaluator[T]) genResidueLUT(i int, f func(int) int) tfhe.LookUpTable[T] {
+	lutOut := tfhe.NewLUT(e.Params.baseParams)
+	e.genResidueLUTTo(lutOut, i, f)
+	return lutOut
+}
+
+// genResidueLUTTo generates a LUT for the i-th residue based on function f and writes it to lutOut.
+func (e *CRTEvaluator[T]) genResidueLUTTo(lutOut tfhe.LookUpTable[T], i int, f func(int) int) {
+	e.Evaluator.GenLUTCustomTo(lutOut, f, e.Params.residueMessageModuli[i], e.Params.residueScales[i])
+}
+
+// encodeResidue encodes a message to the i-th residue plaintext.
+func (e *CRTEvaluator[T]) encodeResidue(i int, message int) tfhe.LWEPlaintext[T] {
+	return e.Evaluator.EncodeLWECustom(message, e.Params.residueMessageModuli[i], e.Params.residueScales[i])
+}
+
+// Add returns ct0 + ct1 modulo Modulus.
+func (e *CRTEvaluator[T]) Add(ct0, ct1 FheUintCRT[T]) FheUintCRT[T] {
+	ctOut := NewFheUintCRT(e.Params)
+	e.AddTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// AddTo computes ctOut = ct0 + ct1 modulo Modulus.
+func (e *CRTEvaluator[T]) AddTo(ctOut, ct0, ct1 FheUintCRT[T]) {
+	for i := range ctOut.Value {
+		e.Evaluator.AddLWETo(ctOut.Value[i], ct0.Value[i], ct1.Value[i])
+		e.Evaluator.BootstrapLUTTo(ctOut.Value[i], ctOut.Value[i], e.lutReduce[i])
+	}
+}
+
+// Sub returns ct0 - ct1 modulo Modulus.
+func (e *CRTEvaluator[T]) Sub(ct0, ct1 FheUintCRT[T]) FheUintCRT[T] {
+	ctOut := NewFheUintCRT(e.Params)
+	e.SubTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// SubTo computes ctOut = ct0 - ct1 modulo Modulus.
+func (e *CRTEvaluator[T]) SubTo(ctOut, ct0, ct1 FheUintCRT[T]) {
+	for i, q := range e.Params.moduli {
+		e.Evaluator.SubLWETo(ctOut.Value[i], ct0.Value[i], ct1.Value[i])
+		ctOut.Value[i].Value[0] += e.encodeResidue(i, int(q)).Value
+		e.Evaluator.BootstrapLUTTo(ctOut.Value[i], ctOut.Value[i], e.lutReduce[i])
+	}
+}
+
+// Neg returns -ct modulo Modulus.
+func (e *CRTEvaluator[T]) Neg(ct FheUintCRT[T]) FheUintCRT[T] {
+	ctOut := NewFheUintCRT(e.Params)
+	e.NegTo(ctOut, ct)
+	return ctOut
+}
+
+// NegTo computes ctOut = -ct modulo Modulus.
+func (e *CRTEvaluator[T]) NegTo(ctOut, ct FheUintCRT[T]) {
+	for i, q := range e.Params.moduli {
+		e.Evaluator.NegLWETo(ctOut.Value[i], ct.Value[i])
+		ctOut.Value[i].Value[0] += e.encodeResidue(i, int(q)).Value
+		e.Evaluator.BootstrapLUTTo(ctOut.Value[i], ctOut.Value[i], e.lutReduce[i])
+	}
+}
+
+// Mul returns ct0 * ct1 modulo Modulus.
+func (e *CRTEvaluator[T]) Mul(ct0, ct1 FheUintCRT[T]) FheUintCRT[T] {
+	ctOut := NewFheUintCRT(e.Params)
+	e.MulTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// MulTo computes ctOut = ct0 * ct1 modulo Modulus.
+//
+// Each pair of residues modulo q is packed into one ciphertext ct0 * q + ct1,
+// and multiplied by bivariate bootstrapping.
+func (e *CRTEvaluator[T]) MulTo(ctOut, ct0, ct1 FheUintCRT[T]) {
+	for i, q := range e.Params.moduli {
+		e.Evaluator.ScalarMulLWETo(e.buf.ctPack, ct0.Value[i], q)
+		e.Evaluator.AddLWETo(e.buf.ctPack, e.buf.ctPack, ct1.Value[i])
+		e.Evaluator.BootstrapLUTTo(ctOut.Value[i], e.buf.ctPack, e.lutMul[i])
+	}
+}
+
+// ScalarAdd returns ct + c modulo Modulus.
+func (e *CRTEvaluator[T]) ScalarAdd(ct FheUintCRT[T], c uint64) FheUintCRT[T] {
+	ctOut := NewFheUintCRT(e.Params)
+	e.ScalarAddTo(ctOut, ct, c)
+	return ctOut
+}
+
+// ScalarAddTo computes ctOut = ct + c modulo Modulus.
+func (e *CRTEvaluator[T]) ScalarAddTo(ctOut, ct FheUintCRT[T], c uint64) {
+	for i, q := range e.Params.moduli {
+		ctOut.Value[i].CopyFrom(ct.Value[i])
+		ctOut.Value[i].Value[0] += e.encodeResidue(i, int(c%uint64(q))).Value
+		e.Evaluator.BootstrapLUTTo(ctOut.Value[i], ctOut.Value[i], e.lutReduce[i])
+	}
+}
+
+// ScalarMul returns ct * c modulo Modulus.
+func (e *CRTEvaluator[T]) ScalarMul(ct FheUintCRT[T], c uint64) FheUintCRT[T] {
+	ctOut := NewFheUintCRT(e.Params)
+	e.ScalarMulTo(ctOut, ct, c)
+	return ctOut
+}
+
+// ScalarMulTo computes ctOut = ct * c modulo Modulus.
+func (e *CRTEvaluator[T]) ScalarMulTo(ctOut, ct FheUintCRT[T], c uint64) {
+	for i, q := range e.Params.moduli {
+		q, cq := int(q), int(c%uint64(q))
+		e.genResidueLUTTo(e.buf.lut, i, func(x int) int { return (x * cq) % q })
+		e.Evaluator.BootstrapLUTTo(ctOut.Value[i], ct.Value[i], e.buf.lut)
+	}
+}
@@ -0,0 +1,142 @@
+package integer
+
+import (
+	"math"
+
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// CRTParametersLiteral is a structure for CRT integer parameters.
+//
+// An integer is represented by its residues modulo pairwise coprime Moduli,
+// each stored in one LWE ciphertext.
+// A residue modulo p is encoded with a custom message modulus p^2,
+// so that two residues can be packed into one ciphertext for bivariate bootstrapping.
+type CRTParametersLiteral[T tfhe.TorusInt] struct {
+	// BaseParams is the base parameter set for this CRTParametersLiteral.
+	BaseParams tfhe.ParametersLiteral[T]
+
+	// Moduli are the pairwise coprime moduli of the residues.
+	// The square of each modulus must be at most BaseParams.MessageModulus,
+	// and their product must fit in uint64.
+	Moduli []T
+}
+
+// WithBaseParams sets the BaseParams and returns the new CRTParametersLiteral.
+func (p CRTParametersLiteral[T]) WithBaseParams(baseParams tfhe.ParametersLiteral[T]) CRTParametersLiteral[T] {
+	p.BaseParams = baseParams
+	return p
+}
+
+// WithModuli sets the Moduli and returns the new CRTParametersLiteral.
+func (p CRTParametersLiteral[T]) WithModuli(moduli []T) CRTParametersLiteral[T] {
+	p.Moduli = moduli
+	return p
+}
+
+// Compile transforms CRTParametersLiteral to read-only CRTParameters.
+// If there is any invalid parameter in the literal, it panics.
+// Default parameters are guaranteed to compile without panicking.
+func (p CRTParametersLiteral[T]) Compile() CRTParameters[T] {
+	baseParams := p.BaseParams.Compile()
+
+	if len(p.Moduli) == 0 {
+		panic("Moduli empty")
+	}
+
+	modulus := uint64(1)
+	for i, q := range p.Moduli {
+		switch {
+		case q < 2:
+			panic("Modulus smaller than two")
+		case q > num.Sqrt(baseParams.MessageModulus()):
+			panic("Modulus squared larger than BaseParams.MessageModulus")
+		case modulus > math.MaxUint64/uint64(q):
+			panic("Product of Moduli overflows uint64")
+		}
+
+		for j := 0; j < i; j++ {
+			if num.Gcd(q, p.Moduli[j]) != 1 {
+				panic("Moduli not pairwise coprime")
+			}
+		}
+
+		modulus *= uint64(q)
+	}
+
+	moduli := make([]T, len(p.Moduli))
+	residueMessageModuli := make([]T, len(p.Moduli))
+	residueScales := make([]T, len(p.Moduli))
+	for i, q := range p.Moduli {
+		moduli[i] = q
+		residueMessageModuli[i] = q * q
+		residueScales[i] = (T(1) << (baseParams.LogQ() - 1)) / (q * q)
+	}
+
+	return CRTParameters[T]{
+		baseParams: baseParams,
+
+		moduli:               moduli,
+		modulus:              modulus,
+		residueMessageModuli: residueMessageModuli,
+		residueScales:        residueScales,
+	}
+}
+
+// CRTParameters are read-only, compiled parameters for CRT integers.
+type CRTParameters[T tfhe.TorusInt] struct {
+	// baseParams is the base parameter set for this CRTParameters.
+	baseParams tfhe.Parameters[T]
+
+	// moduli are the pairwise coprime moduli of the residues.
+	moduli []T
+	// modulus is the product of moduli.
+	modulus uint64
+	// residueMessageModuli are the message moduli used for encoding each residue.
+	// Equals the square of each modulus.
+	residueMessageModuli []T
+	// residueScales are the scales used for encoding each residue.
+	residueScales []T
+}
+
+// BaseParams returns the base parameters for this CRTParameters.
+func (p CRTParameters[T]) BaseParams() tfhe.Parameters[T] {
+	return p.baseParams
+}
+
+// ResidueCount returns the number of residues.
+func (p CRTParameters[T]) ResidueCount() int {
+	return len(p.moduli)
+}
+
+// Moduli returns the pairwise coprime moduli of the residues.
+func (p CRTParameters[T]) Moduli() []T {
+	moduli := make([]T, len(p.moduli))
+	copy(moduli, p.moduli)
+	return moduli
+}
+
+// Modulus returns the product of Moduli.
+// All operations are done modulo Modulus.
+func (p CRTParameters[T]) Modulus() uint64 {
+	return p.modulus
+}
+
+// ResidueMessageModulus returns the message modulus used for encoding the i-th residue.
+func (p CRTParameters[T]) ResidueMessageModulus(i int) T {
+	return p.residueMessageModuli[i]
+}
+
+// ResidueScale returns the scale used for encoding the i-th residue.
+func (p CRTParameters[T]) ResidueScale(i int) T {
+	return p.residueScales[i]
+}
+
+// Literal returns a CRTParametersLiteral from this CRTParameters.
+func (p CRTParameters[T]) Literal() CRTParametersLiteral[T] {
+	return CRTParametersLiteral[T]{
+		BaseParams: p.baseParams.Literal(),
+		Moduli:     p.Moduli(),
+	}
+}
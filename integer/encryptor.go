@@ -0,0 +1,154 @@
+package integer
+
+import (
+	"math"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// Encryptor encrypts and decrypts radix integers.
+// This is meant to be private, only for clients.
+//
+// Encryptor is not safe for concurrent use.
+// Use [Encryptor.SafeCopy] to get a safe copy.
+type Encryptor[T tfhe.TorusInt] struct {
+	// Params is the parameter set for this Encryptor.
+	Params Parameters[T]
+	// Encryptor is a generic Encryptor for this Encryptor.
+	Encryptor *tfhe.Encryptor[T]
+}
+
+// NewEncryptor creates a new [Encryptor].
+// It also automatically samples LWE and GLWE key.
+func NewEncryptor[T tfhe.TorusInt](params Parameters[T]) *Encryptor[T] {
+	return &Encryptor[T]{
+		Params:    params,
+		Encryptor: tfhe.NewEncryptor(params.baseParams),
+	}
+}
+
+// NewEncryptorWithKey creates a new [Encryptor] with given parameters and key.
+func NewEncryptorWithKey[T tfhe.TorusInt](params Parameters[T], sk tfhe.SecretKey[T]) *Encryptor[T] {
+	return &Encryptor[T]{
+		Params:    params,
+		Encryptor: tfhe.NewEncryptorWithKey(params.baseParams, sk),
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *Encryptor[T]) SafeCopy() *Encryptor[T] {
+	return &Encryptor[T]{
+		Params:    e.Params,
+		Encryptor: e.Encryptor.SafeCopy(),
+	}
+}
+
+// EncryptFheUint encrypts an unsigned integer message to FheUint of given bits.
+// The message is cut by 2^bits.
+//
+// Panics if bits is not a positive multiple of LogMessageModulus.
+func (e *Encryptor[T]) EncryptFheUint(message uint64, bits int) FheUint[T] {
+	ctOut := NewFheUint(e.Params, bits)
+	e.EncryptFheUintTo(ctOut, message)
+	return ctOut
+}
+
+// EncryptFheUintTo encrypts an unsigned integer message and writes it to ctOut.
+// The message is cut by the bit length of ctOut.
+func (e *Encryptor[T]) EncryptFheUintTo(ctOut FheUint[T], message uint64) {
+	for i := 0; i < len(ctOut.Value); i++ {
+		e.Encryptor.EncryptLWETo(ctOut.Value[i], int(message&uint64(e.Params.messageModulus-1)))
+		message >>= e.Params.logMessageModulus
+	}
+}
+
+// DecryptFheUint decrypts FheUint to an unsigned integer message.
+//
+// The blocks need not have their carries propagated.
+func (e *Encryptor[T]) DecryptFheUint(ct FheUint[T]) uint64 {
+	var message uint64
+	for i := len(ct.Value) - 1; i >= 0; i-- {
+		message <<= e.Params.logMessageModulus
+		message += uint64(e.Encryptor.DecryptLWE(ct.Value[i]))
+	}
+
+	bits := len(ct.Value) * e.Params.logMessageModulus
+	if bits < 64 {
+		message &= 1<<bits - 1
+	}
+	return message
+}
+
+// EncryptFheInt encrypts a signed integer message to FheUint of given bits,
+// in two's complement.
+// The message is cut by 2^bits.
+//
+// Panics if bits is not a positive multiple of LogMessageModulus.
+func (e *Encryptor[T]) EncryptFheInt(message int64, bits int) FheUint[T] {
+	return e.EncryptFheUint(uint64(message), bits)
+}
+
+// EncryptFheIntTo encrypts a signed integer message in two's complement and writes it to ctOut.
+// The message is cut by the bit length of ctOut.
+func (e *Encryptor[T]) EncryptFheIntTo(ctOut FheUint[T], message int64) {
+	e.EncryptFheUintTo(ctOut, uint64(message))
+}
+
+// DecryptFheInt decrypts FheUint to a signed integer message,
+// interpreting it in two's complement.
+//
+// The blocks need not have their carries propagated.
+func (e *Encryptor[T]) DecryptFheInt(ct FheUint[T]) int64 {
+	message := e.DecryptFheUint(ct)
+
+	bits := len(ct.Value) * e.Params.logMessageModulus
+	if bits < 64 && message>>(bits-1)&1 == 1 {
+		message |= ^uint64(0) << bits
+	}
+	return int64(message)
+}
+
+// EncryptFheFixed encrypts a float64 message to a [FheFixed] of given bits and fractional bits.
+// The message is rounded to the nearest multiple of 2^-fracBits.
+func (e *Encryptor[T]) EncryptFheFixed(message float64, bits, fracBits int) FheFixed[T] {
+	ctOut := NewFheFixed(e.Params, bits, fracBits)
+	e.EncryptFheFixedTo(ctOut, message)
+	return ctOut
+}
+
+// EncryptFheFixedTo encrypts a float64 message and writes it to ctOut.
+// The message is rounded to the nearest multiple of 2^-ctOut.FracBits.
+func (e *Encryptor[T]) EncryptFheFixedTo(ctOut FheFixed[T], message float64) {
+	e.EncryptFheIntTo(ctOut.FheUint, int64(math.Round(math.Ldexp(message, ctOut.FracBits))))
+}
+
+// DecryptFheFixed decrypts a [FheFixed] to a float64 message.
+func (e *Encryptor[T]) DecryptFheFixed(ct FheFixed[T]) float64 {
+	return math.Ldexp(float64(e.DecryptFheInt(ct.FheUint)), -ct.FracBits)
+}
+
+// EncryptBool encrypts a boolean message to a block encrypting 0 or 1.
+func (e *Encryptor[T]) EncryptBool(message bool) tfhe.LWECiphertext[T] {
+	if message {
+		return e.Encryptor.EncryptLWE(1)
+	}
+	return e.Encryptor.EncryptLWE(0)
+}
+
+// DecryptBool decrypts a block encrypting 0 or 1 to a boolean message.
+func (e *Encryptor[T]) DecryptBool(ct tfhe.LWECiphertext[T]) bool {
+	return e.Encryptor.DecryptLWE(ct) != 0
+}
+
+// GenEvalKey samples a new evaluation key for bootstrapping.
+//
+// This can take a long time.
+// Use [Encryptor.GenEvalKeyParallel] for better key generation performance.
+func (e *Encryptor[T]) GenEvalKey() tfhe.EvaluationKey[T] {
+	return e.Encryptor.GenEvalKey()
+}
+
+// GenEvalKeyParallel samples a new evaluation key for bootstrapping in parallel.
+func (e *Encryptor[T]) GenEvalKeyParallel() tfhe.EvaluationKey[T] {
+	return e.Encryptor.GenEvalKeyParallel()
+}
@@ -0,0 +1,170 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+	"github.com/sp301415/tfhe-go/xtfhe"
+)
+
+// Evaluator evaluates homomorphic operations on radix integers.
+// All ciphertexts should be encrypted with [Encryptor].
+// This is meant to be public, usually for servers.
+//
+// Unless stated otherwise, every operation returns a ciphertext
+// whose carries are propagated, i.e. every block holds a value smaller than MessageModulus.
+//
+// Evaluator is not safe for concurrent use.
+// Use [Evaluator.SafeCopy] to get a safe copy.
+type Evaluator[T tfhe.TorusInt] struct {
+	// Params is the parameter set for this Evaluator.
+	Params Parameters[T]
+	// Evaluator is a generic Evaluator for this Evaluator.
+	Evaluator *tfhe.Evaluator[T]
+	// ManyLUTEvaluator is a ManyLUTEvaluator for this Evaluator.
+	// It shares the generic Evaluator, and is nil if many-LUT bootstrapping is not used.
+	ManyLUTEvaluator *xtfhe.ManyLUTEvaluator[T]
+
+	// lutMessage is a LUT for extracting the message of a block.
+	lutMessage tfhe.LookUpTable[T]
+	// lutMessageCarry is a LUT pair for extracting the message and the carry of a block.
+	lutMessageCarry lutPair[T]
+	// lutMulLow is a bivariate LUT for the low digit of the product of two blocks.
+	lutMulLow tfhe.LookUpTable[T]
+	// lutMulLowHigh is a bivariate LUT pair for the low and high digits of the product of two blocks.
+	lutMulLowHigh lutPair[T]
+	// lutSelectTrue is a bivariate LUT for c * x, used for selection.
+	lutSelectTrue tfhe.LookUpTable[T]
+	// lutSelectFalse is a bivariate LUT for (1 - c) * x, used for selection.
+	lutSelectFalse tfhe.LookUpTable[T]
+	// lutSelectBool is a LUT for selecting between two packed booleans.
+	lutSelectBool tfhe.LookUpTable[T]
+
+	buf evaluatorBuffer[T]
+}
+
+// evaluatorBuffer is a buffer for Evaluator.
+type evaluatorBuffer[T tfhe.TorusInt] struct {
+	// ctBlock is a block with the incoming carry added.
+	ctBlock tfhe.LWECiphertext[T]
+	// ctCarry is the carry extracted from the previous block.
+	ctCarry tfhe.LWECiphertext[T]
+	// ctPack is a block packing two blocks for bivariate bootstrapping.
+	ctPack tfhe.LWECiphertext[T]
+	// ctCond is an encrypted boolean used for selection.
+	ctCond tfhe.LWECiphertext[T]
+	// ctLow is the low digit of a block product.
+	ctLow tfhe.LWECiphertext[T]
+	// ctHigh is the high digit of a block product.
+	ctHigh tfhe.LWECiphertext[T]
+	// ctManyLUT is the output of many-LUT bootstrapping.
+	ctManyLUT []tfhe.LWECiphertext[T]
+
+	// lut is an empty lookup table.
+	lut tfhe.LookUpTable[T]
+}
+
+// lutPair is a pair of LUTs, which are evaluated in one bootstrap
+// if many-LUT bootstrapping is used.
+type lutPair[T tfhe.TorusInt] struct {
+	// lut is a pair of LUTs, used if many-LUT bootstrapping is not used.
+	lut [2]tfhe.LookUpTable[T]
+	// lutMany is a many-LUT, used if many-LUT bootstrapping is used.
+	lutMany tfhe.LookUpTable[T]
+}
+
+// NewEvaluator creates a new [Evaluator].
+// This does not copy evaluation keys, since they may be large.
+func NewEvaluator[T tfhe.TorusInt](params Parameters[T], evk tfhe.EvaluationKey[T]) *Evaluator[T] {
+	eval := &Evaluator[T]{
+		Params: params,
+
+		buf: newEvaluatorBuffer(params),
+	}
+
+	if params.lutCount > 1 {
+		eval.ManyLUTEvaluator = xtfhe.NewManyLUTEvaluator(params.manyLUTParams, evk)
+		eval.Evaluator = eval.ManyLUTEvaluator.Evaluator
+	} else {
+		eval.Evaluator = tfhe.NewEvaluator(params.baseParams, evk)
+	}
+
+	messageModulus := int(params.messageModulus)
+	eval.lutMessage = eval.Evaluator.GenLUT(func(x int) int { return x % messageModulus })
+	eval.lutMessageCarry = eval.genLUTPair(
+		func(x int) int { return x % messageModulus },
+		func(x int) int { return x / messageModulus },
+	)
+	eval.lutMulLow = eval.GenBivariateLUT(func(x, y int) int { return (x * y) % messageModulus })
+	eval.lutMulLowHigh = eval.genLUTPair(
+		eval.bivariate(func(x, y int) int { return (x * y) % messageModulus }),
+		eval.bivariate(func(x, y int) int { return (x * y) / messageModulus }),
+	)
+	eval.lutSelectTrue = eval.GenBivariateLUT(func(c, x int) int { return c * x })
+	eval.lutSelectFalse = eval.GenBivariateLUT(func(c, x int) int { return (1 - c) * x })
+	eval.lutSelectBool = eval.Evaluator.GenLUT(func(x int) int {
+		if x>>2&1 == 1 {
+			return x >> 1 & 1
+		}
+		return x & 1
+	})
+
+	return eval
+}
+
+// newEvaluatorBuffer creates a new [evaluatorBuffer].
+func newEvaluatorBuffer[T tfhe.TorusInt](params Parameters[T]) evaluatorBuffer[T] {
+	ctManyLUT := make([]tfhe.LWECiphertext[T], params.lutCount)
+	for i := range ctManyLUT {
+		ctManyLUT[i] = tfhe.NewLWECiphertext(params.baseParams)
+	}
+
+	return evaluatorBuffer[T]{
+		ctBlock:   tfhe.NewLWECiphertext(params.baseParams),
+		ctCarry:   tfhe.NewLWECiphertext(params.baseParams),
+		ctPack:    tfhe.NewLWECiphertext(params.baseParams),
+		ctCond:    tfhe.NewLWECiphertext(params.baseParams),
+		ctLow:     tfhe.NewLWECiphertext(params.baseParams),
+		ctHigh:    tfhe.NewLWECiphertext(params.baseParams),
+		ctManyLUT: ctManyLUT,
+
+		lut: tfhe.NewLUT(params.baseParams),
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *Evaluator[T]) SafeCopy() *Evaluator[T] {
+	eval := &Evaluator[T]{
+		Params: e.Params,
+
+		lutMessage:      e.lutMessage,
+		lutMessageCarry: e.lutMessageCarry,
+		lutMulLow:       e.lutMulLow,
+		lutMulLowHigh:   e.lutMulLowHigh,
+		lutSelectTrue:   e.lutSelectTrue,
+		lutSelectFalse:  e.lutSelectFalse,
+		lutSelectBool:   e.lutSelectBool,
+
+		buf: newEvaluatorBuffer(e.Params),
+	}
+
+	if e.ManyLUTEvaluator != nil {
+		eval.ManyLUTEvaluator = e.ManyLUTEvaluator.SafeCopy()
+		eval.Evaluator = eval.ManyLUTEvaluator.Evaluator
+	} else {
+		eval.Evaluator = e.Evaluator.SafeCopy()
+	}
+
+	return eval
+}
+
+// newFheUint creates a new FheUint with given block count.
+func (e *Evaluator[T]) newFheUint(blockCount int) FheUint[T] {
+	return NewFheUintCustom[T](blockCount, e.Params.baseParams.DefaultLWEDimension())
+}
+
+// digit returns the i-th radix digit of c.
+func (e *Evaluator[T]) digit(c uint64, i int) int {
+	if i*e.Params.logMessageModulus >= 64 {
+		return 0
+	}
+	return int((c >> (i * e.Params.logMessageModulus)) & uint64(e.Params.messageModulus-1))
+}
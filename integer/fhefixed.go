@@ -0,0 +1,38 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// FheFixed is an encrypted signed fixed-point number.
+// It holds a signed integer in two's complement, scaled by 2^FracBits.
+//
+// A FheFixed of b bits has b - FracBits integer bits, including the sign bit.
+type FheFixed[T tfhe.TorusInt] struct {
+	// FheUint holds the scaled integer.
+	FheUint[T]
+	// FracBits is the number of fractional bits.
+	FracBits int
+}
+
+// NewFheFixed creates a new [FheFixed] of given bits and fractional bits.
+//
+// Panics if bits is not a positive multiple of LogMessageModulus,
+// or fracBits is not in [0, bits).
+func NewFheFixed[T tfhe.TorusInt](params Parameters[T], bits, fracBits int) FheFixed[T] {
+	if fracBits < 0 || fracBits >= bits {
+		panic("fracBits out of range")
+	}
+	return FheFixed[T]{FheUint: NewFheUint(params, bits), FracBits: fracBits}
+}
+
+// Copy returns a copy of the ciphertext.
+func (ct FheFixed[T]) Copy() FheFixed[T] {
+	return FheFixed[T]{FheUint: ct.FheUint.Copy(), FracBits: ct.FracBits}
+}
+
+// CopyFrom copies values from the ciphertext.
+func (ct *FheFixed[T]) CopyFrom(ctIn FheFixed[T]) {
+	ct.FheUint.CopyFrom(ctIn.FheUint)
+	ct.FracBits = ctIn.FracBits
+}
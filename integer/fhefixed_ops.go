@@ -0,0 +1,164 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// newFheFixed creates a new FheFixed with given block count and fractional bits.
+func (e *Evaluator[T]) newFheFixed(blockCount, fracBits int) FheFixed[T] {
+	return FheFixed[T]{FheUint: e.newFheUint(blockCount), FracBits: fracBits}
+}
+
+// checkFracBits panics if ct0 and ct1 have different fractional bits.
+func checkFracBits[T tfhe.TorusInt](ct0, ct1 FheFixed[T]) {
+	if ct0.FracBits != ct1.FracBits {
+		panic("FracBits mismatch")
+	}
+}
+
+// AddFixed returns ct0 + ct1.
+//
+// Panics if ct0 and ct1 have different FracBits.
+func (e *Evaluator[T]) AddFixed(ct0, ct1 FheFixed[T]) FheFixed[T] {
+	ctOut := e.newFheFixed(len(ct0.Value), ct0.FracBits)
+	e.AddFixedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// AddFixedTo computes ctOut = ct0 + ct1.
+// ctOut should have the same FracBits as ct0 and ct1.
+//
+// Panics if ct0 and ct1 have different FracBits.
+func (e *Evaluator[T]) AddFixedTo(ctOut, ct0, ct1 FheFixed[T]) {
+	checkFracBits(ct0, ct1)
+	e.AddTo(ctOut.FheUint, ct0.FheUint, ct1.FheUint)
+}
+
+// SubFixed returns ct0 - ct1.
+//
+// Panics if ct0 and ct1 have different FracBits.
+func (e *Evaluator[T]) SubFixed(ct0, ct1 FheFixed[T]) FheFixed[T] {
+	ctOut := e.newFheFixed(len(ct0.Value), ct0.FracBits)
+	e.SubFixedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// SubFixedTo computes ctOut = ct0 - ct1.
+// ctOut should have the same FracBits as ct0 and ct1.
+//
+// Panics if ct0 and ct1 have different FracBits.
+func (e *Evaluator[T]) SubFixedTo(ctOut, ct0, ct1 FheFixed[T]) {
+	checkFracBits(ct0, ct1)
+	e.SubTo(ctOut.FheUint, ct0.FheUint, ct1.FheUint)
+}
+
+// NegFixed returns -ct.
+func (e *Evaluator[T]) NegFixed(ct FheFixed[T]) FheFixed[T] {
+	ctOut := e.newFheFixed(len(ct.Value), ct.FracBits)
+	e.NegFixedTo(ctOut, ct)
+	return ctOut
+}
+
+// NegFixedTo computes ctOut = -ct.
+// ctOut should have the same FracBits as ct.
+func (e *Evaluator[T]) NegFixedTo(ctOut, ct FheFixed[T]) {
+	e.NegTo(ctOut.FheUint, ct.FheUint)
+}
+
+// MulFixed returns ct0 * ct1, rounded to the nearest.
+//
+// Panics if ct0 and ct1 have different FracBits.
+func (e *Evaluator[T]) MulFixed(ct0, ct1 FheFixed[T]) FheFixed[T] {
+	ctOut := e.newFheFixed(len(ct0.Value), ct0.FracBits)
+	e.MulFixedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// MulFixedTo computes ctOut = ct0 * ct1, rounded to the nearest.
+// ctOut should have the same FracBits as ct0 and ct1.
+//
+// Panics if ct0 and ct1 have different FracBits.
+func (e *Evaluator[T]) MulFixedTo(ctOut, ct0, ct1 FheFixed[T]) {
+	e.mulFixedTo(ctOut, ct0, ct1, true)
+}
+
+// MulFixedTruncate returns ct0 * ct1, truncated towards negative infinity.
+//
+// Panics if ct0 and ct1 have different FracBits.
+func (e *Evaluator[T]) MulFixedTruncate(ct0, ct1 FheFixed[T]) FheFixed[T] {
+	ctOut := e.newFheFixed(len(ct0.Value), ct0.FracBits)
+	e.MulFixedTruncateTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// MulFixedTruncateTo computes ctOut = ct0 * ct1, truncated towards negative infinity.
+// ctOut should have the same FracBits as ct0 and ct1.
+//
+// Panics if ct0 and ct1 have different FracBits.
+func (e *Evaluator[T]) MulFixedTruncateTo(ctOut, ct0, ct1 FheFixed[T]) {
+	e.mulFixedTo(ctOut, ct0, ct1, false)
+}
+
+// mulFixedTo computes ctOut = ct0 * ct1, rounded to the nearest if round is true,
+// and truncated otherwise.
+//
+// The product is computed in bits + FracBits bits, so that no integer bits are lost
+// before rescaling.
+func (e *Evaluator[T]) mulFixedTo(ctOut, ct0, ct1 FheFixed[T], round bool) {
+	checkFracBits(ct0, ct1)
+
+	logMessageModulus := e.Params.logMessageModulus
+	blockCount := len(ct0.Value)
+	wideBlockCount := blockCount + (ct0.FracBits+logMessageModulus-1)/logMessageModulus
+
+	ctWide0 := e.newFheUint(wideBlockCount)
+	ctWide1 := e.newFheUint(wideBlockCount)
+	e.SignExtendTo(ctWide0, ct0.FheUint)
+	e.SignExtendTo(ctWide1, ct1.FheUint)
+
+	e.MulTo(ctWide0, ctWide0, ctWide1)
+	e.rescaleTo(ctWide0, ctWide0, ct0.FracBits, round)
+	e.SignExtendTo(ctOut.FheUint, ctWide0)
+}
+
+// RescaleFixed returns ct with FracBits changed to fracBits, keeping the bit length.
+// If the fractional bits decrease, the result is rounded to the nearest.
+//
+// Panics if fracBits is not in [0, bits).
+func (e *Evaluator[T]) RescaleFixed(ct FheFixed[T], fracBits int) FheFixed[T] {
+	if fracBits < 0 || fracBits >= len(ct.Value)*e.Params.logMessageModulus {
+		panic("fracBits out of range")
+	}
+
+	ctOut := e.newFheFixed(len(ct.Value), fracBits)
+	e.RescaleFixedTo(ctOut, ct)
+	return ctOut
+}
+
+// RescaleFixedTo changes FracBits of ct to FracBits of ctOut and writes it to ctOut.
+// If the fractional bits decrease, the result is rounded to the nearest.
+func (e *Evaluator[T]) RescaleFixedTo(ctOut, ct FheFixed[T]) {
+	switch {
+	case ctOut.FracBits > ct.FracBits:
+		e.ScalarShiftLeftTo(ctOut.FheUint, ct.FheUint, ctOut.FracBits-ct.FracBits)
+	case ctOut.FracBits < ct.FracBits:
+		e.rescaleTo(ctOut.FheUint, ct.FheUint, ct.FracBits-ctOut.FracBits, true)
+	default:
+		ctOut.FheUint.CopyFrom(ct.FheUint)
+	}
+}
+
+// rescaleTo computes ctOut = ct / 2^k as signed integers,
+// rounded to the nearest if round is true, and truncated towards negative infinity otherwise.
+func (e *Evaluator[T]) rescaleTo(ctOut, ct FheUint[T], k int, round bool) {
+	if k == 0 {
+		ctOut.CopyFrom(ct)
+		return
+	}
+
+	if round {
+		e.ScalarAddTo(ctOut, ct, 1<<(k-1))
+		ct = ctOut
+	}
+	e.ScalarShiftRightSignedTo(ctOut, ct, k)
+}
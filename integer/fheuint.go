@@ -0,0 +1,66 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// FheUint is an encrypted unsigned integer, decomposed into radix blocks.
+// Each block is an LWE ciphertext encrypting a digit modulo MessageModulus,
+// with extra room for carries.
+//
+// An integer of b bits has b / LogMessageModulus blocks,
+// so FheUint8, FheUint16, FheUint32 and FheUint64 are obtained
+// by calling [NewFheUint] with bits 8, 16, 32 and 64.
+//
+// FheUint can also hold a signed integer in two's complement.
+// Addition, subtraction and multiplication are the same for both,
+// and the operations that differ have the Signed suffix.
+type FheUint[T tfhe.TorusInt] struct {
+	// Value holds the blocks in little-endian order.
+	// Therefore, value has length BlockCount.
+	Value []tfhe.LWECiphertext[T]
+}
+
+// NewFheUint creates a new [FheUint] of given bits.
+//
+// Panics if bits is not a positive multiple of LogMessageModulus.
+func NewFheUint[T tfhe.TorusInt](params Parameters[T], bits int) FheUint[T] {
+	return NewFheUintCustom[T](params.BlockCount(bits), params.baseParams.DefaultLWEDimension())
+}
+
+// NewFheUintCustom creates a new [FheUint] with given block count and LWE dimension.
+func NewFheUintCustom[T tfhe.TorusInt](blockCount, lweDimension int) FheUint[T] {
+	ct := make([]tfhe.LWECiphertext[T], blockCount)
+	for i := 0; i < blockCount; i++ {
+		ct[i] = tfhe.NewLWECiphertextCustom[T](lweDimension)
+	}
+	return FheUint[T]{Value: ct}
+}
+
+// BlockCount returns the number of blocks in the ciphertext.
+func (ct FheUint[T]) BlockCount() int {
+	return len(ct.Value)
+}
+
+// Copy returns a copy of the ciphertext.
+func (ct FheUint[T]) Copy() FheUint[T] {
+	ctCopy := make([]tfhe.LWECiphertext[T], len(ct.Value))
+	for i := range ct.Value {
+		ctCopy[i] = ct.Value[i].Copy()
+	}
+	return FheUint[T]{Value: ctCopy}
+}
+
+// CopyFrom copies values from the ciphertext.
+func (ct *FheUint[T]) CopyFrom(ctIn FheUint[T]) {
+	for i := range ct.Value {
+		ct.Value[i].CopyFrom(ctIn.Value[i])
+	}
+}
+
+// Clear clears the ciphertext.
+func (ct *FheUint[T]) Clear() {
+	for i := range ct.Value {
+		ct.Value[i].Clear()
+	}
+}
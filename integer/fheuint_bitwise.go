@@ -0,0 +1,269 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// And returns ct0 & ct1.
+func (e *Evaluator[T]) And(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.AndTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// AndTo computes ctOut = ct0 & ct1.
+func (e *Evaluator[T]) AndTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.bitwiseTo(ctOut, ct0, ct1, func(x, y int) int { return x & y })
+}
+
+// Or returns ct0 | ct1.
+func (e *Evaluator[T]) Or(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.OrTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// OrTo computes ctOut = ct0 | ct1.
+func (e *Evaluator[T]) OrTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.bitwiseTo(ctOut, ct0, ct1, func(x, y int) int { return x | y })
+}
+
+// Xor returns ct0 ^ ct1.
+func (e *Evaluator[T]) Xor(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.XorTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// XorTo computes ctOut = ct0 ^ ct1.
+func (e *Evaluator[T]) XorTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.bitwiseTo(ctOut, ct0, ct1, func(x, y int) int { return x ^ y })
+}
+
+// bitwiseTo computes a blockwise function f of ct0 and ct1 and writes it to ctOut.
+func (e *Evaluator[T]) bitwiseTo(ctOut, ct0, ct1 FheUint[T], f func(x, y int) int) {
+	e.GenBivariateLUTTo(e.buf.lut, f)
+	for i := 0; i < len(ctOut.Value); i++ {
+		e.BootstrapBivariateLUTTo(ctOut.Value[i], ct0.Value[i], ct1.Value[i], e.buf.lut)
+	}
+}
+
+// Not returns ^ct.
+// This does not need bootstrapping.
+func (e *Evaluator[T]) Not(ct FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.NotTo(ctOut, ct)
+	return ctOut
+}
+
+// NotTo computes ctOut = ^ct.
+// This does not need bootstrapping.
+func (e *Evaluator[T]) NotTo(ctOut, ct FheUint[T]) {
+	ptMax := e.Evaluator.EncodeLWE(int(e.Params.messageModulus - 1))
+	for i := 0; i < len(ctOut.Value); i++ {
+		e.Evaluator.NegLWETo(ctOut.Value[i], ct.Value[i])
+		ctOut.Value[i].Value[0] += ptMax.Value
+	}
+}
+
+// ScalarShiftLeft returns ct << k.
+//
+// Panics if k < 0.
+func (e *Evaluator[T]) ScalarShiftLeft(ct FheUint[T], k int) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ScalarShiftLeftTo(ctOut, ct, k)
+	return ctOut
+}
+
+// ScalarShiftLeftTo computes ctOut = ct << k.
+//
+// Panics if k < 0.
+func (e *Evaluator[T]) ScalarShiftLeftTo(ctOut, ct FheUint[T], k int) {
+	if k < 0 {
+		panic("negative shift amount")
+	}
+	e.shiftTo(ctOut, ct, -num.Min(k, len(ct.Value)*e.Params.logMessageModulus), false, e.zeroBlock())
+}
+
+// ScalarShiftRight returns ct >> k.
+// This is a logical shift, so zeros are shifted in.
+//
+// Panics if k < 0.
+func (e *Evaluator[T]) ScalarShiftRight(ct FheUint[T], k int) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ScalarShiftRightTo(ctOut, ct, k)
+	return ctOut
+}
+
+// ScalarShiftRightTo computes ctOut = ct >> k.
+// This is a logical shift, so zeros are shifted in.
+//
+// Panics if k < 0.
+func (e *Evaluator[T]) ScalarShiftRightTo(ctOut, ct FheUint[T], k int) {
+	if k < 0 {
+		panic("negative shift amount")
+	}
+	e.shiftTo(ctOut, ct, num.Min(k, len(ct.Value)*e.Params.logMessageModulus), false, e.zeroBlock())
+}
+
+// ScalarRotateLeft returns ct rotated left by k bits.
+// k can be negative, in which case ct is rotated right.
+func (e *Evaluator[T]) ScalarRotateLeft(ct FheUint[T], k int) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ScalarRotateLeftTo(ctOut, ct, k)
+	return ctOut
+}
+
+// ScalarRotateLeftTo computes ctOut = ct rotated left by k bits.
+// k can be negative, in which case ct is rotated right.
+func (e *Evaluator[T]) ScalarRotateLeftTo(ctOut, ct FheUint[T], k int) {
+	e.shiftTo(ctOut, ct, -k, true, tfhe.LWECiphertext[T]{})
+}
+
+// ScalarRotateRight returns ct rotated right by k bits.
+// k can be negative, in which case ct is rotated left.
+func (e *Evaluator[T]) ScalarRotateRight(ct FheUint[T], k int) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ScalarRotateRightTo(ctOut, ct, k)
+	return ctOut
+}
+
+// ScalarRotateRightTo computes ctOut = ct rotated right by k bits.
+// k can be negative, in which case ct is rotated left.
+func (e *Evaluator[T]) ScalarRotateRightTo(ctOut, ct FheUint[T], k int) {
+	e.shiftTo(ctOut, ct, k, true, tfhe.LWECiphertext[T]{})
+}
+
+// zeroBlock returns a trivial encryption of zero.
+func (e *Evaluator[T]) zeroBlock() tfhe.LWECiphertext[T] {
+	return tfhe.NewLWECiphertext(e.Params.baseParams)
+}
+
+// shiftTo computes ctOut = ct >> k and writes it to ctOut.
+// If k is negative, ct is shifted left by -k.
+//
+// If rotate is true, ct is rotated instead.
+// Otherwise, the shifted-in bits are taken from ctFill,
+// which should be a block with all bits equal.
+// In this case, |k| should be at most the bit length of ct.
+func (e *Evaluator[T]) shiftTo(ctOut, ct FheUint[T], k int, rotate bool, ctFill tfhe.LWECiphertext[T]) {
+	blockCount := len(ct.Value)
+	logMessageModulus := e.Params.logMessageModulus
+	messageModulus := int(e.Params.messageModulus)
+
+	if rotate {
+		bits := blockCount * logMessageModulus
+		k = ((k % bits) + bits) % bits
+	}
+
+	// Output block i consists of input blocks i + blockShift and i + blockShift + 1.
+	// We offset k to be non-negative, so that division floors.
+	blockShift := (k+blockCount*logMessageModulus)/logMessageModulus - blockCount
+	bitShift := k - blockShift*logMessageModulus
+
+	block := func(j int) (tfhe.LWECiphertext[T], bool) {
+		switch {
+		case rotate:
+			return ct.Value[((j%blockCount)+blockCount)%blockCount], true
+		case j < 0 || j >= blockCount:
+			return ctFill, false
+		}
+		return ct.Value[j], true
+	}
+
+	e.GenBivariateLUTTo(e.buf.lut, func(hi, lo int) int {
+		return (hi<<(logMessageModulus-bitShift) + lo>>bitShift) % messageModulus
+	})
+
+	ctShift := e.newFheUint(blockCount)
+	for i := 0; i < blockCount; i++ {
+		ctLo, okLo := block(i + blockShift)
+		ctHi, okHi := block(i + blockShift + 1)
+		switch {
+		case bitShift == 0 || (!okLo && !okHi):
+			ctShift.Value[i].CopyFrom(ctLo)
+		default:
+			e.BootstrapBivariateLUTTo(ctShift.Value[i], ctHi, ctLo, e.buf.lut)
+		}
+	}
+	ctOut.CopyFrom(ctShift)
+}
+
+// ShiftLeft returns ct << ctAmount.
+// If bits is a power of two, the amount is taken modulo bits.
+func (e *Evaluator[T]) ShiftLeft(ct, ctAmount FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ShiftLeftTo(ctOut, ct, ctAmount)
+	return ctOut
+}
+
+// ShiftLeftTo computes ctOut = ct << ctAmount.
+// If bits is a power of two, the amount is taken modulo bits.
+func (e *Evaluator[T]) ShiftLeftTo(ctOut, ct, ctAmount FheUint[T]) {
+	e.barrelShiftTo(ctOut, ct, ctAmount, func(ctOut, ct FheUint[T], k int) { e.ScalarShiftLeftTo(ctOut, ct, k) })
+}
+
+// ShiftRight returns ct >> ctAmount.
+// If bits is a power of two, the amount is taken modulo bits.
+// This is a logical shift, so zeros are shifted in.
+func (e *Evaluator[T]) ShiftRight(ct, ctAmount FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ShiftRightTo(ctOut, ct, ctAmount)
+	return ctOut
+}
+
+// ShiftRightTo computes ctOut = ct >> ctAmount.
+// If bits is a power of two, the amount is taken modulo bits.
+// This is a logical shift, so zeros are shifted in.
+func (e *Evaluator[T]) ShiftRightTo(ctOut, ct, ctAmount FheUint[T]) {
+	e.barrelShiftTo(ctOut, ct, ctAmount, func(ctOut, ct FheUint[T], k int) { e.ScalarShiftRightTo(ctOut, ct, k) })
+}
+
+// RotateLeft returns ct rotated left by ctAmount bits.
+func (e *Evaluator[T]) RotateLeft(ct, ctAmount FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.RotateLeftTo(ctOut, ct, ctAmount)
+	return ctOut
+}
+
+// RotateLeftTo computes ctOut = ct rotated left by ctAmount bits.
+func (e *Evaluator[T]) RotateLeftTo(ctOut, ct, ctAmount FheUint[T]) {
+	e.barrelShiftTo(ctOut, ct, ctAmount, func(ctOut, ct FheUint[T], k int) { e.ScalarRotateLeftTo(ctOut, ct, k) })
+}
+
+// RotateRight returns ct rotated right by ctAmount bits.
+func (e *Evaluator[T]) RotateRight(ct, ctAmount FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.RotateRightTo(ctOut, ct, ctAmount)
+	return ctOut
+}
+
+// RotateRightTo computes ctOut = ct rotated right by ctAmount bits.
+func (e *Evaluator[T]) RotateRightTo(ctOut, ct, ctAmount FheUint[T]) {
+	e.barrelShiftTo(ctOut, ct, ctAmount, func(ctOut, ct FheUint[T], k int) { e.ScalarRotateRightTo(ctOut, ct, k) })
+}
+
+// barrelShiftTo shifts ct by ctAmount using a barrel shifter,
+// where shift shifts by a clear amount.
+//
+// For each bit b_t of ctAmount, ct is shifted by 2^t and selected by b_t.
+// Only the bits of ctAmount smaller than bits are used,
+// so the amount is taken modulo bits if bits is a power of two.
+func (e *Evaluator[T]) barrelShiftTo(ctOut, ct, ctAmount FheUint[T], shift func(ctOut, ct FheUint[T], k int)) {
+	logMessageModulus := e.Params.logMessageModulus
+	bits := len(ct.Value) * logMessageModulus
+
+	ctAcc := ct.Copy()
+	ctShift := e.newFheUint(len(ct.Value))
+	ctBit := tfhe.NewLWECiphertext(e.Params.baseParams)
+	for t := 0; 1<<t < bits && t < len(ctAmount.Value)*logMessageModulus; t++ {
+		shiftBit := t % logMessageModulus
+		e.Evaluator.GenLUTTo(e.buf.lut, func(x int) int { return (x >> shiftBit) & 1 })
+		e.Evaluator.BootstrapLUTTo(ctBit, ctAmount.Value[t/logMessageModulus], e.buf.lut)
+
+		shift(ctShift, ctAcc, 1<<t)
+		e.SelectTo(ctAcc, ctBit, ctShift, ctAcc)
+	}
+	ctOut.CopyFrom(ctAcc)
+}
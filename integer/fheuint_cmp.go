@@ -0,0 +1,282 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// boolToInt converts a boolean to 0 or 1.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Sign of two blocks, encoded as a trit.
+const (
+	signLess    = 0
+	signEqual   = 1
+	signGreater = 2
+)
+
+// Eq returns an encrypted boolean of ct0 == ct1.
+func (e *Evaluator[T]) Eq(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.EqTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// EqTo computes ctOut = (ct0 == ct1) as an encrypted boolean.
+func (e *Evaluator[T]) EqTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.eqTo(ctOut, ct0, ct1, func(eq bool) bool { return eq })
+}
+
+// Ne returns an encrypted boolean of ct0 != ct1.
+func (e *Evaluator[T]) Ne(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.NeTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// NeTo computes ctOut = (ct0 != ct1) as an encrypted boolean.
+func (e *Evaluator[T]) NeTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.eqTo(ctOut, ct0, ct1, func(eq bool) bool { return !eq })
+}
+
+// Lt returns an encrypted boolean of ct0 < ct1.
+func (e *Evaluator[T]) Lt(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.LtTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// LtTo computes ctOut = (ct0 < ct1) as an encrypted boolean.
+func (e *Evaluator[T]) LtTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.signTo(ctOut, ct0, ct1, false, func(sign int) bool { return sign == signLess })
+}
+
+// Le returns an encrypted boolean of ct0 <= ct1.
+func (e *Evaluator[T]) Le(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.LeTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// LeTo computes ctOut = (ct0 <= ct1) as an encrypted boolean.
+func (e *Evaluator[T]) LeTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.signTo(ctOut, ct0, ct1, false, func(sign int) bool { return sign != signGreater })
+}
+
+// Gt returns an encrypted boolean of ct0 > ct1.
+func (e *Evaluator[T]) Gt(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.GtTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// GtTo computes ctOut = (ct0 > ct1) as an encrypted boolean.
+func (e *Evaluator[T]) GtTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.signTo(ctOut, ct0, ct1, false, func(sign int) bool { return sign == signGreater })
+}
+
+// Ge returns an encrypted boolean of ct0 >= ct1.
+func (e *Evaluator[T]) Ge(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.GeTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// GeTo computes ctOut = (ct0 >= ct1) as an encrypted boolean.
+func (e *Evaluator[T]) GeTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.signTo(ctOut, ct0, ct1, false, func(sign int) bool { return sign != signLess })
+}
+
+// Min returns min(ct0, ct1).
+func (e *Evaluator[T]) Min(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.MinTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// MinTo computes ctOut = min(ct0, ct1).
+func (e *Evaluator[T]) MinTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.LtTo(e.buf.ctCond, ct0, ct1)
+	e.SelectTo(ctOut, e.buf.ctCond, ct0, ct1)
+}
+
+// Max returns max(ct0, ct1).
+func (e *Evaluator[T]) Max(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.MaxTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// MaxTo computes ctOut = max(ct0, ct1).
+func (e *Evaluator[T]) MaxTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.GtTo(e.buf.ctCond, ct0, ct1)
+	e.SelectTo(ctOut, e.buf.ctCond, ct0, ct1)
+}
+
+// Select returns ctCond ? ct0 : ct1, where ctCond is an encrypted boolean.
+// ct0 and ct1 should have the same number of blocks.
+func (e *Evaluator[T]) Select(ctCond tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.SelectTo(ctOut, ctCond, ct0, ct1)
+	return ctOut
+}
+
+// SelectTo computes ctOut = ctCond ? ct0 : ct1, where ctCond is an encrypted boolean.
+// ct0 and ct1 should have the same number of blocks as ctOut.
+//
+// Each block is selected with two bivariate bootstraps.
+func (e *Evaluator[T]) SelectTo(ctOut FheUint[T], ctCond tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	for i := 0; i < len(ctOut.Value); i++ {
+		// Exactly one of the two terms is nonzero, so no carry is produced.
+		e.BootstrapBivariateLUTTo(e.buf.ctBlock, ctCond, ct1.Value[i], e.lutSelectFalse)
+		e.BootstrapBivariateLUTTo(ctOut.Value[i], ctCond, ct0.Value[i], e.lutSelectTrue)
+		e.Evaluator.AddLWETo(ctOut.Value[i], ctOut.Value[i], e.buf.ctBlock)
+	}
+}
+
+// SelectBool returns ctCond ? ct0 : ct1, where ctCond, ct0 and ct1 are encrypted booleans.
+func (e *Evaluator[T]) SelectBool(ctCond, ct0, ct1 tfhe.LWECiphertext[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.SelectBoolTo(ctOut, ctCond, ct0, ct1)
+	return ctOut
+}
+
+// SelectBoolTo computes ctOut = ctCond ? ct0 : ct1, where ctCond, ct0 and ct1 are encrypted booleans.
+//
+// The three booleans are packed into one block 4 * ctCond + 2 * ct0 + ct1,
+// which fits into the carry space, so this takes only one bootstrap.
+func (e *Evaluator[T]) SelectBoolTo(ctOut, ctCond, ct0, ct1 tfhe.LWECiphertext[T]) {
+	e.Evaluator.ScalarMulLWETo(e.buf.ctPack, ctCond, 4)
+	e.Evaluator.ScalarMulAddLWETo(e.buf.ctPack, ct0, 2)
+	e.Evaluator.AddLWETo(e.buf.ctPack, e.buf.ctPack, ct1)
+	e.Evaluator.BootstrapLUTTo(ctOut, e.buf.ctPack, e.lutSelectBool)
+}
+
+// eqTo computes f(ct0 == ct1) as an encrypted boolean and writes it to ctOut.
+//
+// Each block pair is compared with a bivariate bootstrap,
+// and then the results are summed up in chunks and compared with the chunk size.
+func (e *Evaluator[T]) eqTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T], f func(eq bool) bool) {
+	ctEq := e.newFheUint(len(ct0.Value)).Value
+
+	e.GenBivariateLUTTo(e.buf.lut, func(x, y int) int { return boolToInt(x == y) })
+	for i := range ctEq {
+		e.BootstrapBivariateLUTTo(ctEq[i], ct0.Value[i], ct1.Value[i], e.buf.lut)
+	}
+
+	// A chunk sums up to chunkSize booleans, which fits in BaseParams.MessageModulus.
+	chunkSize := int(e.Params.baseParams.MessageModulus() - 1)
+	for {
+		chunkCount := (len(ctEq) + chunkSize - 1) / chunkSize
+		if chunkCount == 1 {
+			e.Evaluator.GenLUTTo(e.buf.lut, func(x int) int { return boolToInt(f(x == chunkSize)) })
+		} else {
+			e.Evaluator.GenLUTTo(e.buf.lut, func(x int) int { return boolToInt(x == chunkSize) })
+		}
+
+		for i := 0; i < chunkCount; i++ {
+			start, end := i*chunkSize, num.Min((i+1)*chunkSize, len(ctEq))
+
+			// Missing booleans in the last chunk are filled with true.
+			e.buf.ctBlock.CopyFrom(ctEq[start])
+			e.buf.ctBlock.Value[0] += e.Evaluator.EncodeLWE(chunkSize - (end - start)).Value
+			for j := start + 1; j < end; j++ {
+				e.Evaluator.AddLWETo(e.buf.ctBlock, e.buf.ctBlock, ctEq[j])
+			}
+
+			if chunkCount == 1 {
+				e.Evaluator.BootstrapLUTTo(ctOut, e.buf.ctBlock, e.buf.lut)
+				return
+			}
+			e.Evaluator.BootstrapLUTTo(ctEq[i], e.buf.ctBlock, e.buf.lut)
+		}
+		ctEq = ctEq[:chunkCount]
+	}
+}
+
+// signTo computes f(sign(ct0 - ct1)) as an encrypted boolean and writes it to ctOut.
+// If signed is true, ct0 and ct1 are compared as signed integers.
+//
+// Each block pair is compared with a bivariate bootstrap to a trit,
+// and then the trits are packed in chunks and merged,
+// where the more significant trit has priority unless it is signEqual.
+func (e *Evaluator[T]) signTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T], signed bool, f func(sign int) bool) {
+	ctSign := e.newFheUint(len(ct0.Value)).Value
+
+	cmp := func(x, y int) int {
+		switch {
+		case x < y:
+			return signLess
+		case x > y:
+			return signGreater
+		}
+		return signEqual
+	}
+	e.GenBivariateLUTTo(e.buf.lut, cmp)
+	for i := 0; i < len(ctSign)-1; i++ {
+		e.BootstrapBivariateLUTTo(ctSign[i], ct0.Value[i], ct1.Value[i], e.buf.lut)
+	}
+
+	// If signed, the most significant block is compared with its top bit flipped,
+	// which maps two's complement order to unsigned order.
+	if signed {
+		half := int(e.Params.messageModulus / 2)
+		e.GenBivariateLUTTo(e.buf.lut, func(x, y int) int { return cmp(x^half, y^half) })
+	}
+	e.BootstrapBivariateLUTTo(ctSign[len(ctSign)-1], ct0.Value[len(ctSign)-1], ct1.Value[len(ctSign)-1], e.buf.lut)
+
+	// A chunk packs chunkSize trits, which should fit in BaseParams.MessageModulus.
+	// To keep the noise growth comparable to bivariate bootstrapping,
+	// the largest scaling factor is bounded by MessageModulus.
+	chunkSize, pow := 1, 3
+	for pow*3 <= int(e.Params.baseParams.MessageModulus()) && pow <= int(e.Params.messageModulus) {
+		chunkSize++
+		pow *= 3
+	}
+
+	merge := func(x int) int {
+		sign := signEqual
+		for j := 0; j < chunkSize; j++ {
+			if x%3 != signEqual {
+				sign = x % 3
+			}
+			x /= 3
+		}
+		return sign
+	}
+
+	for {
+		chunkCount := (len(ctSign) + chunkSize - 1) / chunkSize
+		if chunkCount == 1 {
+			e.Evaluator.GenLUTTo(e.buf.lut, func(x int) int { return boolToInt(f(merge(x))) })
+		} else {
+			e.Evaluator.GenLUTTo(e.buf.lut, merge)
+		}
+
+		for i := 0; i < chunkCount; i++ {
+			start := i * chunkSize
+
+			// Missing trits in the last chunk are filled with signEqual.
+			e.buf.ctBlock.Clear()
+			for j, pow := 0, 1; j < chunkSize; j, pow = j+1, pow*3 {
+				if start+j < len(ctSign) {
+					e.Evaluator.ScalarMulAddLWETo(e.buf.ctBlock, ctSign[start+j], T(pow))
+				} else {
+					e.buf.ctBlock.Value[0] += e.Evaluator.EncodeLWE(signEqual * pow).Value
+				}
+			}
+
+			if chunkCount == 1 {
+				e.Evaluator.BootstrapLUTTo(ctOut, e.buf.ctBlock, e.buf.lut)
+				return
+			}
+			e.Evaluator.BootstrapLUTTo(ctSign[i], e.buf.ctBlock, e.buf.lut)
+		}
+		ctSign = ctSign[:chunkCount]
+	}
+}
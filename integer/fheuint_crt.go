@@ -0,0 +1,59 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// FheUintCRT is an encrypted unsigned integer modulo CRTParameters.Modulus,
+// represented by its residues modulo pairwise coprime moduli.
+// Each residue is an LWE ciphertext, encoded with its own message modulus and scale.
+//
+// Unlike [FheUint], there are no carries between residues,
+// so every operation is done residue-wise.
+type FheUintCRT[T tfhe.TorusInt] struct {
+	// Value holds the residues in the order of CRTParameters.Moduli.
+	// Therefore, value has length ResidueCount.
+	Value []tfhe.LWECiphertext[T]
+}
+
+// NewFheUintCRT creates a new [FheUintCRT].
+func NewFheUintCRT[T tfhe.TorusInt](params CRTParameters[T]) FheUintCRT[T] {
+	return NewFheUintCRTCustom[T](params.ResidueCount(), params.baseParams.DefaultLWEDimension())
+}
+
+// NewFheUintCRTCustom creates a new [FheUintCRT] with given residue count and LWE dimension.
+func NewFheUintCRTCustom[T tfhe.TorusInt](residueCount, lweDimension int) FheUintCRT[T] {
+	ct := make([]tfhe.LWECiphertext[T], residueCount)
+	for i := 0; i < residueCount; i++ {
+		ct[i] = tfhe.NewLWECiphertextCustom[T](lweDimension)
+	}
+	return FheUintCRT[T]{Value: ct}
+}
+
+// ResidueCount returns the number of residues in the ciphertext.
+func (ct FheUintCRT[T]) ResidueCount() int {
+	return len(ct.Value)
+}
+
+// Copy returns a copy of the ciphertext.
+func (ct FheUintCRT[T]) Copy() FheUintCRT[T] {
+	ctCopy := make([]tfhe.LWECiphertext[T], len(ct.Value))
+	for i := range ct.Value {
+		ctCopy[i] = ct.Value[i].Copy()
+	}
+	return FheUintCRT[T]{Value: ctCopy}
+}
+
+// CopyFrom copies values from the ciphertext.
+func (ct *FheUintCRT[T]) CopyFrom(ctIn FheUintCRT[T]) {
+	for i := range ct.Value {
+		ct.Value[i].CopyFrom(ctIn.Value[i])
+	}
+}
+
+// Clear clears the ciphertext.
+func (ct *FheUintCRT[T]) Clear() {
+	for i := range ct.Value {
+		ct.Value[i].Clear()
+	}
+}
@@ -0,0 +1,146 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// Mul returns ct0 * ct1 modulo 2^bits.
+func (e *Evaluator[T]) Mul(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.MulTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// MulTo computes ctOut = ct0 * ct1 modulo 2^bits.
+//
+// This uses schoolbook multiplication over blocks.
+// The low and high digits of each block product are computed by bivariate bootstrapping,
+// in one bootstrap if many-LUT bootstrapping is used.
+func (e *Evaluator[T]) MulTo(ctOut, ct0, ct1 FheUint[T]) {
+	blockCount := len(ctOut.Value)
+	ctAcc := e.newFheUint(blockCount)
+
+	// Each row adds at most 2 * (MessageModulus - 1) to a block,
+	// so we can accumulate rowsPerPropagate rows before propagating carries.
+	rowsPerPropagate := num.Max(1, (int(e.Params.baseParams.MessageModulus()-1)/int(e.Params.messageModulus)-1)/2)
+
+	for i := 0; i < blockCount; i++ {
+		for j := 0; i+j < blockCount; j++ {
+			e.packTo(e.buf.ctPack, ct0.Value[j], ct1.Value[i])
+			if i+j == blockCount-1 {
+				e.Evaluator.BootstrapLUTTo(e.buf.ctLow, e.buf.ctPack, e.lutMulLow)
+				e.Evaluator.AddLWETo(ctAcc.Value[i+j], ctAcc.Value[i+j], e.buf.ctLow)
+			} else {
+				e.bootstrapLUTPairTo(e.buf.ctLow, e.buf.ctHigh, e.buf.ctPack, e.lutMulLowHigh)
+				e.Evaluator.AddLWETo(ctAcc.Value[i+j], ctAcc.Value[i+j], e.buf.ctLow)
+				e.Evaluator.AddLWETo(ctAcc.Value[i+j+1], ctAcc.Value[i+j+1], e.buf.ctHigh)
+			}
+		}
+
+		if (i+1)%rowsPerPropagate == 0 || i == blockCount-1 {
+			e.PropagateCarryTo(ctAcc, ctAcc)
+		}
+	}
+
+	ctOut.CopyFrom(ctAcc)
+}
+
+// Div returns ct0 / ct1.
+//
+// If ct1 is zero, the quotient is 2^bits - 1.
+func (e *Evaluator[T]) Div(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.DivTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// DivTo computes ctOut = ct0 / ct1.
+//
+// If ct1 is zero, the quotient is 2^bits - 1.
+func (e *Evaluator[T]) DivTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.DivRemTo(ctOut, e.newFheUint(len(ct0.Value)), ct0, ct1)
+}
+
+// Rem returns ct0 % ct1.
+//
+// If ct1 is zero, the remainder is ct0.
+func (e *Evaluator[T]) Rem(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.RemTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// RemTo computes ctOut = ct0 % ct1.
+//
+// If ct1 is zero, the remainder is ct0.
+func (e *Evaluator[T]) RemTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.DivRemTo(e.newFheUint(len(ct0.Value)), ctOut, ct0, ct1)
+}
+
+// DivRem returns ct0 / ct1 and ct0 % ct1.
+//
+// If ct1 is zero, the quotient is 2^bits - 1 and the remainder is ct0.
+func (e *Evaluator[T]) DivRem(ct0, ct1 FheUint[T]) (FheUint[T], FheUint[T]) {
+	ctQuo := e.newFheUint(len(ct0.Value))
+	ctRem := e.newFheUint(len(ct0.Value))
+	e.DivRemTo(ctQuo, ctRem, ct0, ct1)
+	return ctQuo, ctRem
+}
+
+// DivRemTo computes ctQuo = ct0 / ct1 and ctRem = ct0 % ct1.
+//
+// If ct1 is zero, the quotient is 2^bits - 1 and the remainder is ct0.
+//
+// This uses restoring long division, one bit of the quotient at a time.
+// The comparison of the remainder and the divisor is given for free
+// as the carry of their subtraction.
+func (e *Evaluator[T]) DivRemTo(ctQuo, ctRem, ct0, ct1 FheUint[T]) {
+	blockCount := len(ct0.Value)
+	logMessageModulus := e.Params.logMessageModulus
+	messageModulus := int(e.Params.messageModulus)
+
+	// The remainder is shifted before subtraction, so it needs one more block.
+	ctQ := e.newFheUint(blockCount)
+	ctR := e.newFheUint(blockCount + 1)
+	ctS := e.newFheUint(blockCount + 1)
+	ctD := e.newFheUint(blockCount + 1)
+	for i := 0; i < blockCount; i++ {
+		ctD.Value[i].CopyFrom(ct1.Value[i])
+	}
+
+	ctBit := tfhe.NewLWECiphertext(e.Params.baseParams)
+	ctGe := tfhe.NewLWECiphertext(e.Params.baseParams)
+
+	// lutShift shifts a block by one bit, filling in the top bit of the previous block.
+	lutShift := e.GenBivariateLUT(func(x, y int) int {
+		return (2*x + y>>(logMessageModulus-1)) % messageModulus
+	})
+	lutBit := tfhe.NewLUT(e.Params.baseParams)
+
+	for i := blockCount*logMessageModulus - 1; i >= 0; i-- {
+		block, shift := i/logMessageModulus, i%logMessageModulus
+
+		// Extract the i-th bit of ct0, placed at the top bit of a block.
+		e.Evaluator.GenLUTTo(lutBit, func(x int) int { return ((x >> shift) & 1) << (logMessageModulus - 1) })
+		e.Evaluator.BootstrapLUTTo(ctBit, ct0.Value[block], lutBit)
+
+		// R = 2R + bit
+		for j := blockCount; j > 0; j-- {
+			e.BootstrapBivariateLUTTo(ctR.Value[j], ctR.Value[j], ctR.Value[j-1], lutShift)
+		}
+		e.BootstrapBivariateLUTTo(ctR.Value[0], ctR.Value[0], ctBit, lutShift)
+
+		// S = R - D, and the carry is 1 if and only if R >= D.
+		e.subWithCarryTo(ctS, ctR, ctD)
+		ctGe.CopyFrom(e.buf.ctCarry)
+
+		e.SelectTo(ctR, ctGe, ctS, ctR)
+		e.Evaluator.ScalarMulAddLWETo(ctQ.Value[block], ctGe, 1<<shift)
+	}
+
+	ctQuo.CopyFrom(ctQ)
+	for i := 0; i < blockCount; i++ {
+		ctRem.Value[i].CopyFrom(ctR.Value[i])
+	}
+}
@@ -0,0 +1,182 @@
+package integer
+
+// PropagateCarry returns a copy of ct with its carries propagated.
+//
+// Each block of ct, added with the carry of the previous block,
+// should fit in BaseParams.MessageModulus.
+// The carry of the last block is discarded.
+func (e *Evaluator[T]) PropagateCarry(ct FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.PropagateCarryTo(ctOut, ct)
+	return ctOut
+}
+
+// PropagateCarryTo propagates the carries of ct and writes it to ctOut.
+//
+// Each block of ct, added with the carry of the previous block,
+// should fit in BaseParams.MessageModulus.
+// The carry of the last block is discarded.
+func (e *Evaluator[T]) PropagateCarryTo(ctOut, ct FheUint[T]) {
+	e.propagateCarryTo(ctOut, ct, false)
+}
+
+// propagateCarryTo propagates the carries of ct and writes it to ctOut.
+// If carryOut is true, the carry of the last block is written to e.buf.ctCarry.
+func (e *Evaluator[T]) propagateCarryTo(ctOut, ct FheUint[T], carryOut bool) {
+	for i := 0; i < len(ct.Value); i++ {
+		if i == 0 {
+			e.buf.ctBlock.CopyFrom(ct.Value[i])
+		} else {
+			e.Evaluator.AddLWETo(e.buf.ctBlock, ct.Value[i], e.buf.ctCarry)
+		}
+
+		if i < len(ct.Value)-1 || carryOut {
+			e.bootstrapLUTPairTo(ctOut.Value[i], e.buf.ctCarry, e.buf.ctBlock, e.lutMessageCarry)
+		} else {
+			e.Evaluator.BootstrapLUTTo(ctOut.Value[i], e.buf.ctBlock, e.lutMessage)
+		}
+	}
+}
+
+// Add returns ct0 + ct1 modulo 2^bits.
+func (e *Evaluator[T]) Add(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.AddTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// AddTo computes ctOut = ct0 + ct1 modulo 2^bits.
+func (e *Evaluator[T]) AddTo(ctOut, ct0, ct1 FheUint[T]) {
+	for i := 0; i < len(ctOut.Value); i++ {
+		e.Evaluator.AddLWETo(ctOut.Value[i], ct0.Value[i], ct1.Value[i])
+	}
+	e.PropagateCarryTo(ctOut, ctOut)
+}
+
+// Sub returns ct0 - ct1 modulo 2^bits.
+func (e *Evaluator[T]) Sub(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.SubTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// SubTo computes ctOut = ct0 - ct1 modulo 2^bits.
+func (e *Evaluator[T]) SubTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.subTo(ctOut, ct0, ct1, false)
+}
+
+// subWithCarryTo computes ctOut = ct0 - ct1 modulo 2^bits,
+// and writes the carry of the last block to e.buf.ctCarry.
+// The carry is 1 if and only if ct0 >= ct1.
+func (e *Evaluator[T]) subWithCarryTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.subTo(ctOut, ct0, ct1, true)
+}
+
+// subTo computes ctOut = ct0 - ct1 modulo 2^bits.
+// If carryOut is true, the carry of the last block is written to e.buf.ctCarry.
+func (e *Evaluator[T]) subTo(ctOut, ct0, ct1 FheUint[T], carryOut bool) {
+	// ct0 - ct1 = ct0 + NOT(ct1) + 1
+	ptMax := e.Evaluator.EncodeLWE(int(e.Params.messageModulus - 1))
+	for i := 0; i < len(ctOut.Value); i++ {
+		e.Evaluator.SubLWETo(ctOut.Value[i], ct0.Value[i], ct1.Value[i])
+		ctOut.Value[i].Value[0] += ptMax.Value
+	}
+	ctOut.Value[0].Value[0] += e.Evaluator.EncodeLWE(1).Value
+	e.propagateCarryTo(ctOut, ctOut, carryOut)
+}
+
+// Neg returns -ct modulo 2^bits.
+func (e *Evaluator[T]) Neg(ct FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.NegTo(ctOut, ct)
+	return ctOut
+}
+
+// NegTo computes ctOut = -ct modulo 2^bits.
+func (e *Evaluator[T]) NegTo(ctOut, ct FheUint[T]) {
+	// -ct = NOT(ct) + 1
+	ptMax := e.Evaluator.EncodeLWE(int(e.Params.messageModulus - 1))
+	for i := 0; i < len(ctOut.Value); i++ {
+		e.Evaluator.NegLWETo(ctOut.Value[i], ct.Value[i])
+		ctOut.Value[i].Value[0] += ptMax.Value
+	}
+	ctOut.Value[0].Value[0] += e.Evaluator.EncodeLWE(1).Value
+	e.PropagateCarryTo(ctOut, ctOut)
+}
+
+// ScalarAdd returns ct + c modulo 2^bits.
+func (e *Evaluator[T]) ScalarAdd(ct FheUint[T], c uint64) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ScalarAddTo(ctOut, ct, c)
+	return ctOut
+}
+
+// ScalarAddTo computes ctOut = ct + c modulo 2^bits.
+func (e *Evaluator[T]) ScalarAddTo(ctOut, ct FheUint[T], c uint64) {
+	for i := 0; i < len(ctOut.Value); i++ {
+		e.Evaluator.AddPlainLWETo(ctOut.Value[i], ct.Value[i], e.Evaluator.EncodeLWE(e.digit(c, i)))
+	}
+	e.PropagateCarryTo(ctOut, ctOut)
+}
+
+// ScalarSub returns ct - c modulo 2^bits.
+func (e *Evaluator[T]) ScalarSub(ct FheUint[T], c uint64) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ScalarSubTo(ctOut, ct, c)
+	return ctOut
+}
+
+// ScalarSubTo computes ctOut = ct - c modulo 2^bits.
+func (e *Evaluator[T]) ScalarSubTo(ctOut, ct FheUint[T], c uint64) {
+	e.ScalarAddTo(ctOut, ct, -c)
+}
+
+// ScalarMul returns ct * c modulo 2^bits.
+func (e *Evaluator[T]) ScalarMul(ct FheUint[T], c uint64) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ScalarMulTo(ctOut, ct, c)
+	return ctOut
+}
+
+// ScalarMulTo computes ctOut = ct * c modulo 2^bits.
+func (e *Evaluator[T]) ScalarMulTo(ctOut, ct FheUint[T], c uint64) {
+	// Horner's method over the radix digits of c:
+	// acc = acc * MessageModulus + c_j * ct, from the most significant digit.
+	ctAcc := e.newFheUint(len(ct.Value))
+	started := false
+	for j := len(ct.Value) - 1; j >= 0; j-- {
+		if started {
+			e.shiftBlocksLeftInPlace(ctAcc, 1)
+		}
+
+		d := e.digit(c, j)
+		if d == 0 {
+			continue
+		}
+
+		for i := 0; i < len(ct.Value)-j; i++ {
+			e.Evaluator.ScalarMulAddLWETo(ctAcc.Value[i], ct.Value[i], T(d))
+		}
+		e.PropagateCarryTo(ctAcc, ctAcc)
+		started = true
+	}
+
+	ctOut.CopyFrom(ctAcc)
+}
+
+// shiftBlocksLeftInPlace shifts the blocks of ct by k blocks towards the most significant block,
+// filling the least significant blocks with trivial zeros.
+// This is a multiplication by MessageModulus^k, and does not need bootstrapping.
+func (e *Evaluator[T]) shiftBlocksLeftInPlace(ct FheUint[T], k int) {
+	if k >= len(ct.Value) {
+		ct.Clear()
+		return
+	}
+
+	for i := len(ct.Value) - 1; i >= k; i-- {
+		ct.Value[i], ct.Value[i-k] = ct.Value[i-k], ct.Value[i]
+	}
+	for i := 0; i < k; i++ {
+		ct.Value[i].Clear()
+	}
+}
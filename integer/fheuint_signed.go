@@ -0,0 +1,254 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// SignBit returns an encrypted boolean of ct < 0,
+// where ct is interpreted as a signed integer.
+func (e *Evaluator[T]) SignBit(ct FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.SignBitTo(ctOut, ct)
+	return ctOut
+}
+
+// SignBitTo computes ctOut = (ct < 0) as an encrypted boolean,
+// where ct is interpreted as a signed integer.
+func (e *Evaluator[T]) SignBitTo(ctOut tfhe.LWECiphertext[T], ct FheUint[T]) {
+	half := int(e.Params.messageModulus / 2)
+	e.Evaluator.GenLUTTo(e.buf.lut, func(x int) int { return boolToInt(x >= half) })
+	e.Evaluator.BootstrapLUTTo(ctOut, ct.Value[len(ct.Value)-1], e.buf.lut)
+}
+
+// signFillTo writes a block filled with the sign bit of ct to ctOut.
+func (e *Evaluator[T]) signFillTo(ctOut tfhe.LWECiphertext[T], ct FheUint[T]) {
+	half := int(e.Params.messageModulus / 2)
+	e.Evaluator.GenLUTTo(e.buf.lut, func(x int) int { return boolToInt(x >= half) * (2*half - 1) })
+	e.Evaluator.BootstrapLUTTo(ctOut, ct.Value[len(ct.Value)-1], e.buf.lut)
+}
+
+// SignExtend returns ct sign-extended or truncated to given bits.
+//
+// Panics if bits is not a positive multiple of LogMessageModulus.
+func (e *Evaluator[T]) SignExtend(ct FheUint[T], bits int) FheUint[T] {
+	ctOut := e.newFheUint(e.Params.BlockCount(bits))
+	e.SignExtendTo(ctOut, ct)
+	return ctOut
+}
+
+// SignExtendTo sign-extends or truncates ct to the bit length of ctOut and writes it to ctOut.
+func (e *Evaluator[T]) SignExtendTo(ctOut, ct FheUint[T]) {
+	if len(ctOut.Value) > len(ct.Value) {
+		e.signFillTo(e.buf.ctCond, ct)
+		for i := len(ct.Value); i < len(ctOut.Value); i++ {
+			ctOut.Value[i].CopyFrom(e.buf.ctCond)
+		}
+	}
+
+	for i := 0; i < len(ctOut.Value) && i < len(ct.Value); i++ {
+		ctOut.Value[i].CopyFrom(ct.Value[i])
+	}
+}
+
+// ScalarShiftRightSigned returns ct >> k, where ct is interpreted as a signed integer.
+// This is an arithmetic shift, so the sign bit is shifted in.
+//
+// Panics if k < 0.
+func (e *Evaluator[T]) ScalarShiftRightSigned(ct FheUint[T], k int) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.ScalarShiftRightSignedTo(ctOut, ct, k)
+	return ctOut
+}
+
+// ScalarShiftRightSignedTo computes ctOut = ct >> k, where ct is interpreted as a signed integer.
+// This is an arithmetic shift, so the sign bit is shifted in.
+//
+// Panics if k < 0.
+func (e *Evaluator[T]) ScalarShiftRightSignedTo(ctOut, ct FheUint[T], k int) {
+	if k < 0 {
+		panic("negative shift amount")
+	}
+
+	ctFill := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.signFillTo(ctFill, ct)
+	e.shiftTo(ctOut, ct, num.Min(k, len(ct.Value)*e.Params.logMessageModulus), false, ctFill)
+}
+
+// Abs returns |ct|, where ct is interpreted as a signed integer.
+// The absolute value of the minimum signed integer is itself.
+func (e *Evaluator[T]) Abs(ct FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct.Value))
+	e.AbsTo(ctOut, ct)
+	return ctOut
+}
+
+// AbsTo computes ctOut = |ct|, where ct is interpreted as a signed integer.
+// The absolute value of the minimum signed integer is itself.
+func (e *Evaluator[T]) AbsTo(ctOut, ct FheUint[T]) {
+	ctSign := e.SignBit(ct)
+	e.negIfTo(ctOut, ctSign, ct)
+}
+
+// negIfTo computes ctOut = ctCond ? -ct : ct,
+// where ctCond is an encrypted boolean.
+func (e *Evaluator[T]) negIfTo(ctOut FheUint[T], ctCond tfhe.LWECiphertext[T], ct FheUint[T]) {
+	ctNeg := e.Neg(ct)
+	e.SelectTo(ctOut, ctCond, ctNeg, ct)
+}
+
+// LtSigned returns an encrypted boolean of ct0 < ct1,
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) LtSigned(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.LtSignedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// LtSignedTo computes ctOut = (ct0 < ct1) as an encrypted boolean,
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) LtSignedTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.signTo(ctOut, ct0, ct1, true, func(sign int) bool { return sign == signLess })
+}
+
+// LeSigned returns an encrypted boolean of ct0 <= ct1,
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) LeSigned(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.LeSignedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// LeSignedTo computes ctOut = (ct0 <= ct1) as an encrypted boolean,
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) LeSignedTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.signTo(ctOut, ct0, ct1, true, func(sign int) bool { return sign != signGreater })
+}
+
+// GtSigned returns an encrypted boolean of ct0 > ct1,
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) GtSigned(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.GtSignedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// GtSignedTo computes ctOut = (ct0 > ct1) as an encrypted boolean,
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) GtSignedTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.signTo(ctOut, ct0, ct1, true, func(sign int) bool { return sign == signGreater })
+}
+
+// GeSigned returns an encrypted boolean of ct0 >= ct1,
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) GeSigned(ct0, ct1 FheUint[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.GeSignedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// GeSignedTo computes ctOut = (ct0 >= ct1) as an encrypted boolean,
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) GeSignedTo(ctOut tfhe.LWECiphertext[T], ct0, ct1 FheUint[T]) {
+	e.signTo(ctOut, ct0, ct1, true, func(sign int) bool { return sign != signLess })
+}
+
+// MinSigned returns min(ct0, ct1),
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) MinSigned(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.MinSignedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// MinSignedTo computes ctOut = min(ct0, ct1),
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) MinSignedTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.LtSignedTo(e.buf.ctCond, ct0, ct1)
+	e.SelectTo(ctOut, e.buf.ctCond, ct0, ct1)
+}
+
+// MaxSigned returns max(ct0, ct1),
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) MaxSigned(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.MaxSignedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// MaxSignedTo computes ctOut = max(ct0, ct1),
+// where ct0 and ct1 are interpreted as signed integers.
+func (e *Evaluator[T]) MaxSignedTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.GtSignedTo(e.buf.ctCond, ct0, ct1)
+	e.SelectTo(ctOut, e.buf.ctCond, ct0, ct1)
+}
+
+// DivSigned returns ct0 / ct1, where ct0 and ct1 are interpreted as signed integers.
+// The quotient is truncated towards zero, as in Go.
+//
+// If ct1 is zero, the quotient is -1 if ct0 is non-negative, and 1 otherwise.
+func (e *Evaluator[T]) DivSigned(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.DivSignedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// DivSignedTo computes ctOut = ct0 / ct1, where ct0 and ct1 are interpreted as signed integers.
+// The quotient is truncated towards zero, as in Go.
+//
+// If ct1 is zero, the quotient is -1 if ct0 is non-negative, and 1 otherwise.
+func (e *Evaluator[T]) DivSignedTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.DivRemSignedTo(ctOut, e.newFheUint(len(ct0.Value)), ct0, ct1)
+}
+
+// RemSigned returns ct0 % ct1, where ct0 and ct1 are interpreted as signed integers.
+// The remainder has the same sign as ct0, as in Go.
+//
+// If ct1 is zero, the remainder is ct0.
+func (e *Evaluator[T]) RemSigned(ct0, ct1 FheUint[T]) FheUint[T] {
+	ctOut := e.newFheUint(len(ct0.Value))
+	e.RemSignedTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// RemSignedTo computes ctOut = ct0 % ct1, where ct0 and ct1 are interpreted as signed integers.
+// The remainder has the same sign as ct0, as in Go.
+//
+// If ct1 is zero, the remainder is ct0.
+func (e *Evaluator[T]) RemSignedTo(ctOut, ct0, ct1 FheUint[T]) {
+	e.DivRemSignedTo(e.newFheUint(len(ct0.Value)), ctOut, ct0, ct1)
+}
+
+// DivRemSigned returns ct0 / ct1 and ct0 % ct1,
+// where ct0 and ct1 are interpreted as signed integers.
+// The quotient is truncated towards zero and the remainder has the same sign as ct0, as in Go.
+//
+// If ct1 is zero, the quotient is -1 if ct0 is non-negative and 1 otherwise,
+// and the remainder is ct0.
+func (e *Evaluator[T]) DivRemSigned(ct0, ct1 FheUint[T]) (FheUint[T], FheUint[T]) {
+	ctQuo := e.newFheUint(len(ct0.Value))
+	ctRem := e.newFheUint(len(ct0.Value))
+	e.DivRemSignedTo(ctQuo, ctRem, ct0, ct1)
+	return ctQuo, ctRem
+}
+
+// DivRemSignedTo computes ctQuo = ct0 / ct1 and ctRem = ct0 % ct1,
+// where ct0 and ct1 are interpreted as signed integers.
+// The quotient is truncated towards zero and the remainder has the same sign as ct0, as in Go.
+//
+// If ct1 is zero, the quotient is -1 if ct0 is non-negative and 1 otherwise,
+// and the remainder is ct0.
+func (e *Evaluator[T]) DivRemSignedTo(ctQuo, ctRem, ct0, ct1 FheUint[T]) {
+	ctSign0 := e.SignBit(ct0)
+	ctSign1 := e.SignBit(ct1)
+	ctSignQuo := e.BootstrapBivariateFunc(ctSign0, ctSign1, func(x, y int) int { return x ^ y })
+
+	ctAbs0 := e.newFheUint(len(ct0.Value))
+	ctAbs1 := e.newFheUint(len(ct1.Value))
+	e.negIfTo(ctAbs0, ctSign0, ct0)
+	e.negIfTo(ctAbs1, ctSign1, ct1)
+
+	e.DivRemTo(ctAbs0, ctAbs1, ctAbs0, ctAbs1)
+	e.negIfTo(ctQuo, ctSignQuo, ctAbs0)
+	e.negIfTo(ctRem, ctSign0, ctAbs1)
+}
@@ -0,0 +1,6 @@
+// Package integer implements homomorphic arithmetic on large encrypted integers,
+// built on top of the integer TFHE scheme.
+//
+// An encrypted integer is decomposed into radix blocks,
+// each of which is an LWE ciphertext holding a small digit and a carry space.
+package integer
@@ -0,0 +1,447 @@
+package integer_test
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/sp301415/tfhe-go/integer"
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	params = integer.ParamsMessage2Carry2.Compile()
+	enc    = integer.NewEncryptor(params)
+	eval   = integer.NewEvaluator(params, enc.GenEvalKeyParallel())
+
+	paramsList = []integer.ParametersLiteral[uint64]{
+		integer.ParamsMessage2Carry2,
+		integer.ParamsMessage3Carry3,
+		integer.ParamsMessage4Carry4,
+	}
+)
+
+func TestParams(t *testing.T) {
+	for _, params := range paramsList {
+		t.Run(fmt.Sprintf("Compile/ParamsMessage%vCarry%v", num.Log2(params.MessageModulus), num.Log2(params.BaseParams.MessageModulus/params.MessageModulus)), func(t *testing.T) {
+			assert.NotPanics(t, func() { params.Compile() })
+		})
+	}
+
+	t.Run("BlockCount", func(t *testing.T) {
+		assert.Equal(t, 4, params.BlockCount(8))
+		assert.Equal(t, 32, params.BlockCount(64))
+		assert.Panics(t, func() { params.BlockCount(7) })
+	})
+}
+
+func TestEncryptor(t *testing.T) {
+	for _, bits := range []int{8, 16, 32, 64} {
+		t.Run(fmt.Sprintf("FheUint%v", bits), func(t *testing.T) {
+			m := rand.Uint64()
+			if bits < 64 {
+				m &= 1<<bits - 1
+			}
+			ct := enc.EncryptFheUint(m, bits)
+			assert.Equal(t, m, enc.DecryptFheUint(ct))
+		})
+	}
+
+	t.Run("Bool", func(t *testing.T) {
+		assert.True(t, enc.DecryptBool(enc.EncryptBool(true)))
+		assert.False(t, enc.DecryptBool(enc.EncryptBool(false)))
+	})
+}
+
+func TestEvaluator(t *testing.T) {
+	bits := 8
+	m0, m1 := uint8(rand.Uint64()), uint8(rand.Uint64())
+	c := uint8(rand.Uint64())
+
+	ct0 := enc.EncryptFheUint(uint64(m0), bits)
+	ct1 := enc.EncryptFheUint(uint64(m1), bits)
+
+	t.Run("Add", func(t *testing.T) {
+		assert.Equal(t, uint64(m0+m1), enc.DecryptFheUint(eval.Add(ct0, ct1)))
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		assert.Equal(t, uint64(m0-m1), enc.DecryptFheUint(eval.Sub(ct0, ct1)))
+	})
+
+	t.Run("Neg", func(t *testing.T) {
+		assert.Equal(t, uint64(-m0), enc.DecryptFheUint(eval.Neg(ct0)))
+	})
+
+	t.Run("ScalarAdd", func(t *testing.T) {
+		assert.Equal(t, uint64(m0+c), enc.DecryptFheUint(eval.ScalarAdd(ct0, uint64(c))))
+	})
+
+	t.Run("ScalarSub", func(t *testing.T) {
+		assert.Equal(t, uint64(m0-c), enc.DecryptFheUint(eval.ScalarSub(ct0, uint64(c))))
+	})
+
+	t.Run("ScalarMul", func(t *testing.T) {
+		assert.Equal(t, uint64(m0*c), enc.DecryptFheUint(eval.ScalarMul(ct0, uint64(c))))
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		assert.Equal(t, uint64(m0*m1), enc.DecryptFheUint(eval.Mul(ct0, ct1)))
+	})
+
+	t.Run("DivRem", func(t *testing.T) {
+		if m1 == 0 {
+			m1 = 1
+			ct1 = enc.EncryptFheUint(uint64(m1), bits)
+		}
+		ctQuo, ctRem := eval.DivRem(ct0, ct1)
+		assert.Equal(t, uint64(m0/m1), enc.DecryptFheUint(ctQuo))
+		assert.Equal(t, uint64(m0%m1), enc.DecryptFheUint(ctRem))
+	})
+
+	t.Run("DivByZero", func(t *testing.T) {
+		ctQuo, ctRem := eval.DivRem(ct0, enc.EncryptFheUint(0, bits))
+		assert.Equal(t, uint64(math.MaxUint8), enc.DecryptFheUint(ctQuo))
+		assert.Equal(t, uint64(m0), enc.DecryptFheUint(ctRem))
+	})
+
+	t.Run("PropagateCarry", func(t *testing.T) {
+		ctSum := ct0.Copy()
+		for i := range ctSum.Value {
+			eval.Evaluator.AddLWETo(ctSum.Value[i], ct0.Value[i], ct1.Value[i])
+		}
+		ctOut := eval.PropagateCarry(ctSum)
+
+		assert.Equal(t, uint64(m0+m1), enc.DecryptFheUint(ctOut))
+		for i := range ctOut.Value {
+			assert.Less(t, uint64(enc.Encryptor.DecryptLWE(ctOut.Value[i])), params.MessageModulus())
+		}
+	})
+}
+
+func TestEvaluatorBitwise(t *testing.T) {
+	bitLen := 8
+	m0, m1 := uint8(rand.Uint64()), uint8(rand.Uint64())
+	k := rand.Intn(bitLen)
+
+	ct0 := enc.EncryptFheUint(uint64(m0), bitLen)
+	ct1 := enc.EncryptFheUint(uint64(m1), bitLen)
+	ctK := enc.EncryptFheUint(uint64(k), bitLen)
+
+	t.Run("And", func(t *testing.T) {
+		assert.Equal(t, uint64(m0&m1), enc.DecryptFheUint(eval.And(ct0, ct1)))
+	})
+
+	t.Run("Or", func(t *testing.T) {
+		assert.Equal(t, uint64(m0|m1), enc.DecryptFheUint(eval.Or(ct0, ct1)))
+	})
+
+	t.Run("Xor", func(t *testing.T) {
+		assert.Equal(t, uint64(m0^m1), enc.DecryptFheUint(eval.Xor(ct0, ct1)))
+	})
+
+	t.Run("Not", func(t *testing.T) {
+		assert.Equal(t, uint64(^m0), enc.DecryptFheUint(eval.Not(ct0)))
+	})
+
+	t.Run("ScalarShift", func(t *testing.T) {
+		assert.Equal(t, uint64(m0<<k), enc.DecryptFheUint(eval.ScalarShiftLeft(ct0, k)))
+		assert.Equal(t, uint64(m0>>k), enc.DecryptFheUint(eval.ScalarShiftRight(ct0, k)))
+		assert.Equal(t, uint64(0), enc.DecryptFheUint(eval.ScalarShiftLeft(ct0, bitLen+1)))
+	})
+
+	t.Run("ScalarRotate", func(t *testing.T) {
+		assert.Equal(t, uint64(bits.RotateLeft8(m0, k)), enc.DecryptFheUint(eval.ScalarRotateLeft(ct0, k)))
+		assert.Equal(t, uint64(bits.RotateLeft8(m0, -k)), enc.DecryptFheUint(eval.ScalarRotateRight(ct0, k)))
+	})
+
+	t.Run("Shift", func(t *testing.T) {
+		assert.Equal(t, uint64(m0<<k), enc.DecryptFheUint(eval.ShiftLeft(ct0, ctK)))
+		assert.Equal(t, uint64(m0>>k), enc.DecryptFheUint(eval.ShiftRight(ct0, ctK)))
+	})
+
+	t.Run("Rotate", func(t *testing.T) {
+		assert.Equal(t, uint64(bits.RotateLeft8(m0, k)), enc.DecryptFheUint(eval.RotateLeft(ct0, ctK)))
+		assert.Equal(t, uint64(bits.RotateLeft8(m0, -k)), enc.DecryptFheUint(eval.RotateRight(ct0, ctK)))
+	})
+}
+
+func TestEvaluatorSigned(t *testing.T) {
+	bits := 8
+	m0, m1 := int8(rand.Uint64()), int8(rand.Uint64())
+	if m1 == 0 {
+		m1 = 1
+	}
+	k := rand.Intn(bits)
+
+	ct0 := enc.EncryptFheInt(int64(m0), bits)
+	ct1 := enc.EncryptFheInt(int64(m1), bits)
+
+	t.Run("Encrypt", func(t *testing.T) {
+		assert.Equal(t, int64(m0), enc.DecryptFheInt(ct0))
+		assert.Equal(t, int64(-1), enc.DecryptFheInt(enc.EncryptFheInt(-1, 16)))
+	})
+
+	t.Run("Arithmetic", func(t *testing.T) {
+		assert.Equal(t, int64(m0+m1), enc.DecryptFheInt(eval.Add(ct0, ct1)))
+		assert.Equal(t, int64(m0*m1), enc.DecryptFheInt(eval.Mul(ct0, ct1)))
+		assert.Equal(t, int64(-m0), enc.DecryptFheInt(eval.Neg(ct0)))
+	})
+
+	t.Run("SignExtend", func(t *testing.T) {
+		assert.Equal(t, int64(m0), enc.DecryptFheInt(eval.SignExtend(ct0, 16)))
+		assert.Equal(t, int64(int8(m0<<4)>>4), enc.DecryptFheInt(eval.SignExtend(eval.SignExtend(ct0, 4), 8)))
+	})
+
+	t.Run("ScalarShiftRightSigned", func(t *testing.T) {
+		assert.Equal(t, int64(m0>>k), enc.DecryptFheInt(eval.ScalarShiftRightSigned(ct0, k)))
+		assert.Equal(t, int64(m0>>bits), enc.DecryptFheInt(eval.ScalarShiftRightSigned(ct0, bits)))
+	})
+
+	t.Run("Abs", func(t *testing.T) {
+		abs := m0
+		if abs < 0 {
+			abs = -abs
+		}
+		assert.Equal(t, int64(abs), enc.DecryptFheInt(eval.Abs(ct0)))
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, m0 < m1, enc.DecryptBool(eval.LtSigned(ct0, ct1)))
+		assert.Equal(t, m0 <= m1, enc.DecryptBool(eval.LeSigned(ct0, ct1)))
+		assert.Equal(t, m0 > m1, enc.DecryptBool(eval.GtSigned(ct0, ct1)))
+		assert.Equal(t, m0 >= m1, enc.DecryptBool(eval.GeSigned(ct0, ct1)))
+		assert.Equal(t, int64(num.Min(m0, m1)), enc.DecryptFheInt(eval.MinSigned(ct0, ct1)))
+		assert.Equal(t, int64(num.Max(m0, m1)), enc.DecryptFheInt(eval.MaxSigned(ct0, ct1)))
+	})
+
+	t.Run("DivRemSigned", func(t *testing.T) {
+		ctQuo, ctRem := eval.DivRemSigned(ct0, ct1)
+		assert.Equal(t, int64(m0/m1), enc.DecryptFheInt(ctQuo))
+		assert.Equal(t, int64(m0%m1), enc.DecryptFheInt(ctRem))
+	})
+}
+
+func TestEvaluatorFixed(t *testing.T) {
+	bits, fracBits := 16, 8
+	m0, m1 := int64(rand.Intn(1<<12)-1<<11), int64(rand.Intn(1<<12)-1<<11)
+	f0, f1 := math.Ldexp(float64(m0), -fracBits), math.Ldexp(float64(m1), -fracBits)
+
+	ct0 := enc.EncryptFheFixed(f0, bits, fracBits)
+	ct1 := enc.EncryptFheFixed(f1, bits, fracBits)
+
+	t.Run("Encrypt", func(t *testing.T) {
+		assert.Equal(t, f0, enc.DecryptFheFixed(ct0))
+		assert.Equal(t, -0.5, enc.DecryptFheFixed(enc.EncryptFheFixed(-0.5, bits, fracBits)))
+		assert.Equal(t, 0.25, enc.DecryptFheFixed(enc.EncryptFheFixed(0.2, bits, 2)))
+	})
+
+	t.Run("Arithmetic", func(t *testing.T) {
+		assert.Equal(t, f0+f1, enc.DecryptFheFixed(eval.AddFixed(ct0, ct1)))
+		assert.Equal(t, f0-f1, enc.DecryptFheFixed(eval.SubFixed(ct0, ct1)))
+		assert.Equal(t, -f0, enc.DecryptFheFixed(eval.NegFixed(ct0)))
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		mulRound := math.Ldexp(float64((m0*m1+1<<(fracBits-1))>>fracBits), -fracBits)
+		mulTrunc := math.Ldexp(float64((m0*m1)>>fracBits), -fracBits)
+		assert.Equal(t, mulRound, enc.DecryptFheFixed(eval.MulFixed(ct0, ct1)))
+		assert.Equal(t, mulTrunc, enc.DecryptFheFixed(eval.MulFixedTruncate(ct0, ct1)))
+	})
+
+	t.Run("Rescale", func(t *testing.T) {
+		assert.Equal(t, math.Ldexp(float64((m0+1<<3)>>4), -4), enc.DecryptFheFixed(eval.RescaleFixed(ct0, 4)))
+		assert.Equal(t, f0, enc.DecryptFheFixed(eval.RescaleFixed(ct0, 10)))
+	})
+}
+
+func TestEvaluatorManyLUT(t *testing.T) {
+	params := integer.ParamsMessage2Carry2LUT2.Compile()
+	enc := integer.NewEncryptor(params)
+	eval := integer.NewEvaluator(params, enc.GenEvalKeyParallel())
+
+	bits := 16
+	m0, m1 := uint16(rand.Uint64()), uint16(rand.Uint64())
+
+	ct0 := enc.EncryptFheUint(uint64(m0), bits)
+	ct1 := enc.EncryptFheUint(uint64(m1), bits)
+
+	t.Run("Add", func(t *testing.T) {
+		assert.Equal(t, uint64(m0+m1), enc.DecryptFheUint(eval.Add(ct0, ct1)))
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		assert.Equal(t, uint64(m0*m1), enc.DecryptFheUint(eval.Mul(ct0, ct1)))
+	})
+}
+
+func TestEvaluatorCompare(t *testing.T) {
+	bits := 16
+	m0, m1 := uint16(rand.Uint64()), uint16(rand.Uint64())
+	m1 = m1&0xff00 | m0&0x00ff
+
+	ct0 := enc.EncryptFheUint(uint64(m0), bits)
+	ct1 := enc.EncryptFheUint(uint64(m1), bits)
+
+	t.Run("Eq", func(t *testing.T) {
+		assert.Equal(t, m0 == m1, enc.DecryptBool(eval.Eq(ct0, ct1)))
+		assert.True(t, enc.DecryptBool(eval.Eq(ct0, ct0)))
+	})
+
+	t.Run("Ne", func(t *testing.T) {
+		assert.Equal(t, m0 != m1, enc.DecryptBool(eval.Ne(ct0, ct1)))
+		assert.False(t, enc.DecryptBool(eval.Ne(ct0, ct0)))
+	})
+
+	t.Run("Lt", func(t *testing.T) {
+		assert.Equal(t, m0 < m1, enc.DecryptBool(eval.Lt(ct0, ct1)))
+		assert.False(t, enc.DecryptBool(eval.Lt(ct0, ct0)))
+	})
+
+	t.Run("Le", func(t *testing.T) {
+		assert.Equal(t, m0 <= m1, enc.DecryptBool(eval.Le(ct0, ct1)))
+		assert.True(t, enc.DecryptBool(eval.Le(ct0, ct0)))
+	})
+
+	t.Run("Gt", func(t *testing.T) {
+		assert.Equal(t, m0 > m1, enc.DecryptBool(eval.Gt(ct0, ct1)))
+		assert.False(t, enc.DecryptBool(eval.Gt(ct0, ct0)))
+	})
+
+	t.Run("Ge", func(t *testing.T) {
+		assert.Equal(t, m0 >= m1, enc.DecryptBool(eval.Ge(ct0, ct1)))
+		assert.True(t, enc.DecryptBool(eval.Ge(ct0, ct0)))
+	})
+
+	t.Run("Min", func(t *testing.T) {
+		assert.Equal(t, uint64(num.Min(m0, m1)), enc.DecryptFheUint(eval.Min(ct0, ct1)))
+	})
+
+	t.Run("Max", func(t *testing.T) {
+		assert.Equal(t, uint64(num.Max(m0, m1)), enc.DecryptFheUint(eval.Max(ct0, ct1)))
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		ctTrue, ctFalse := enc.EncryptBool(true), enc.EncryptBool(false)
+		assert.Equal(t, uint64(m0), enc.DecryptFheUint(eval.Select(ctTrue, ct0, ct1)))
+		assert.Equal(t, uint64(m1), enc.DecryptFheUint(eval.Select(ctFalse, ct0, ct1)))
+
+		for _, c := range []bool{true, false} {
+			for _, b0 := range []bool{true, false} {
+				for _, b1 := range []bool{true, false} {
+					ctSelect := eval.SelectBool(enc.EncryptBool(c), enc.EncryptBool(b0), enc.EncryptBool(b1))
+					assert.Equal(t, c && b0 || !c && b1, enc.DecryptBool(ctSelect))
+				}
+			}
+		}
+	})
+}
+
+func TestCRT(t *testing.T) {
+	params := integer.ParamsCRTUint6.Compile()
+	enc := integer.NewCRTEncryptor(params)
+	eval := integer.NewCRTEvaluator(params, enc.GenEvalKeyParallel())
+
+	t.Run("Compile", func(t *testing.T) {
+		assert.NotPanics(t, func() { integer.ParamsCRTUint8.Compile() })
+		assert.Equal(t, uint64(840), params.Modulus())
+		assert.Panics(t, func() { integer.ParamsCRTUint6.WithModuli([]uint64{3, 6}).Compile() })
+		assert.Panics(t, func() { integer.ParamsCRTUint6.WithModuli([]uint64{3, 11}).Compile() })
+	})
+
+	M := params.Modulus()
+	m0, m1 := rand.Uint64()%M, rand.Uint64()%M
+	c := rand.Uint64()
+
+	ct0 := enc.EncryptFheUintCRT(m0)
+	ct1 := enc.EncryptFheUintCRT(m1)
+
+	t.Run("Encrypt", func(t *testing.T) {
+		assert.Equal(t, m0, enc.DecryptFheUintCRT(ct0))
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		assert.Equal(t, (m0+m1)%M, enc.DecryptFheUintCRT(eval.Add(ct0, ct1)))
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		assert.Equal(t, (m0+M-m1)%M, enc.DecryptFheUintCRT(eval.Sub(ct0, ct1)))
+	})
+
+	t.Run("Neg", func(t *testing.T) {
+		assert.Equal(t, (M-m0)%M, enc.DecryptFheUintCRT(eval.Neg(ct0)))
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		assert.Equal(t, (m0*m1)%M, enc.DecryptFheUintCRT(eval.Mul(ct0, ct1)))
+	})
+
+	t.Run("ScalarAdd", func(t *testing.T) {
+		assert.Equal(t, (m0+c%M)%M, enc.DecryptFheUintCRT(eval.ScalarAdd(ct0, c)))
+	})
+
+	t.Run("ScalarMul", func(t *testing.T) {
+		assert.Equal(t, (m0*(c%M))%M, enc.DecryptFheUintCRT(eval.ScalarMul(ct0, c)))
+	})
+}
+
+func BenchmarkAdd(b *testing.B) {
+	for _, bits := range []int{8, 16, 32, 64} {
+		ct0 := enc.EncryptFheUint(0, bits)
+		ct1 := enc.EncryptFheUint(0, bits)
+		ctOut := ct0.Copy()
+
+		b.Run(fmt.Sprintf("FheUint%v", bits), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				eval.AddTo(ctOut, ct0, ct1)
+			}
+		})
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	for _, bits := range []int{8, 16, 32} {
+		ct0 := enc.EncryptFheUint(0, bits)
+		ct1 := enc.EncryptFheUint(0, bits)
+		ctOut := ct0.Copy()
+
+		b.Run(fmt.Sprintf("FheUint%v", bits), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				eval.MulTo(ctOut, ct0, ct1)
+			}
+		})
+	}
+}
+
+func BenchmarkLt(b *testing.B) {
+	for _, bits := range []int{8, 16, 32, 64} {
+		ct0 := enc.EncryptFheUint(0, bits)
+		ct1 := enc.EncryptFheUint(0, bits)
+		ctOut := eval.Lt(ct0, ct1)
+
+		b.Run(fmt.Sprintf("FheUint%v", bits), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				eval.LtTo(ctOut, ct0, ct1)
+			}
+		})
+	}
+}
+
+func ExampleEvaluator_Add() {
+	params := integer.ParamsMessage2Carry2.Compile()
+
+	enc := integer.NewEncryptor(params)
+
+	ct0 := enc.EncryptFheUint(200, 8)
+	ct1 := enc.EncryptFheUint(100, 8)
+
+	eval := integer.NewEvaluator(params, enc.GenEvalKeyParallel())
+
+	ctOut := eval.Add(ct0, ct1)
+	fmt.Println(enc.DecryptFheUint(ctOut))
+	// Output:
+	// 44
+}
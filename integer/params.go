@@ -0,0 +1,161 @@
+package integer
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+	"github.com/sp301415/tfhe-go/xtfhe"
+)
+
+// ParametersLiteral is a structure for radix integer parameters.
+//
+// The MessageModulus of BaseParams is the modulus of a whole block,
+// which is split into a message space of size MessageModulus and a carry space of size
+// BaseParams.MessageModulus / MessageModulus.
+// BaseParams.MessageModulus must be at least 9,
+// so that the comparison results of two blocks can be packed into one block.
+type ParametersLiteral[T tfhe.TorusInt] struct {
+	// BaseParams is the base parameter set for this ParametersLiteral.
+	BaseParams tfhe.ParametersLiteral[T]
+
+	// MessageModulus is the modulus of the message stored in each block.
+	// It must be a power of two, and the carry modulus must be at least MessageModulus.
+	MessageModulus T
+
+	// LUTCount is the number of LUTs evaluated at once by many-LUT bootstrapping,
+	// which is used to extract multiple values from a block in one bootstrap.
+	// If LUTCount is larger than one, BaseParams should support xtfhe.ManyLUTParametersLiteral
+	// with given LUTCount.
+	// Otherwise, many-LUT bootstrapping is not used.
+	LUTCount int
+}
+
+// WithBaseParams sets the BaseParams and returns the new ParametersLiteral.
+func (p ParametersLiteral[T]) WithBaseParams(baseParams tfhe.ParametersLiteral[T]) ParametersLiteral[T] {
+	p.BaseParams = baseParams
+	return p
+}
+
+// WithMessageModulus sets the MessageModulus and returns the new ParametersLiteral.
+func (p ParametersLiteral[T]) WithMessageModulus(messageModulus T) ParametersLiteral[T] {
+	p.MessageModulus = messageModulus
+	return p
+}
+
+// WithLUTCount sets the LUTCount and returns the new ParametersLiteral.
+func (p ParametersLiteral[T]) WithLUTCount(lutCount int) ParametersLiteral[T] {
+	p.LUTCount = lutCount
+	return p
+}
+
+// Compile transforms ParametersLiteral to read-only Parameters.
+// If there is any invalid parameter in the literal, it panics.
+// Default parameters are guaranteed to compile without panicking.
+func (p ParametersLiteral[T]) Compile() Parameters[T] {
+	baseParams := p.BaseParams.Compile()
+
+	switch {
+	case p.MessageModulus < 2:
+		panic("MessageModulus smaller than two")
+	case !num.IsPowerOfTwo(p.MessageModulus):
+		panic("MessageModulus not power of two")
+	case !num.IsPowerOfTwo(baseParams.MessageModulus()):
+		panic("BaseParams.MessageModulus not power of two")
+	case baseParams.MessageModulus()/p.MessageModulus < p.MessageModulus:
+		panic("CarryModulus smaller than MessageModulus")
+	case baseParams.MessageModulus() < 9:
+		panic("BaseParams.MessageModulus smaller than 9")
+	}
+
+	params := Parameters[T]{
+		baseParams: baseParams,
+
+		messageModulus:    p.MessageModulus,
+		logMessageModulus: num.Log2(p.MessageModulus),
+		carryModulus:      baseParams.MessageModulus() / p.MessageModulus,
+
+		lutCount: 1,
+	}
+
+	if p.LUTCount > 1 {
+		params.manyLUTParams = xtfhe.ManyLUTParametersLiteral[T]{
+			BaseParams: p.BaseParams,
+			LUTCount:   p.LUTCount,
+		}.Compile()
+		params.lutCount = p.LUTCount
+	}
+
+	return params
+}
+
+// Parameters are read-only, compiled parameters for radix integers.
+type Parameters[T tfhe.TorusInt] struct {
+	// baseParams is the base parameter set for this Parameters.
+	baseParams tfhe.Parameters[T]
+
+	// messageModulus is the modulus of the message stored in each block.
+	messageModulus T
+	// logMessageModulus equals log(MessageModulus).
+	logMessageModulus int
+	// carryModulus is the modulus of the carry stored in each block.
+	// Equals BaseParams.MessageModulus / MessageModulus.
+	carryModulus T
+
+	// lutCount is the number of LUTs evaluated at once by many-LUT bootstrapping.
+	// Equals one if many-LUT bootstrapping is not used.
+	lutCount int
+	// manyLUTParams is the parameter set for many-LUT bootstrapping.
+	// Only valid if lutCount > 1.
+	manyLUTParams xtfhe.ManyLUTParameters[T]
+}
+
+// BaseParams returns the base parameters for this Parameters.
+func (p Parameters[T]) BaseParams() tfhe.Parameters[T] {
+	return p.baseParams
+}
+
+// MessageModulus is the modulus of the message stored in each block.
+func (p Parameters[T]) MessageModulus() T {
+	return p.messageModulus
+}
+
+// LogMessageModulus equals log(MessageModulus).
+func (p Parameters[T]) LogMessageModulus() int {
+	return p.logMessageModulus
+}
+
+// CarryModulus is the modulus of the carry stored in each block.
+// Equals BaseParams.MessageModulus / MessageModulus.
+func (p Parameters[T]) CarryModulus() T {
+	return p.carryModulus
+}
+
+// LUTCount is the number of LUTs evaluated at once by many-LUT bootstrapping.
+// Equals one if many-LUT bootstrapping is not used.
+func (p Parameters[T]) LUTCount() int {
+	return p.lutCount
+}
+
+// ManyLUTParams returns the parameters for many-LUT bootstrapping.
+// Only valid if LUTCount > 1.
+func (p Parameters[T]) ManyLUTParams() xtfhe.ManyLUTParameters[T] {
+	return p.manyLUTParams
+}
+
+// BlockCount returns the number of blocks needed to represent an integer of given bits.
+//
+// Panics if bits is not a positive multiple of LogMessageModulus.
+func (p Parameters[T]) BlockCount(bits int) int {
+	if bits <= 0 || bits%p.logMessageModulus != 0 {
+		panic("bits not multiple of LogMessageModulus")
+	}
+	return bits / p.logMessageModulus
+}
+
+// Literal returns a ParametersLiteral from this Parameters.
+func (p Parameters[T]) Literal() ParametersLiteral[T] {
+	return ParametersLiteral[T]{
+		BaseParams:     p.baseParams.Literal(),
+		MessageModulus: p.messageModulus,
+		LUTCount:       p.lutCount,
+	}
+}
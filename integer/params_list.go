@@ -0,0 +1,46 @@
+package integer
+
+import "github.com/sp301415/tfhe-go/tfhe"
+
+var (
+	// ParamsMessage2Carry2 is a default parameter set with 2 bits of message and 2 bits of carry per block.
+	ParamsMessage2Carry2 = ParametersLiteral[uint64]{
+		BaseParams:     tfhe.ParamsUint4,
+		MessageModulus: 1 << 2,
+	}
+
+	// ParamsMessage2Carry2LUT2 is a parameter set with 2 bits of message and 2 bits of carry per block,
+	// which uses many-LUT bootstrapping with 2 LUTs.
+	// This halves the number of bootstraps in carry propagation and multiplication.
+	ParamsMessage2Carry2LUT2 = ParametersLiteral[uint64]{
+		BaseParams:     tfhe.ParamsUint5.WithMessageModulus(1 << 4),
+		MessageModulus: 1 << 2,
+		LUTCount:       2,
+	}
+
+	// ParamsMessage3Carry3 is a parameter set with 3 bits of message and 3 bits of carry per block.
+	ParamsMessage3Carry3 = ParametersLiteral[uint64]{
+		BaseParams:     tfhe.ParamsUint6,
+		MessageModulus: 1 << 3,
+	}
+
+	// ParamsMessage4Carry4 is a parameter set with 4 bits of message and 4 bits of carry per block.
+	ParamsMessage4Carry4 = ParametersLiteral[uint64]{
+		BaseParams:     tfhe.ParamsUint8,
+		MessageModulus: 1 << 4,
+	}
+
+	// ParamsCRTUint6 is a parameter set for CRT integers with moduli 3, 5, 7 and 8,
+	// so that the integer is computed modulo 840.
+	ParamsCRTUint6 = CRTParametersLiteral[uint64]{
+		BaseParams: tfhe.ParamsUint6,
+		Moduli:     []uint64{3, 5, 7, 8},
+	}
+
+	// ParamsCRTUint8 is a parameter set for CRT integers with moduli 7, 11, 13, 15 and 16,
+	// so that the integer is computed modulo 240240.
+	ParamsCRTUint8 = CRTParametersLiteral[uint64]{
+		BaseParams: tfhe.ParamsUint8,
+		Moduli:     []uint64{7, 11, 13, 15, 16},
+	}
+)
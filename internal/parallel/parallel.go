@@ -0,0 +1,58 @@
+// Package parallel implements the worker pool shared by batch and parallel operations.
+package parallel
+
+import (
+	"sync"
+)
+
+// Workers returns the first min(workerCount, jobCount) elements of pool,
+// appending the results of newWorker to pool if it is not long enough.
+// Workers are kept in pool so that they can be reused by later calls.
+func Workers[W any](pool *[]W, workerCount, jobCount int, newWorker func() W) []W {
+	if jobCount < workerCount {
+		workerCount = jobCount
+	}
+
+	for len(*pool) < workerCount {
+		*pool = append(*pool, newWorker())
+	}
+	return (*pool)[:workerCount]
+}
+
+// Run calls f(worker, i) for all 0 <= i < jobCount using workerCount goroutines,
+// where 0 <= worker < workerCount is the index of the goroutine calling f.
+// Calls with the same worker index are never concurrent,
+// so f can use per-worker buffers or evaluators indexed by worker.
+//
+// Panics if workerCount is not positive and jobCount is positive.
+func Run(workerCount, jobCount int, f func(worker, i int)) {
+	if jobCount == 0 {
+		return
+	}
+	if workerCount <= 0 {
+		panic("Worker count not positive")
+	}
+	if jobCount < workerCount {
+		workerCount = jobCount
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := 0; i < jobCount; i++ {
+			jobs <- i
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workerCount)
+	for w := 0; w < workerCount; w++ {
+		go func(worker int) {
+			defer wg.Done()
+			for i := range jobs {
+				f(worker, i)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
@@ -213,6 +213,16 @@ func Sqrt[T Integer](x T) T {
 	return T(c)
 }
 
+// Gcd returns the greatest common divisor of x and y.
+// Output is always non-negative.
+func Gcd[T Integer](x, y T) T {
+	x, y = Abs(x), Abs(y)
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return x
+}
+
 // ModInverse returns the modular inverse of x modulo m.
 // Output is always positive.
 // Panics if m <= 0 or x and m are not coprime.
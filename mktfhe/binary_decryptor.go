@@ -1,6 +1,10 @@
 package mktfhe
 
-import "github.com/sp301415/tfhe-go/tfhe"
+import (
+	"math/bits"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+)
 
 // BinaryDecryptor is a multi-key TFHE binary decryptor.
 type BinaryDecryptor[T tfhe.TorusInt] struct {
@@ -51,3 +55,15 @@ func (d *BinaryDecryptor[T]) DecryptLWEBits(ct []LWECiphertext[T]) int {
 	}
 	return message
 }
+
+// DecryptLWEBitsSigned decrypts a slice of binary LWE ciphertext
+// to signed integer message in two's complement.
+// The order of bits of LWE ciphertexts is assumed to be little-endian,
+// and the last bit is the sign bit.
+func (d *BinaryDecryptor[T]) DecryptLWEBitsSigned(ct []LWECiphertext[T]) int {
+	message := d.DecryptLWEBits(ct)
+	if len(ct) > 0 && len(ct) < bits.UintSize && message>>(len(ct)-1)&1 == 1 {
+		message -= 1 << len(ct)
+	}
+	return message
+}
@@ -61,6 +61,9 @@ func TestBinaryEvaluator(t *testing.T) {
 		{false, false, encBinary[0].EncryptLWEBool(false), encBinary[1].EncryptLWEBool(false)},
 	}
 
+	pt2s := []bool{true, false}
+	ct2s := []mktfhe.LWECiphertext[uint64]{encBinary[0].EncryptLWEBool(true), encBinary[0].EncryptLWEBool(false)}
+
 	t.Run("AND", func(t *testing.T) {
 		for _, tc := range tests {
 			assert.Equal(t, tc.pt0 && tc.pt1, decBinary.DecryptLWEBool(evalBinary.AND(tc.ct0, tc.ct1)))
@@ -133,6 +136,144 @@ func TestBinaryEvaluator(t *testing.T) {
 		}
 	})
 
+	t.Run("ANDNY", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, !tc.pt0 && tc.pt1, decBinary.DecryptLWEBool(evalBinary.ANDNY(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ANDNYParallel", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, !tc.pt0 && tc.pt1, decBinary.DecryptLWEBool(evalBinary.ANDNYParallel(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ANDYN", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, tc.pt0 && !tc.pt1, decBinary.DecryptLWEBool(evalBinary.ANDYN(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ANDYNParallel", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, tc.pt0 && !tc.pt1, decBinary.DecryptLWEBool(evalBinary.ANDYNParallel(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ORNY", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, !tc.pt0 || tc.pt1, decBinary.DecryptLWEBool(evalBinary.ORNY(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ORNYParallel", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, !tc.pt0 || tc.pt1, decBinary.DecryptLWEBool(evalBinary.ORNYParallel(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ORYN", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, tc.pt0 || !tc.pt1, decBinary.DecryptLWEBool(evalBinary.ORYN(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ORYNParallel", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, tc.pt0 || !tc.pt1, decBinary.DecryptLWEBool(evalBinary.ORYNParallel(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("MAJ", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, (tc.pt0 && tc.pt1) || (tc.pt1 && pt2) || (pt2 && tc.pt0), decBinary.DecryptLWEBool(evalBinary.MAJ(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("MAJParallel", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, (tc.pt0 && tc.pt1) || (tc.pt1 && pt2) || (pt2 && tc.pt0), decBinary.DecryptLWEBool(evalBinary.MAJParallel(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("XOR3", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 != tc.pt1 != pt2, decBinary.DecryptLWEBool(evalBinary.XOR3(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("XOR3Parallel", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 != tc.pt1 != pt2, decBinary.DecryptLWEBool(evalBinary.XOR3Parallel(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("AND3", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 && tc.pt1 && pt2, decBinary.DecryptLWEBool(evalBinary.AND3(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("AND3Parallel", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 && tc.pt1 && pt2, decBinary.DecryptLWEBool(evalBinary.AND3Parallel(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("OR3", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 || tc.pt1 || pt2, decBinary.DecryptLWEBool(evalBinary.OR3(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("OR3Parallel", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 || tc.pt1 || pt2, decBinary.DecryptLWEBool(evalBinary.OR3Parallel(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("MUX", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, (pt2 && tc.pt0) || (!pt2 && tc.pt1), decBinary.DecryptLWEBool(evalBinary.MUX(ct2, tc.ct0, tc.ct1)))
+			}
+		}
+	})
+
+	t.Run("MUXParallel", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, (pt2 && tc.pt0) || (!pt2 && tc.pt1), decBinary.DecryptLWEBool(evalBinary.MUXParallel(ct2, tc.ct0, tc.ct1)))
+			}
+		}
+	})
+
 	t.Run("Bits", func(t *testing.T) {
 		msg0, msg1 := 0b01, 0b10
 		ct0 := encBinary[0].EncryptLWEBits(msg0, 4)
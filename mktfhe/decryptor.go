@@ -123,6 +123,12 @@ func (d *Decryptor[T]) DecryptLWE(ct LWECiphertext[T]) int {
 	return d.DecodeLWE(d.DecryptLWEPlaintext(ct))
 }
 
+// DecryptLWESigned decrypts and decodes LWE ciphertext to signed integer message
+// in [-MessageModulus/2, MessageModulus/2).
+func (d *Decryptor[T]) DecryptLWESigned(ct LWECiphertext[T]) int {
+	return d.DecodeLWESigned(d.DecryptLWEPlaintext(ct))
+}
+
 // DecryptLWEPlaintext decrypts LWE ciphertext to LWE plaintext.
 func (d *Decryptor[T]) DecryptLWEPlaintext(ct LWECiphertext[T]) tfhe.LWEPlaintext[T] {
 	ptOut := ct.Value[0]
@@ -0,0 +1,82 @@
+package mktfhe
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// Object types of the mktfhe package.
+const (
+	ObjectLWECiphertext     tfhe.ObjectType = 0x0200
+	ObjectGLWECiphertext    tfhe.ObjectType = 0x0201
+	ObjectUniEncryption     tfhe.ObjectType = 0x0202
+	ObjectFFTGLWECiphertext tfhe.ObjectType = 0x0203
+	ObjectFFTUniEncryption  tfhe.ObjectType = 0x0204
+	ObjectEvaluationKey     tfhe.ObjectType = 0x0205
+)
+
+// Hash returns the hash of the parameters.
+// Parameters compiled from the same ParametersLiteral have the same hash.
+func (p Parameters[T]) Hash() [tfhe.ParamsHashSize]byte {
+	return tfhe.HashParameters("mktfhe.Parameters", p.LogQ(), p)
+}
+
+// ObjectType returns [ObjectLWECiphertext].
+func (ct LWECiphertext[T]) ObjectType() tfhe.ObjectType {
+	return ObjectLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct LWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGLWECiphertext].
+func (ct GLWECiphertext[T]) ObjectType() tfhe.ObjectType {
+	return ObjectGLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct GLWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectUniEncryption].
+func (ct UniEncryption[T]) ObjectType() tfhe.ObjectType {
+	return ObjectUniEncryption
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct UniEncryption[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectFFTGLWECiphertext].
+func (ct FFTGLWECiphertext[T]) ObjectType() tfhe.ObjectType {
+	return ObjectFFTGLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct FFTGLWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectFFTUniEncryption].
+func (ct FFTUniEncryption[T]) ObjectType() tfhe.ObjectType {
+	return ObjectFFTUniEncryption
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct FFTUniEncryption[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectEvaluationKey].
+func (evk EvaluationKey[T]) ObjectType() tfhe.ObjectType {
+	return ObjectEvaluationKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (evk EvaluationKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
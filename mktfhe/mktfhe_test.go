@@ -233,4 +233,28 @@ func TestMarshal(t *testing.T) {
 
 		assert.Equal(t, evkIn, evkOut)
 	})
+	t.Run("Envelope", func(t *testing.T) {
+		var ctIn, ctOut mktfhe.GLWECiphertext[uint64]
+
+		ctIn = enc[0].EncryptGLWE([]int{0})
+		n, err = tfhe.WriteEnvelope(&buf, params, ctIn)
+		assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = tfhe.ReadEnvelope(&buf, params, &ctOut)
+		assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, ctIn, ctOut)
+
+		data, err := tfhe.MarshalEnvelope(params, ctIn)
+		assert.NoError(t, err)
+		assert.Equal(t, data[6:8], []byte{0x02, 0x01})
+
+		assert.Equal(t, mktfhe.ObjectLWECiphertext, tfhe.ObjectType(0x0200))
+		assert.Equal(t, mktfhe.ObjectEvaluationKey, tfhe.ObjectType(0x0205))
+
+		assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, params.SubParams(), &ctOut), tfhe.ErrParamsMismatch)
+		assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, params, &mktfhe.LWECiphertext[uint64]{}), tfhe.ErrObjectTypeMismatch)
+	})
 }
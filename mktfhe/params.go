@@ -124,6 +124,8 @@ func (p ParametersLiteral[T]) Compile() Parameters[T] {
 		panic("Multi-Key TFHE only supports GLWE dimension 1")
 	case subParams.LUTSize() != subParams.PolyRank():
 		panic("Multi-Key TFHE only supports LUTSize equal to PolyRank")
+	case subParams.GroupingFactor() != 1:
+		panic("Multi-Key TFHE only supports GroupingFactor 1")
 	}
 
 	return Parameters[T]{
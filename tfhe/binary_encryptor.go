@@ -1,5 +1,7 @@
 package tfhe
 
+import "math/bits"
+
 // BinaryEncryptor encrypts binary TFHE plaintexts and ciphertexts.
 // This is meant to be private, only for clients.
 //
@@ -93,6 +95,18 @@ func (e *BinaryEncryptor[T]) DecryptLWEBits(ct []LWECiphertext[T]) int {
 	return message
 }
 
+// DecryptLWEBitsSigned decrypts a slice of binary LWE ciphertext
+// to signed integer message in two's complement.
+// The order of bits of LWE ciphertexts is assumed to be little-endian,
+// and the last bit is the sign bit.
+func (e *BinaryEncryptor[T]) DecryptLWEBitsSigned(ct []LWECiphertext[T]) int {
+	message := e.DecryptLWEBits(ct)
+	if len(ct) > 0 && len(ct) < bits.UintSize && message>>(len(ct)-1)&1 == 1 {
+		message -= 1 << len(ct)
+	}
+	return message
+}
+
 // GenPublicKey samples a new public key.
 //
 // Panics when the parameters do not support public key encryption.
@@ -19,6 +19,18 @@ type BinaryEvaluator[T TorusInt] struct {
 	Evaluator *Evaluator[T]
 	// signLUT is a LUT for sign function.
 	signLUT LookUpTable[T]
+
+	buf binaryEvaluatorBuffer[T]
+}
+
+// binaryEvaluatorBuffer is a buffer for BinaryEvaluator.
+type binaryEvaluatorBuffer[T TorusInt] struct {
+	// ctGate is the input of the gate bootstraps in multi-bootstrap gates.
+	ctGate [2]LWECiphertext[T]
+	// ctRotate is a blind rotated GLWE ciphertext for MUX.
+	ctRotate GLWECiphertext[T]
+	// ctExtract is the extracted LWE ciphertexts after Blind Rotation for MUX.
+	ctExtract [2]LWECiphertext[T]
 }
 
 // NewBinaryEvaluator creates a new [BinaryEvaluator].
@@ -34,6 +46,17 @@ func NewBinaryEvaluator[T TorusInt](params Parameters[T], evk EvaluationKey[T])
 		Params:        params,
 		Evaluator:     NewEvaluator(params, evk),
 		signLUT:       signLUT,
+
+		buf: newBinaryEvaluatorBuffer(params),
+	}
+}
+
+// newBinaryEvaluatorBuffer creates a new [binaryEvaluatorBuffer].
+func newBinaryEvaluatorBuffer[T TorusInt](params Parameters[T]) binaryEvaluatorBuffer[T] {
+	return binaryEvaluatorBuffer[T]{
+		ctGate:    [2]LWECiphertext[T]{NewLWECiphertext(params), NewLWECiphertext(params)},
+		ctRotate:  NewGLWECiphertext(params),
+		ctExtract: [2]LWECiphertext[T]{NewLWECiphertextCustom[T](params.glweDimension), NewLWECiphertextCustom[T](params.glweDimension)},
 	}
 }
 
@@ -44,6 +67,8 @@ func (e *BinaryEvaluator[T]) SafeCopy() *BinaryEvaluator[T] {
 		Params:        e.Params,
 		Evaluator:     e.Evaluator.SafeCopy(),
 		signLUT:       e.signLUT,
+
+		buf: newBinaryEvaluatorBuffer(e.Params),
 	}
 }
 
@@ -177,3 +202,213 @@ func (e *BinaryEvaluator[T]) XNORTo(ctOut, ct0, ct1 LWECiphertext[T]) {
 
 	e.Evaluator.BootstrapLUTTo(ctOut, ctOut, e.signLUT)
 }
+
+// ANDNY returns (NOT ct0) AND ct1.
+// Equivalent to !ct0 && ct1.
+func (e *BinaryEvaluator[T]) ANDNY(ct0, ct1 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.ANDNYTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// ANDNYTo computes ctOut = (NOT ct0) AND ct1.
+// Equivalent to !ct0 && ct1.
+func (e *BinaryEvaluator[T]) ANDNYTo(ctOut, ct0, ct1 LWECiphertext[T]) {
+	for i := 0; i < e.Params.DefaultLWEDimension()+1; i++ {
+		ctOut.Value[i] = -ct0.Value[i] + ct1.Value[i]
+	}
+	ctOut.Value[0] -= 1 << (e.Params.logQ - 3)
+
+	e.Evaluator.BootstrapLUTTo(ctOut, ctOut, e.signLUT)
+}
+
+// ANDYN returns ct0 AND (NOT ct1).
+// Equivalent to ct0 && !ct1.
+func (e *BinaryEvaluator[T]) ANDYN(ct0, ct1 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.ANDYNTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// ANDYNTo computes ctOut = ct0 AND (NOT ct1).
+// Equivalent to ct0 && !ct1.
+func (e *BinaryEvaluator[T]) ANDYNTo(ctOut, ct0, ct1 LWECiphertext[T]) {
+	for i := 0; i < e.Params.DefaultLWEDimension()+1; i++ {
+		ctOut.Value[i] = ct0.Value[i] - ct1.Value[i]
+	}
+	ctOut.Value[0] -= 1 << (e.Params.logQ - 3)
+
+	e.Evaluator.BootstrapLUTTo(ctOut, ctOut, e.signLUT)
+}
+
+// ORNY returns (NOT ct0) OR ct1.
+// Equivalent to !ct0 || ct1.
+func (e *BinaryEvaluator[T]) ORNY(ct0, ct1 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.ORNYTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// ORNYTo computes ctOut = (NOT ct0) OR ct1.
+// Equivalent to !ct0 || ct1.
+func (e *BinaryEvaluator[T]) ORNYTo(ctOut, ct0, ct1 LWECiphertext[T]) {
+	for i := 0; i < e.Params.DefaultLWEDimension()+1; i++ {
+		ctOut.Value[i] = -ct0.Value[i] + ct1.Value[i]
+	}
+	ctOut.Value[0] += 1 << (e.Params.logQ - 3)
+
+	e.Evaluator.BootstrapLUTTo(ctOut, ctOut, e.signLUT)
+}
+
+// ORYN returns ct0 OR (NOT ct1).
+// Equivalent to ct0 || !ct1.
+func (e *BinaryEvaluator[T]) ORYN(ct0, ct1 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.ORYNTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// ORYNTo computes ctOut = ct0 OR (NOT ct1).
+// Equivalent to ct0 || !ct1.
+func (e *BinaryEvaluator[T]) ORYNTo(ctOut, ct0, ct1 LWECiphertext[T]) {
+	for i := 0; i < e.Params.DefaultLWEDimension()+1; i++ {
+		ctOut.Value[i] = ct0.Value[i] - ct1.Value[i]
+	}
+	ctOut.Value[0] += 1 << (e.Params.logQ - 3)
+
+	e.Evaluator.BootstrapLUTTo(ctOut, ctOut, e.signLUT)
+}
+
+// MAJ returns the majority of ct0, ct1 and ct2.
+// Equivalent to (ct0 && ct1) || (ct1 && ct2) || (ct2 && ct0).
+//
+// This takes only one bootstrap, but has slightly higher failure probability
+// than two-input gates, since three ciphertexts are added.
+func (e *BinaryEvaluator[T]) MAJ(ct0, ct1, ct2 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.MAJTo(ctOut, ct0, ct1, ct2)
+	return ctOut
+}
+
+// MAJTo computes ctOut = the majority of ct0, ct1 and ct2.
+// Equivalent to (ct0 && ct1) || (ct1 && ct2) || (ct2 && ct0).
+//
+// This takes only one bootstrap, but has slightly higher failure probability
+// than two-input gates, since three ciphertexts are added.
+func (e *BinaryEvaluator[T]) MAJTo(ctOut, ct0, ct1, ct2 LWECiphertext[T]) {
+	for i := 0; i < e.Params.DefaultLWEDimension()+1; i++ {
+		ctOut.Value[i] = ct0.Value[i] + ct1.Value[i] + ct2.Value[i]
+	}
+
+	e.Evaluator.BootstrapLUTTo(ctOut, ctOut, e.signLUT)
+}
+
+// XOR3 returns ct0 XOR ct1 XOR ct2.
+// Equivalent to ct0 != ct1 != ct2.
+//
+// This takes only one bootstrap, but has slightly higher failure probability
+// than two-input gates, since three ciphertexts are added.
+func (e *BinaryEvaluator[T]) XOR3(ct0, ct1, ct2 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.XOR3To(ctOut, ct0, ct1, ct2)
+	return ctOut
+}
+
+// XOR3To computes ctOut = ct0 XOR ct1 XOR ct2.
+// Equivalent to ct0 != ct1 != ct2.
+//
+// This takes only one bootstrap, but has slightly higher failure probability
+// than two-input gates, since three ciphertexts are added.
+func (e *BinaryEvaluator[T]) XOR3To(ctOut, ct0, ct1, ct2 LWECiphertext[T]) {
+	for i := 0; i < e.Params.DefaultLWEDimension()+1; i++ {
+		ctOut.Value[i] = 2 * (-ct0.Value[i] - ct1.Value[i] - ct2.Value[i])
+	}
+
+	e.Evaluator.BootstrapLUTTo(ctOut, ctOut, e.signLUT)
+}
+
+// AND3 returns ct0 AND ct1 AND ct2.
+// Equivalent to ct0 && ct1 && ct2.
+//
+// This takes two bootstraps.
+func (e *BinaryEvaluator[T]) AND3(ct0, ct1, ct2 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.AND3To(ctOut, ct0, ct1, ct2)
+	return ctOut
+}
+
+// AND3To computes ctOut = ct0 AND ct1 AND ct2.
+// Equivalent to ct0 && ct1 && ct2.
+//
+// This takes two bootstraps.
+func (e *BinaryEvaluator[T]) AND3To(ctOut, ct0, ct1, ct2 LWECiphertext[T]) {
+	e.ANDTo(e.buf.ctGate[0], ct0, ct1)
+	e.ANDTo(ctOut, e.buf.ctGate[0], ct2)
+}
+
+// OR3 returns ct0 OR ct1 OR ct2.
+// Equivalent to ct0 || ct1 || ct2.
+//
+// This takes two bootstraps.
+func (e *BinaryEvaluator[T]) OR3(ct0, ct1, ct2 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.OR3To(ctOut, ct0, ct1, ct2)
+	return ctOut
+}
+
+// OR3To computes ctOut = ct0 OR ct1 OR ct2.
+// Equivalent to ct0 || ct1 || ct2.
+//
+// This takes two bootstraps.
+func (e *BinaryEvaluator[T]) OR3To(ctOut, ct0, ct1, ct2 LWECiphertext[T]) {
+	e.ORTo(e.buf.ctGate[0], ct0, ct1)
+	e.ORTo(ctOut, e.buf.ctGate[0], ct2)
+}
+
+// MUX returns ctSel ? ct0 : ct1.
+// Equivalent to (ctSel && ct0) || (!ctSel && ct1).
+//
+// This takes two blind rotations and one keyswitching.
+func (e *BinaryEvaluator[T]) MUX(ctSel, ct0, ct1 LWECiphertext[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.MUXTo(ctOut, ctSel, ct0, ct1)
+	return ctOut
+}
+
+// MUXTo computes ctOut = ctSel ? ct0 : ct1.
+// Equivalent to (ctSel && ct0) || (!ctSel && ct1).
+//
+// This takes two blind rotations and one keyswitching.
+func (e *BinaryEvaluator[T]) MUXTo(ctOut, ctSel, ct0, ct1 LWECiphertext[T]) {
+	for i := 0; i < e.Params.DefaultLWEDimension()+1; i++ {
+		e.buf.ctGate[0].Value[i] = ctSel.Value[i] + ct0.Value[i]
+		e.buf.ctGate[1].Value[i] = -ctSel.Value[i] + ct1.Value[i]
+	}
+	e.buf.ctGate[0].Value[0] -= 1 << (e.Params.logQ - 3)
+	e.buf.ctGate[1].Value[0] -= 1 << (e.Params.logQ - 3)
+
+	for j := 0; j < 2; j++ {
+		switch e.Params.bootstrapOrder {
+		case OrderKeySwitchBlindRotate:
+			e.Evaluator.BootstrapLUTTo(e.buf.ctExtract[j], e.buf.ctGate[j], e.signLUT)
+		case OrderBlindRotateKeySwitch:
+			e.Evaluator.BlindRotateTo(e.buf.ctRotate, e.buf.ctGate[j], e.signLUT)
+			e.buf.ctRotate.AsLWECiphertextTo(0, e.buf.ctExtract[j])
+		}
+	}
+
+	// Exactly one of ctSel AND ct0 and (NOT ctSel) AND ct1 can be true,
+	// so their sum plus 1/8 is a valid encryption of the result.
+	// We keyswitch after the addition to save one keyswitching.
+	for i := range e.buf.ctExtract[0].Value {
+		e.buf.ctExtract[0].Value[i] += e.buf.ctExtract[1].Value[i]
+	}
+	e.buf.ctExtract[0].Value[0] += 1 << (e.Params.logQ - 3)
+
+	switch e.Params.bootstrapOrder {
+	case OrderKeySwitchBlindRotate:
+		ctOut.CopyFrom(e.buf.ctExtract[0])
+	case OrderBlindRotateKeySwitch:
+		e.Evaluator.DefaultKeySwitchTo(ctOut, e.buf.ctExtract[0])
+	}
+}
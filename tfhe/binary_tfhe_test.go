@@ -68,6 +68,9 @@ func TestBinaryEvaluator(t *testing.T) {
 		{false, false, encBinary.EncryptLWEBool(false), encBinary.EncryptLWEBool(false)},
 	}
 
+	pt2s := []bool{true, false}
+	ct2s := []tfhe.LWECiphertext[uint32]{encBinary.EncryptLWEBool(true), encBinary.EncryptLWEBool(false)}
+
 	t.Run("AND", func(t *testing.T) {
 		for _, tc := range tests {
 			assert.Equal(t, tc.pt0 && tc.pt1, encBinary.DecryptLWEBool(evalBinary.AND(tc.ct0, tc.ct1)))
@@ -104,6 +107,75 @@ func TestBinaryEvaluator(t *testing.T) {
 		}
 	})
 
+	t.Run("ANDNY", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, !tc.pt0 && tc.pt1, encBinary.DecryptLWEBool(evalBinary.ANDNY(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ANDYN", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, tc.pt0 && !tc.pt1, encBinary.DecryptLWEBool(evalBinary.ANDYN(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ORNY", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, !tc.pt0 || tc.pt1, encBinary.DecryptLWEBool(evalBinary.ORNY(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("ORYN", func(t *testing.T) {
+		for _, tc := range tests {
+			assert.Equal(t, tc.pt0 || !tc.pt1, encBinary.DecryptLWEBool(evalBinary.ORYN(tc.ct0, tc.ct1)))
+		}
+	})
+
+	t.Run("MAJ", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, (tc.pt0 && tc.pt1) || (tc.pt1 && pt2) || (pt2 && tc.pt0), encBinary.DecryptLWEBool(evalBinary.MAJ(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("XOR3", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 != tc.pt1 != pt2, encBinary.DecryptLWEBool(evalBinary.XOR3(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("AND3", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 && tc.pt1 && pt2, encBinary.DecryptLWEBool(evalBinary.AND3(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("OR3", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, tc.pt0 || tc.pt1 || pt2, encBinary.DecryptLWEBool(evalBinary.OR3(tc.ct0, tc.ct1, ct2)))
+			}
+		}
+	})
+
+	t.Run("MUX", func(t *testing.T) {
+		for _, tc := range tests {
+			for i, pt2 := range pt2s {
+				ct2 := ct2s[i]
+				assert.Equal(t, (pt2 && tc.pt0) || (!pt2 && tc.pt1), encBinary.DecryptLWEBool(evalBinary.MUX(ct2, tc.ct0, tc.ct1)))
+			}
+		}
+	})
+
 	t.Run("Bits", func(t *testing.T) {
 		msg0, msg1 := 0b01, 0b10
 		ct0 := encBinary.EncryptLWEBits(msg0, 4)
@@ -117,6 +189,14 @@ func TestBinaryEvaluator(t *testing.T) {
 		assert.Equal(t, encBinary.DecryptLWEBits(ctOut), msg0^msg1)
 	})
 
+	t.Run("BitsSigned", func(t *testing.T) {
+		msg := -3
+		ct := encBinary.EncryptLWEBits(msg, 4)
+
+		assert.Equal(t, encBinary.DecryptLWEBitsSigned(ct), msg)
+		assert.Equal(t, encBinary.DecryptLWEBits(ct), msg&0b1111)
+	})
+
 	t.Run("BootstrapOriginal", func(t *testing.T) {
 		paramsBinaryOriginal := paramsBinary.Literal().WithBlockSize(1).Compile()
 
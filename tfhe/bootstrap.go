@@ -52,6 +52,8 @@ func (e *Evaluator[T]) BlindRotate(ct LWECiphertext[T], lut LookUpTable[T]) GLWE
 // BlindRotateTo computes the blind rotation of LWE ciphertext with respect to LUT, and writes it to ctOut.
 func (e *Evaluator[T]) BlindRotateTo(ctOut GLWECiphertext[T], ct LWECiphertext[T], lut LookUpTable[T]) {
 	switch {
+	case e.Params.groupingFactor > 1:
+		e.blindRotateMultiBitTo(ctOut, ct, lut)
 	case e.Params.lutSize > e.Params.polyRank:
 		e.blindRotateExtendedTo(ctOut, ct, lut)
 	case e.Params.blockSize > 1:
@@ -316,6 +318,81 @@ func (e *Evaluator[T]) blindRotateBlockTo(ctOut GLWECiphertext[T], ct LWECiphert
 	}
 }
 
+// blindRotateMultiBitTo computes the blind rotation when GroupingFactor > 1.
+// For each group of key bits, the accumulator is multiplied by
+// 1 + sum_c (X^(-<a, c>) - 1) * GGSW(1[s = c]) for all nonzero combinations c.
+// The sum is computed in the Fourier domain by [Evaluator.multiBitGGSWTo],
+// so only one external product is needed per group.
+func (e *Evaluator[T]) blindRotateMultiBitTo(ctOut GLWECiphertext[T], ct LWECiphertext[T], lut LookUpTable[T]) {
+	pDcmp := e.Decomposer.buf.pDcmp[:e.Params.blindRotateParams.level]
+
+	e.PolyEvaluator.MonomialMulPolyTo(ctOut.Value[0], lut.Value[0], -e.ModSwitch(ct.Value[0]))
+	for i := 1; i < e.Params.glweRank+1; i++ {
+		ctOut.Value[i].Clear()
+	}
+
+	e.Decomposer.DecomposePolyTo(pDcmp, ctOut.Value[0], e.Params.blindRotateParams)
+	for k := 0; k < e.Params.blindRotateParams.level; k++ {
+		e.PolyEvaluator.FwdFFTTo(e.buf.ctAccFFTDcmp[0][0][k], pDcmp[k])
+	}
+
+	e.multiBitGGSWTo(e.buf.ctFFTGGSWGroup, ct, 0, 1)
+	e.GadgetProdFFTGLWETo(e.buf.ctFFTAcc[0], e.buf.ctFFTGGSWGroup.Value[0], e.buf.ctAccFFTDcmp[0][0])
+
+	for j := 0; j < e.Params.glweRank+1; j++ {
+		e.PolyEvaluator.InvFFTAddToUnsafe(ctOut.Value[j], e.buf.ctFFTAcc[0].Value[j])
+	}
+
+	for i := 1; i < e.Params.groupCount; i++ {
+		for j := 0; j < e.Params.glweRank+1; j++ {
+			e.Decomposer.DecomposePolyTo(pDcmp, ctOut.Value[j], e.Params.blindRotateParams)
+			for k := 0; k < e.Params.blindRotateParams.level; k++ {
+				e.PolyEvaluator.FwdFFTTo(e.buf.ctAccFFTDcmp[0][j][k], pDcmp[k])
+			}
+		}
+
+		e.multiBitGGSWTo(e.buf.ctFFTGGSWGroup, ct, i, e.Params.glweRank+1)
+		e.ExternalProdFFTGLWETo(e.buf.ctFFTAcc[0], e.buf.ctFFTGGSWGroup, e.buf.ctAccFFTDcmp[0])
+
+		for j := 0; j < e.Params.glweRank+1; j++ {
+			e.PolyEvaluator.InvFFTAddToUnsafe(ctOut.Value[j], e.buf.ctFFTAcc[0].Value[j])
+		}
+	}
+}
+
+// multiBitGGSWTo computes sum_c (X^(-<a, c>) - 1) * GGSW(1[s = c]) of the i-th group
+// and writes it to ctOut.
+// Only the first rowCount GLev ciphertexts of ctOut are written.
+func (e *Evaluator[T]) multiBitGGSWTo(ctOut FFTGGSWCiphertext[T], ct LWECiphertext[T], i, rowCount int) {
+	combCount := 1<<e.Params.groupingFactor - 1
+
+	for c := 1; c <= combCount; c++ {
+		e.PolyEvaluator.MonomialSubOneFwdFFTTo(e.buf.fMono, -e.modSwitchComb(ct, i, c))
+		brk := e.EvalKey.BlindRotateKey.Value[i*combCount+c-1]
+		for j := 0; j < rowCount; j++ {
+			for k := 0; k < e.Params.blindRotateParams.level; k++ {
+				if c == 1 {
+					e.FFTPolyMulFFTGLWETo(ctOut.Value[j].Value[k], brk.Value[j].Value[k], e.buf.fMono)
+				} else {
+					e.FFTPolyMulAddFFTGLWETo(ctOut.Value[j].Value[k], brk.Value[j].Value[k], e.buf.fMono)
+				}
+			}
+		}
+	}
+}
+
+// modSwitchComb returns the sum of modulus switched LWE masks of the i-th group,
+// for which the corresponding bit of c is set.
+func (e *Evaluator[T]) modSwitchComb(ct LWECiphertext[T], i, c int) int {
+	a2N := 0
+	for j := 0; j < e.Params.groupingFactor; j++ {
+		if c>>j&1 == 1 {
+			a2N += e.ModSwitch(ct.Value[i*e.Params.groupingFactor+j+1])
+		}
+	}
+	return a2N
+}
+
 // blindRotateOriginalTo computes the blind rotation when PolyRank = LUTSize and BlockSize = 1.
 // This is equivalent to the original blind rotation algorithm.
 func (e *Evaluator[T]) blindRotateOriginalTo(ctOut GLWECiphertext[T], ct LWECiphertext[T], lut LookUpTable[T]) {
@@ -0,0 +1,104 @@
+package tfhe
+
+import (
+	"runtime"
+
+	"github.com/sp301415/tfhe-go/internal/parallel"
+)
+
+// BatchWorkerCount returns the number of workers used in batch bootstrapping,
+// such as [Evaluator.BootstrapLUTBatch].
+func (e *Evaluator[T]) BatchWorkerCount() int {
+	if e.batchWorkerCount == 0 {
+		return runtime.NumCPU()
+	}
+	return e.batchWorkerCount
+}
+
+// SetBatchWorkerCount sets the number of workers used in batch bootstrapping,
+// such as [Evaluator.BootstrapLUTBatch].
+// By default, runtime.NumCPU() workers are used.
+//
+// Panics if workerCount is not positive.
+func (e *Evaluator[T]) SetBatchWorkerCount(workerCount int) {
+	if workerCount <= 0 {
+		panic("Worker count not positive")
+	}
+	e.batchWorkerCount = workerCount
+}
+
+// BootstrapFuncBatch returns bootstrapped LWE ciphertexts with respect to the given functions in parallel.
+// If len(f) == 1, the same function is used for all ciphertexts.
+// Otherwise, ct[i] is bootstrapped with respect to f[i].
+//
+// Panics if len(f) != 1 and len(f) != len(ct).
+func (e *Evaluator[T]) BootstrapFuncBatch(ct []LWECiphertext[T], f []func(int) int) []LWECiphertext[T] {
+	ctOut := make([]LWECiphertext[T], len(ct))
+	for i := range ctOut {
+		ctOut[i] = NewLWECiphertext(e.Params)
+	}
+	e.BootstrapFuncBatchTo(ctOut, ct, f)
+	return ctOut
+}
+
+// BootstrapFuncBatchTo bootstraps LWE ciphertexts with respect to the given functions and writes them to ctOut in parallel.
+// If len(f) == 1, the same function is used for all ciphertexts.
+// Otherwise, ct[i] is bootstrapped with respect to f[i].
+//
+// Panics if len(f) != 1 and len(f) != len(ct), or len(ctOut) != len(ct).
+func (e *Evaluator[T]) BootstrapFuncBatchTo(ctOut, ct []LWECiphertext[T], f []func(int) int) {
+	if len(f) == 1 {
+		e.GenLUTTo(e.buf.lut, f[0])
+		e.BootstrapLUTBatchTo(ctOut, ct, []LookUpTable[T]{e.buf.lut})
+		return
+	}
+
+	if len(f) != len(ct) {
+		panic("Function count mismatch")
+	}
+	if len(ctOut) != len(ct) {
+		panic("Ciphertext count mismatch")
+	}
+
+	pool := parallel.Workers(&e.batchPool, e.BatchWorkerCount(), len(ct), e.SafeCopy)
+	parallel.Run(len(pool), len(ct), func(worker, i int) {
+		pool[worker].BootstrapFuncTo(ctOut[i], ct[i], f[i])
+	})
+}
+
+// BootstrapLUTBatch returns bootstrapped LWE ciphertexts with respect to the given LUTs in parallel.
+// If len(lut) == 1, the same LUT is used for all ciphertexts.
+// Otherwise, ct[i] is bootstrapped with respect to lut[i].
+//
+// Panics if len(lut) != 1 and len(lut) != len(ct).
+func (e *Evaluator[T]) BootstrapLUTBatch(ct []LWECiphertext[T], lut []LookUpTable[T]) []LWECiphertext[T] {
+	ctOut := make([]LWECiphertext[T], len(ct))
+	for i := range ctOut {
+		ctOut[i] = NewLWECiphertext(e.Params)
+	}
+	e.BootstrapLUTBatchTo(ctOut, ct, lut)
+	return ctOut
+}
+
+// BootstrapLUTBatchTo bootstraps LWE ciphertexts with respect to the given LUTs and writes them to ctOut in parallel.
+// If len(lut) == 1, the same LUT is used for all ciphertexts.
+// Otherwise, ct[i] is bootstrapped with respect to lut[i].
+//
+// Panics if len(lut) != 1 and len(lut) != len(ct), or len(ctOut) != len(ct).
+func (e *Evaluator[T]) BootstrapLUTBatchTo(ctOut, ct []LWECiphertext[T], lut []LookUpTable[T]) {
+	if len(lut) != 1 && len(lut) != len(ct) {
+		panic("LUT count mismatch")
+	}
+	if len(ctOut) != len(ct) {
+		panic("Ciphertext count mismatch")
+	}
+
+	pool := parallel.Workers(&e.batchPool, e.BatchWorkerCount(), len(ct), e.SafeCopy)
+	parallel.Run(len(pool), len(ct), func(worker, i int) {
+		if len(lut) == 1 {
+			pool[worker].BootstrapLUTTo(ctOut[i], ct[i], lut[0])
+		} else {
+			pool[worker].BootstrapLUTTo(ctOut[i], ct[i], lut[i])
+		}
+	})
+}
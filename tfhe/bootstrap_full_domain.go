@@ -0,0 +1,123 @@
+package tfhe
+
+// FullDomainLookUpTable is a lookup table for full-domain programmable bootstrapping,
+// which evaluates arbitrary functions on messages in [0, 2*MessageModulus)
+// without reserving a padding bit.
+//
+// Let p = MessageModulus. A usual LUT evaluated on such a ciphertext
+// gives f(x) for x < p, and -f(x - p) otherwise.
+// Full-domain bootstrapping combines two such evaluations:
+//
+//   - Diff, evaluated on the input ciphertext, encodes (f(x) - f(x + p)) / 2.
+//   - Sum, evaluated on the input ciphertext shifted to [p, 2p), encodes -(f(x) + f(x + p)) / 2.
+//
+// The shift is computed with an additional bootstrapping extracting the most significant bit,
+// so full-domain bootstrapping requires three bootstrappings.
+type FullDomainLookUpTable[T TorusInt] struct {
+	// Diff is the LUT evaluated on the input ciphertext.
+	Diff LookUpTable[T]
+	// Sum is the LUT evaluated on the shifted ciphertext.
+	Sum LookUpTable[T]
+}
+
+// NewFullDomainLUT creates a new [FullDomainLookUpTable].
+func NewFullDomainLUT[T TorusInt](params Parameters[T]) FullDomainLookUpTable[T] {
+	return FullDomainLookUpTable[T]{
+		Diff: NewLUT(params),
+		Sum:  NewLUT(params),
+	}
+}
+
+// Copy returns a copy of the LUT.
+func (lut FullDomainLookUpTable[T]) Copy() FullDomainLookUpTable[T] {
+	return FullDomainLookUpTable[T]{
+		Diff: lut.Diff.Copy(),
+		Sum:  lut.Sum.Copy(),
+	}
+}
+
+// CopyFrom copies values from the LUT.
+func (lut *FullDomainLookUpTable[T]) CopyFrom(lutIn FullDomainLookUpTable[T]) {
+	lut.Diff.CopyFrom(lutIn.Diff)
+	lut.Sum.CopyFrom(lutIn.Sum)
+}
+
+// Clear clears the LUT.
+func (lut *FullDomainLookUpTable[T]) Clear() {
+	lut.Diff.Clear()
+	lut.Sum.Clear()
+}
+
+// GenFullDomainLUT generates a full-domain lookup table based on function f.
+// Input and output of f is cut by 2 * MessageModulus.
+func (e *Evaluator[T]) GenFullDomainLUT(f func(int) int) FullDomainLookUpTable[T] {
+	lutOut := NewFullDomainLUT(e.Params)
+	e.GenFullDomainLUTTo(lutOut, f)
+	return lutOut
+}
+
+// GenFullDomainLUTTo generates a full-domain lookup table based on function f and writes it to lutOut.
+// Input and output of f is cut by 2 * MessageModulus.
+func (e *Evaluator[T]) GenFullDomainLUTTo(lutOut FullDomainLookUpTable[T], f func(int) int) {
+	p := e.Params.messageModulus
+	halfScale := e.Params.scale / 2
+
+	e.GenLUTCustomFullTo(lutOut.Diff, func(x int) T {
+		return e.EncodeLWECustom(f(x), 2*p, halfScale).Value - e.EncodeLWECustom(f(x+int(p)), 2*p, halfScale).Value
+	}, p)
+	e.GenLUTCustomFullTo(lutOut.Sum, func(x int) T {
+		return -e.EncodeLWECustom(f(x), 2*p, halfScale).Value - e.EncodeLWECustom(f(x+int(p)), 2*p, halfScale).Value
+	}, p)
+}
+
+// BootstrapFullDomainFunc returns a bootstrapped LWE ciphertext with respect to the given function,
+// using full-domain bootstrapping.
+// Input and output ciphertexts are encoded by [Encoder.EncodeLWEFullDomain].
+func (e *Evaluator[T]) BootstrapFullDomainFunc(ct LWECiphertext[T], f func(int) int) LWECiphertext[T] {
+	e.GenFullDomainLUTTo(e.buf.lutFullDomain, f)
+	return e.BootstrapFullDomainLUT(ct, e.buf.lutFullDomain)
+}
+
+// BootstrapFullDomainFuncTo bootstraps LWE ciphertext with respect to the given function and writes it to ctOut,
+// using full-domain bootstrapping.
+// Input and output ciphertexts are encoded by [Encoder.EncodeLWEFullDomain].
+func (e *Evaluator[T]) BootstrapFullDomainFuncTo(ctOut, ct LWECiphertext[T], f func(int) int) {
+	e.GenFullDomainLUTTo(e.buf.lutFullDomain, f)
+	e.BootstrapFullDomainLUTTo(ctOut, ct, e.buf.lutFullDomain)
+}
+
+// BootstrapFullDomainLUT returns a bootstrapped LWE ciphertext with respect to the given full-domain LUT.
+// Input and output ciphertexts are encoded by [Encoder.EncodeLWEFullDomain].
+func (e *Evaluator[T]) BootstrapFullDomainLUT(ct LWECiphertext[T], lut FullDomainLookUpTable[T]) LWECiphertext[T] {
+	ctOut := NewLWECiphertext(e.Params)
+	e.BootstrapFullDomainLUTTo(ctOut, ct, lut)
+	return ctOut
+}
+
+// BootstrapFullDomainLUTTo bootstraps LWE ciphertext with respect to the given full-domain LUT and writes it to ctOut.
+// Input and output ciphertexts are encoded by [Encoder.EncodeLWEFullDomain].
+func (e *Evaluator[T]) BootstrapFullDomainLUTTo(ctOut, ct LWECiphertext[T], lut FullDomainLookUpTable[T]) {
+	e.BootstrapLUTTo(e.buf.ctSign, ct, e.lutSign)
+	e.buf.ctSign.Value[0] += 1 << (e.Params.logQ - 2)
+	e.AddLWETo(e.buf.ctSign, ct, e.buf.ctSign)
+
+	e.BootstrapLUTTo(e.buf.ctSign, e.buf.ctSign, lut.Sum)
+	e.BootstrapLUTTo(ctOut, ct, lut.Diff)
+	e.AddLWETo(ctOut, ctOut, e.buf.ctSign)
+}
+
+// GenSignLUT generates a lookup table used in full-domain bootstrapping.
+// Bootstrapping a full-domain ciphertext with this LUT gives Q/4 if the message is smaller than MessageModulus,
+// and -Q/4 otherwise.
+func (e *Evaluator[T]) GenSignLUT() LookUpTable[T] {
+	lutOut := NewLUT(e.Params)
+	e.GenSignLUTTo(lutOut)
+	return lutOut
+}
+
+// GenSignLUTTo generates a lookup table used in full-domain bootstrapping and writes it to lutOut.
+// Bootstrapping a full-domain ciphertext with this LUT gives Q/4 if the message is smaller than MessageModulus,
+// and -Q/4 otherwise.
+func (e *Evaluator[T]) GenSignLUTTo(lutOut LookUpTable[T]) {
+	e.GenLUTFullTo(lutOut, func(int) T { return 1 << (e.Params.logQ - 2) })
+}
@@ -50,23 +50,29 @@ func (evk *EvaluationKey[T]) Clear() {
 // BlindRotateKey is a key for blind rotation.
 // Essentially, this is a GGSW encryption of LWEKey with GLWEKey.
 // However, FFT is already applied for fast external product.
+//
+// In multi-bit blind rotation, Value[i*(2^GroupingFactor-1)+c-1] for 0 < c < 2^GroupingFactor
+// is a GGSW encryption of 1 if LWEKey[i*GroupingFactor+j] equals the j-th bit of c for all j,
+// and 0 otherwise.
 type BlindRotateKey[T TorusInt] struct {
 	GadgetParams GadgetParameters[T]
 
-	// Value has length LWEDimension.
+	// Value has length BlindRotateKeyCount,
+	// which is LWEDimension if GroupingFactor is 1.
 	Value []FFTGGSWCiphertext[T]
 }
 
 // NewBlindRotateKey creates a new [BlindRotateKey].
 func NewBlindRotateKey[T TorusInt](params Parameters[T]) BlindRotateKey[T] {
-	brk := make([]FFTGGSWCiphertext[T], params.lweDimension)
-	for i := 0; i < params.lweDimension; i++ {
+	brk := make([]FFTGGSWCiphertext[T], params.BlindRotateKeyCount())
+	for i := range brk {
 		brk[i] = NewFFTGGSWCiphertext(params, params.blindRotateParams)
 	}
 	return BlindRotateKey[T]{Value: brk, GadgetParams: params.blindRotateParams}
 }
 
 // NewBlindRotateKeyCustom creates a new [BlindRotateKey] with custom parameters.
+// For multi-bit blind rotation, lweDimension should be the number of GGSW ciphertexts in the key.
 func NewBlindRotateKeyCustom[T TorusInt](lweDimension, glweRank, polyRank int, gadgetParams GadgetParameters[T]) BlindRotateKey[T] {
 	brk := make([]FFTGGSWCiphertext[T], lweDimension)
 	for i := 0; i < lweDimension; i++ {
@@ -34,13 +34,14 @@ func (e *Encryptor[T]) GenEvalKeyParallel() EvaluationKey[T] {
 func (e *Encryptor[T]) GenBlindRotateKey() BlindRotateKey[T] {
 	brk := NewBlindRotateKey(e.Params)
 
-	for i := 0; i < e.Params.lweDimension; i++ {
+	for i := 0; i < len(brk.Value); i++ {
+		m := e.blindRotateKeyMessage(i)
 		for j := 0; j < e.Params.glweRank+1; j++ {
 			if j == 0 {
 				e.buf.ptGGSW.Clear()
-				e.buf.ptGGSW.Coeffs[0] = e.SecretKey.LWEKey.Value[i]
+				e.buf.ptGGSW.Coeffs[0] = m
 			} else {
-				e.PolyEvaluator.ScalarMulPolyTo(e.buf.ptGGSW, e.SecretKey.GLWEKey.Value[j-1], e.SecretKey.LWEKey.Value[i])
+				e.PolyEvaluator.ScalarMulPolyTo(e.buf.ptGGSW, e.SecretKey.GLWEKey.Value[j-1], m)
 			}
 			for k := 0; k < e.Params.blindRotateParams.level; k++ {
 				e.PolyEvaluator.ScalarMulPolyTo(e.buf.ctGLWE.Value[0], e.buf.ptGGSW, e.Params.blindRotateParams.BaseQ(k))
@@ -57,7 +58,7 @@ func (e *Encryptor[T]) GenBlindRotateKey() BlindRotateKey[T] {
 func (e *Encryptor[T]) GenBlindRotateKeyParallel() BlindRotateKey[T] {
 	brk := NewBlindRotateKey(e.Params)
 
-	workSize := e.Params.lweDimension * (e.Params.glweRank + 1)
+	workSize := len(brk.Value) * (e.Params.glweRank + 1)
 	chunkCount := num.Min(runtime.NumCPU(), num.Sqrt(workSize))
 
 	encryptorPool := make([]*Encryptor[T], chunkCount)
@@ -68,7 +69,7 @@ func (e *Encryptor[T]) GenBlindRotateKeyParallel() BlindRotateKey[T] {
 	jobs := make(chan [2]int)
 	go func() {
 		defer close(jobs)
-		for i := 0; i < e.Params.lweDimension; i++ {
+		for i := 0; i < len(brk.Value); i++ {
 			for j := 0; j < e.Params.glweRank+1; j++ {
 				jobs <- [2]int{i, j}
 			}
@@ -83,11 +84,12 @@ func (e *Encryptor[T]) GenBlindRotateKeyParallel() BlindRotateKey[T] {
 			for job := range jobs {
 				i, j := job[0], job[1]
 
+				m := eIdx.blindRotateKeyMessage(i)
 				if j == 0 {
 					eIdx.buf.ptGGSW.Clear()
-					eIdx.buf.ptGGSW.Coeffs[0] = eIdx.SecretKey.LWEKey.Value[i]
+					eIdx.buf.ptGGSW.Coeffs[0] = m
 				} else {
-					eIdx.PolyEvaluator.ScalarMulPolyTo(eIdx.buf.ptGGSW, eIdx.SecretKey.GLWEKey.Value[j-1], eIdx.SecretKey.LWEKey.Value[i])
+					eIdx.PolyEvaluator.ScalarMulPolyTo(eIdx.buf.ptGGSW, eIdx.SecretKey.GLWEKey.Value[j-1], m)
 				}
 				for k := 0; k < eIdx.Params.blindRotateParams.level; k++ {
 					eIdx.PolyEvaluator.ScalarMulPolyTo(eIdx.buf.ctGLWE.Value[0], eIdx.buf.ptGGSW, eIdx.Params.blindRotateParams.BaseQ(k))
@@ -103,6 +105,29 @@ func (e *Encryptor[T]) GenBlindRotateKeyParallel() BlindRotateKey[T] {
 	return brk
 }
 
+// blindRotateKeyMessage returns the message encrypted in the i-th GGSW ciphertext of the blind rotation key.
+// If GroupingFactor is 1, this is LWEKey[i].
+// Otherwise, this is 1 if the key bits of the group match the combination of i, and 0 otherwise.
+func (e *Encryptor[T]) blindRotateKeyMessage(i int) T {
+	if e.Params.groupingFactor == 1 {
+		return e.SecretKey.LWEKey.Value[i]
+	}
+
+	combCount := 1<<e.Params.groupingFactor - 1
+	group, comb := i/combCount, i%combCount+1
+
+	var m T = 1
+	for j := 0; j < e.Params.groupingFactor; j++ {
+		s := e.SecretKey.LWEKey.Value[group*e.Params.groupingFactor+j]
+		if comb>>j&1 == 1 {
+			m *= s
+		} else {
+			m *= 1 - s
+		}
+	}
+	return m
+}
+
 // GenDefaultKeySwitchKey samples a new keyswitch key LWELargeKey -> LWEKey,
 // used for bootstrapping.
 //
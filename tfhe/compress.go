@@ -0,0 +1,191 @@
+package tfhe
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/math/poly"
+	"github.com/sp301415/tfhe-go/math/vec"
+)
+
+// CompressedLWECiphertext is an LWE ciphertext modulus switched from Q to 2^LogModulus,
+// which is used to reduce the size of ciphertexts for transport.
+// When serialized, each element takes only LogModulus bits.
+//
+// Modulus switching adds a rounding error,
+// so LogModulus should be large enough to keep the message intact.
+// Use [CompressedLWECiphertext.Decompress] to switch the modulus back to Q.
+type CompressedLWECiphertext[T TorusInt] struct {
+	// LogModulus is the log of the modulus of this ciphertext.
+	LogModulus int
+
+	// Value is ordered as [body, mask], same as LWECiphertext.
+	// Each element is in [0, 2^LogModulus).
+	Value []T
+}
+
+// NewCompressedLWECiphertext creates a new [CompressedLWECiphertext].
+//
+// Panics if logModulus is not in [1, LogQ].
+func NewCompressedLWECiphertext[T TorusInt](params Parameters[T], logModulus int) CompressedLWECiphertext[T] {
+	return NewCompressedLWECiphertextCustom[T](params.DefaultLWEDimension(), logModulus)
+}
+
+// NewCompressedLWECiphertextCustom creates a new [CompressedLWECiphertext] with given dimension.
+//
+// Panics if logModulus is not in [1, LogQ].
+func NewCompressedLWECiphertextCustom[T TorusInt](lweDimension, logModulus int) CompressedLWECiphertext[T] {
+	checkLogModulus[T](logModulus)
+	return CompressedLWECiphertext[T]{LogModulus: logModulus, Value: make([]T, lweDimension+1)}
+}
+
+// Copy returns a copy of the ciphertext.
+func (ct CompressedLWECiphertext[T]) Copy() CompressedLWECiphertext[T] {
+	return CompressedLWECiphertext[T]{LogModulus: ct.LogModulus, Value: vec.Copy(ct.Value)}
+}
+
+// CopyFrom copies values from the ciphertext.
+func (ct *CompressedLWECiphertext[T]) CopyFrom(ctIn CompressedLWECiphertext[T]) {
+	copy(ct.Value, ctIn.Value)
+	ct.LogModulus = ctIn.LogModulus
+}
+
+// Clear clears the ciphertext.
+func (ct *CompressedLWECiphertext[T]) Clear() {
+	vec.Fill(ct.Value, 0)
+}
+
+// Decompress returns the LWE ciphertext switched back to modulus Q.
+func (ct CompressedLWECiphertext[T]) Decompress() LWECiphertext[T] {
+	ctOut := NewLWECiphertextCustom[T](len(ct.Value) - 1)
+	ct.DecompressTo(ctOut)
+	return ctOut
+}
+
+// DecompressTo switches the modulus of the ciphertext back to Q and writes it to ctOut.
+func (ct CompressedLWECiphertext[T]) DecompressTo(ctOut LWECiphertext[T]) {
+	vec.ScalarMulTo(ctOut.Value, ct.Value, T(1)<<(num.SizeT[T]()-ct.LogModulus))
+}
+
+// CompressedGLWECiphertext is a GLWE ciphertext modulus switched from Q to 2^LogModulus,
+// which is used to reduce the size of ciphertexts for transport.
+// When serialized, each coefficient takes only LogModulus bits.
+//
+// Modulus switching adds a rounding error,
+// so LogModulus should be large enough to keep the message intact.
+// Use [CompressedGLWECiphertext.Decompress] to switch the modulus back to Q.
+type CompressedGLWECiphertext[T TorusInt] struct {
+	// LogModulus is the log of the modulus of this ciphertext.
+	LogModulus int
+
+	// Value is ordered as [body, mask], same as GLWECiphertext.
+	// Each coefficient is in [0, 2^LogModulus).
+	Value []poly.Poly[T]
+}
+
+// NewCompressedGLWECiphertext creates a new [CompressedGLWECiphertext].
+//
+// Panics if logModulus is not in [1, LogQ].
+func NewCompressedGLWECiphertext[T TorusInt](params Parameters[T], logModulus int) CompressedGLWECiphertext[T] {
+	return NewCompressedGLWECiphertextCustom[T](params.glweRank, params.polyRank, logModulus)
+}
+
+// NewCompressedGLWECiphertextCustom creates a new [CompressedGLWECiphertext] with given dimension and polyRank.
+//
+// Panics if logModulus is not in [1, LogQ].
+func NewCompressedGLWECiphertextCustom[T TorusInt](glweRank, polyRank, logModulus int) CompressedGLWECiphertext[T] {
+	checkLogModulus[T](logModulus)
+	ct := make([]poly.Poly[T], glweRank+1)
+	for i := 0; i < glweRank+1; i++ {
+		ct[i] = poly.NewPoly[T](polyRank)
+	}
+	return CompressedGLWECiphertext[T]{LogModulus: logModulus, Value: ct}
+}
+
+// Copy returns a copy of the ciphertext.
+func (ct CompressedGLWECiphertext[T]) Copy() CompressedGLWECiphertext[T] {
+	ctCopy := make([]poly.Poly[T], len(ct.Value))
+	for i := range ct.Value {
+		ctCopy[i] = ct.Value[i].Copy()
+	}
+	return CompressedGLWECiphertext[T]{LogModulus: ct.LogModulus, Value: ctCopy}
+}
+
+// CopyFrom copies values from the ciphertext.
+func (ct *CompressedGLWECiphertext[T]) CopyFrom(ctIn CompressedGLWECiphertext[T]) {
+	for i := range ct.Value {
+		ct.Value[i].CopyFrom(ctIn.Value[i])
+	}
+	ct.LogModulus = ctIn.LogModulus
+}
+
+// Clear clears the ciphertext.
+func (ct *CompressedGLWECiphertext[T]) Clear() {
+	for i := range ct.Value {
+		ct.Value[i].Clear()
+	}
+}
+
+// Decompress returns the GLWE ciphertext switched back to modulus Q.
+func (ct CompressedGLWECiphertext[T]) Decompress() GLWECiphertext[T] {
+	ctOut := NewGLWECiphertextCustom[T](len(ct.Value)-1, ct.Value[0].Rank())
+	ct.DecompressTo(ctOut)
+	return ctOut
+}
+
+// DecompressTo switches the modulus of the ciphertext back to Q and writes it to ctOut.
+func (ct CompressedGLWECiphertext[T]) DecompressTo(ctOut GLWECiphertext[T]) {
+	for i := range ct.Value {
+		vec.ScalarMulTo(ctOut.Value[i].Coeffs, ct.Value[i].Coeffs, T(1)<<(num.SizeT[T]()-ct.LogModulus))
+	}
+}
+
+// CompressLWE switches the modulus of ct from Q to 2^logModulus.
+// Each coefficient is rounded to the nearest multiple of Q / 2^logModulus,
+// so the rounding error is at most half a step of the new modulus,
+// and it adds to the noise of the ciphertext.
+//
+// Panics if logModulus is not in [1, LogQ].
+func (e *Evaluator[T]) CompressLWE(ct LWECiphertext[T], logModulus int) CompressedLWECiphertext[T] {
+	ctOut := NewCompressedLWECiphertextCustom[T](len(ct.Value)-1, logModulus)
+	e.CompressLWETo(ctOut, ct)
+	return ctOut
+}
+
+// CompressLWETo switches the modulus of ct from Q to 2^ctOut.LogModulus and writes it to ctOut.
+func (e *Evaluator[T]) CompressLWETo(ctOut CompressedLWECiphertext[T], ct LWECiphertext[T]) {
+	for i := range ct.Value {
+		ctOut.Value[i] = compressTorus(ct.Value[i], ctOut.LogModulus)
+	}
+}
+
+// CompressGLWE switches the modulus of ct from Q to 2^logModulus.
+// Each coefficient is rounded to the nearest multiple of Q / 2^logModulus,
+// so the rounding error is at most half a step of the new modulus,
+// and it adds to the noise of the ciphertext.
+//
+// Panics if logModulus is not in [1, LogQ].
+func (e *Evaluator[T]) CompressGLWE(ct GLWECiphertext[T], logModulus int) CompressedGLWECiphertext[T] {
+	ctOut := NewCompressedGLWECiphertextCustom[T](len(ct.Value)-1, ct.Value[0].Rank(), logModulus)
+	e.CompressGLWETo(ctOut, ct)
+	return ctOut
+}
+
+// CompressGLWETo switches the modulus of ct from Q to 2^ctOut.LogModulus and writes it to ctOut.
+func (e *Evaluator[T]) CompressGLWETo(ctOut CompressedGLWECiphertext[T], ct GLWECiphertext[T]) {
+	for i := range ct.Value {
+		for j := range ct.Value[i].Coeffs {
+			ctOut.Value[i].Coeffs[j] = compressTorus(ct.Value[i].Coeffs[j], ctOut.LogModulus)
+		}
+	}
+}
+
+// compressTorus switches the modulus of x from Q to 2^logModulus.
+func compressTorus[T TorusInt](x T, logModulus int) T {
+	return num.DivRoundBits(x, num.SizeT[T]()-logModulus) & (T(1)<<logModulus - 1)
+}
+
+// checkLogModulus panics if logModulus is not in [1, LogQ].
+func checkLogModulus[T TorusInt](logModulus int) {
+	if logModulus < 1 || logModulus > num.SizeT[T]() {
+		panic("LogModulus out of range")
+	}
+}
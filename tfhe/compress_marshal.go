@@ -0,0 +1,367 @@
+package tfhe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/sp301415/tfhe-go/math/num"
+)
+
+// packedByteSize returns the number of bytes needed to pack count elements of bits bits.
+func packedByteSize(count, bits int) int {
+	return (count*bits + 7) / 8
+}
+
+// packBitsTo packs the lower bits bits of each element of v to buf, from the most significant bit.
+// Assumes the length of buf is exactly packedByteSize(len(v), bits).
+func packBitsTo[T TorusInt](buf []byte, v []T, bits int) {
+	var acc uint64
+	var accBits, idx int
+	for _, x := range v {
+		for rem := bits; rem > 0; {
+			chunk := num.Min(rem, 8)
+			rem -= chunk
+			acc = acc<<chunk | uint64(x>>rem)&(1<<chunk-1)
+			accBits += chunk
+			if accBits >= 8 {
+				accBits -= 8
+				buf[idx] = byte(acc >> accBits)
+				idx++
+			}
+		}
+	}
+	if accBits > 0 {
+		buf[idx] = byte(acc << (8 - accBits))
+	}
+}
+
+// unpackBitsTo unpacks buf packed by packBitsTo to v.
+// Assumes the length of buf is exactly packedByteSize(len(v), bits).
+func unpackBitsTo[T TorusInt](v []T, buf []byte, bits int) {
+	var acc uint64
+	var accBits, idx int
+	for i := range v {
+		var x uint64
+		for rem := bits; rem > 0; {
+			if accBits == 0 {
+				acc = uint64(buf[idx])
+				accBits = 8
+				idx++
+			}
+			chunk := num.Min(rem, accBits)
+			rem -= chunk
+			accBits -= chunk
+			x = x<<chunk | (acc>>accBits)&(1<<chunk-1)
+		}
+		v[i] = T(x)
+	}
+}
+
+// ByteSize returns the size of the ciphertext in bytes.
+func (ct CompressedLWECiphertext[T]) ByteSize() int {
+	return 16 + packedByteSize(len(ct.Value), ct.LogModulus)
+}
+
+// headerWriteTo writes the header.
+func (ct CompressedLWECiphertext[T]) headerWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var buf [8]byte
+
+	logModulus := ct.LogModulus
+	binary.BigEndian.PutUint64(buf[:], uint64(logModulus))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	lweDimension := len(ct.Value) - 1
+	binary.BigEndian.PutUint64(buf[:], uint64(lweDimension))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// valueWriteTo writes the value.
+func (ct CompressedLWECiphertext[T]) valueWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+
+	buf := make([]byte, packedByteSize(len(ct.Value), ct.LogModulus))
+	packBitsTo(buf, ct.Value, ct.LogModulus)
+	if nWrite, err = w.Write(buf); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] LogModulus
+//	[8] LWEDimension
+//	    Value, packed to LogModulus bits per element
+func (ct CompressedLWECiphertext[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = ct.headerWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = ct.valueWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(ct.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// headerReadFrom reads the header, and initializes the value.
+func (ct *CompressedLWECiphertext[T]) headerReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	logModulus := int(binary.BigEndian.Uint64(buf[:]))
+	if logModulus < 1 || logModulus > num.SizeT[T]() {
+		return n, fmt.Errorf("LogModulus %d out of range", logModulus)
+	}
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	lweDimension := int(binary.BigEndian.Uint64(buf[:]))
+
+	*ct = NewCompressedLWECiphertextCustom[T](lweDimension, logModulus)
+
+	return
+}
+
+// valueReadFrom reads the value.
+func (ct *CompressedLWECiphertext[T]) valueReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+
+	buf := make([]byte, packedByteSize(len(ct.Value), ct.LogModulus))
+	if nRead, err = io.ReadFull(r, buf); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	unpackBitsTo(ct.Value, buf, ct.LogModulus)
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (ct *CompressedLWECiphertext[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = ct.headerReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = ct.valueReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (ct CompressedLWECiphertext[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, ct.ByteSize()))
+	_, err = ct.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (ct *CompressedLWECiphertext[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := ct.ReadFrom(buf)
+	return err
+}
+
+// ByteSize returns the size of the ciphertext in bytes.
+func (ct CompressedGLWECiphertext[T]) ByteSize() int {
+	glweRank := len(ct.Value) - 1
+	polyRank := ct.Value[0].Rank()
+
+	return 24 + packedByteSize((glweRank+1)*polyRank, ct.LogModulus)
+}
+
+// headerWriteTo writes the header.
+func (ct CompressedGLWECiphertext[T]) headerWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var buf [8]byte
+
+	logModulus := ct.LogModulus
+	binary.BigEndian.PutUint64(buf[:], uint64(logModulus))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	glweRank := len(ct.Value) - 1
+	binary.BigEndian.PutUint64(buf[:], uint64(glweRank))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	polyRank := ct.Value[0].Rank()
+	binary.BigEndian.PutUint64(buf[:], uint64(polyRank))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// valueWriteTo writes the value.
+// All coefficients are packed together, so that no bits are wasted between polynomials.
+func (ct CompressedGLWECiphertext[T]) valueWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+
+	polyRank := ct.Value[0].Rank()
+	coeffs := make([]T, 0, len(ct.Value)*polyRank)
+	for _, p := range ct.Value {
+		coeffs = append(coeffs, p.Coeffs...)
+	}
+
+	buf := make([]byte, packedByteSize(len(coeffs), ct.LogModulus))
+	packBitsTo(buf, coeffs, ct.LogModulus)
+	if nWrite, err = w.Write(buf); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] LogModulus
+//	[8] GLWERank
+//	[8] PolyRank
+//	    Value, packed to LogModulus bits per coefficient
+func (ct CompressedGLWECiphertext[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = ct.headerWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = ct.valueWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(ct.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// headerReadFrom reads the header, and initializes the value.
+func (ct *CompressedGLWECiphertext[T]) headerReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	logModulus := int(binary.BigEndian.Uint64(buf[:]))
+	if logModulus < 1 || logModulus > num.SizeT[T]() {
+		return n, fmt.Errorf("LogModulus %d out of range", logModulus)
+	}
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	glweRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	polyRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	*ct = NewCompressedGLWECiphertextCustom[T](glweRank, polyRank, logModulus)
+
+	return
+}
+
+// valueReadFrom reads the value.
+func (ct *CompressedGLWECiphertext[T]) valueReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+
+	polyRank := ct.Value[0].Rank()
+	coeffs := make([]T, len(ct.Value)*polyRank)
+
+	buf := make([]byte, packedByteSize(len(coeffs), ct.LogModulus))
+	if nRead, err = io.ReadFull(r, buf); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	unpackBitsTo(coeffs, buf, ct.LogModulus)
+
+	for i := range ct.Value {
+		copy(ct.Value[i].Coeffs, coeffs[i*polyRank:(i+1)*polyRank])
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (ct *CompressedGLWECiphertext[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = ct.headerReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = ct.valueReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (ct CompressedGLWECiphertext[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, ct.ByteSize()))
+	_, err = ct.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (ct *CompressedGLWECiphertext[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := ct.ReadFrom(buf)
+	return err
+}
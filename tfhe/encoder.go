@@ -32,11 +32,16 @@ func (e *Encoder[T]) EncodeLWE(message int) LWEPlaintext[T] {
 
 // EncodeLWECustom encodes integer message to LWE plaintext
 // using custom MessageModulus and Scale.
+// Negative messages are reduced modulo MessageModulus,
+// so that they are encoded in two's complement if MessageModulus is a power of two.
 //
 // If MessageModulus = 0, then no modulus reduction is performed.
 func (e *Encoder[T]) EncodeLWECustom(message int, messageModulus, scale T) LWEPlaintext[T] {
 	encoded := T(message)
 	if messageModulus != 0 {
+		if message < 0 {
+			encoded = messageModulus - T(-message)%messageModulus
+		}
 		encoded %= messageModulus
 	}
 	return LWEPlaintext[T]{Value: encoded * scale}
@@ -60,6 +65,42 @@ func (e *Encoder[T]) DecodeLWECustom(pt LWEPlaintext[T], messageModulus, scale T
 	return int(decoded % messageModulus)
 }
 
+// DecodeLWESigned decodes LWE plaintext to signed integer message
+// in [-MessageModulus/2, MessageModulus/2).
+// Parameter's MessageModulus and Scale are used.
+func (e *Encoder[T]) DecodeLWESigned(pt LWEPlaintext[T]) int {
+	return e.DecodeLWECustomSigned(pt, e.Params.messageModulus, e.Params.scale)
+}
+
+// DecodeLWECustomSigned decodes LWE plaintext to signed integer message
+// in [-MessageModulus/2, MessageModulus/2)
+// using custom MessageModulus and Scale.
+//
+// If MessageModulus = 0, then it is automatically set to round(Q / scale).
+func (e *Encoder[T]) DecodeLWECustomSigned(pt LWEPlaintext[T], messageModulus, scale T) int {
+	if messageModulus == 0 {
+		messageModulus = T(math.Round(math.Exp2(float64(e.Params.logQ)) / float64(scale)))
+	}
+	decoded := e.DecodeLWECustom(pt, messageModulus, scale)
+	if decoded >= int(messageModulus/2) {
+		decoded -= int(messageModulus)
+	}
+	return decoded
+}
+
+// EncodeLWEFullDomain encodes integer message in [0, 2*MessageModulus) to LWE plaintext,
+// using the padding bit as a part of the message.
+// This is used in full-domain bootstrapping, such as [Evaluator.BootstrapFullDomainFunc].
+func (e *Encoder[T]) EncodeLWEFullDomain(message int) LWEPlaintext[T] {
+	return e.EncodeLWECustom(message, 2*e.Params.messageModulus, e.Params.scale)
+}
+
+// DecodeLWEFullDomain decodes LWE plaintext to integer message in [0, 2*MessageModulus).
+// This is used in full-domain bootstrapping, such as [Evaluator.BootstrapFullDomainFunc].
+func (e *Encoder[T]) DecodeLWEFullDomain(pt LWEPlaintext[T]) int {
+	return e.DecodeLWECustom(pt, 2*e.Params.messageModulus, e.Params.scale)
+}
+
 // EncodeGLWE encodes up to Parameters.PolyRank integer messages into one GLWE plaintext.
 // Parameter's MessageModulus and Scale are used.
 //
@@ -46,6 +46,8 @@ type encryptorBuffer[T TorusInt] struct {
 	ptGLWE GLWEPlaintext[T]
 	// ctGLWE is a standard GLWE Ciphertext for Fourier encryption and decryption.
 	ctGLWE GLWECiphertext[T]
+	// ctLWE is a standard LWE ciphertext for seeded encryption.
+	ctLWE LWECiphertext[T]
 	// ptGGSW is GLWEKey * Pt in GGSW encryption.
 	ptGGSW poly.Poly[T]
 }
@@ -100,6 +102,7 @@ func newEncryptorBuffer[T TorusInt](params Parameters[T]) encryptorBuffer[T] {
 	return encryptorBuffer[T]{
 		ptGLWE: NewGLWEPlaintext(params),
 		ctGLWE: NewGLWECiphertext(params),
+		ctLWE:  NewLWECiphertext(params),
 		ptGGSW: poly.NewPoly[T](params.polyRank),
 	}
 }
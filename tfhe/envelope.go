@@ -0,0 +1,541 @@
+package tfhe
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sp301415/tfhe-go/math/num"
+)
+
+const (
+	// EnvelopeVersion is the current version of the envelope format.
+	EnvelopeVersion = 1
+
+	// EnvelopeHeaderSize is the size of the envelope header in bytes.
+	EnvelopeHeaderSize = 4 + 2 + 2 + 1 + ParamsHashSize
+
+	// ParamsHashSize is the size of the parameter hash in bytes.
+	ParamsHashSize = sha256.Size
+)
+
+// EnvelopeMagic is the magic number written at the start of every envelope.
+var EnvelopeMagic = [4]byte{'T', 'F', 'G', 'O'}
+
+var (
+	// ErrInvalidEnvelope is returned when the data does not start with [EnvelopeMagic].
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+	// ErrEnvelopeVersion is returned when the envelope version is not supported.
+	ErrEnvelopeVersion = errors.New("unsupported envelope version")
+	// ErrObjectTypeMismatch is returned when the envelope holds a different type of object.
+	ErrObjectTypeMismatch = errors.New("object type mismatch")
+	// ErrTorusIntMismatch is returned when the envelope was written with a different TorusInt.
+	ErrTorusIntMismatch = errors.New("TorusInt size mismatch")
+	// ErrParamsMismatch is returned when the envelope was written under different parameters.
+	ErrParamsMismatch = errors.New("parameters mismatch")
+)
+
+// ObjectType is a tag identifying the type of an object in an envelope.
+//
+// The upper byte identifies the package:
+// 0x01 for tfhe, 0x02 for mktfhe and 0x03 for xtfhe.
+// Object types are persisted, so their values must never change.
+// New object types get new values.
+type ObjectType uint16
+
+// Object types of the tfhe package.
+const (
+	ObjectLWESecretKey             ObjectType = 0x0100
+	ObjectLWEPublicKey             ObjectType = 0x0101
+	ObjectLWEPlaintext             ObjectType = 0x0102
+	ObjectLWECiphertext            ObjectType = 0x0103
+	ObjectLevCiphertext            ObjectType = 0x0104
+	ObjectGSWCiphertext            ObjectType = 0x0105
+	ObjectGLWESecretKey            ObjectType = 0x0106
+	ObjectGLWEPublicKey            ObjectType = 0x0107
+	ObjectGLWEPlaintext            ObjectType = 0x0108
+	ObjectGLWECiphertext           ObjectType = 0x0109
+	ObjectGLevCiphertext           ObjectType = 0x010A
+	ObjectGGSWCiphertext           ObjectType = 0x010B
+	ObjectFFTGLWESecretKey         ObjectType = 0x010C
+	ObjectFFTGLWECiphertext        ObjectType = 0x010D
+	ObjectFFTGLevCiphertext        ObjectType = 0x010E
+	ObjectFFTGGSWCiphertext        ObjectType = 0x010F
+	ObjectSecretKey                ObjectType = 0x0110
+	ObjectPublicKey                ObjectType = 0x0111
+	ObjectEvaluationKey            ObjectType = 0x0112
+	ObjectBlindRotateKey           ObjectType = 0x0113
+	ObjectLWEKeySwitchKey          ObjectType = 0x0114
+	ObjectGLWEKeySwitchKey         ObjectType = 0x0115
+	ObjectPackingKeySwitchKey      ObjectType = 0x0116
+	ObjectSeededLWECiphertext      ObjectType = 0x0117
+	ObjectSeededGLWECiphertext     ObjectType = 0x0118
+	ObjectSeededEvaluationKey      ObjectType = 0x0119
+	ObjectSeededBlindRotateKey     ObjectType = 0x011A
+	ObjectSeededLWEKeySwitchKey    ObjectType = 0x011B
+	ObjectSeededGLWEKeySwitchKey   ObjectType = 0x011C
+	ObjectCompressedLWECiphertext  ObjectType = 0x011D
+	ObjectCompressedGLWECiphertext ObjectType = 0x011E
+)
+
+// EnvelopeParameters is a parameter set that objects in an envelope are bound to.
+type EnvelopeParameters interface {
+	// LogQ returns log(Q), where Q is the modulus of the ciphertext.
+	LogQ() int
+	// Hash returns the hash of the parameters.
+	Hash() [ParamsHashSize]byte
+}
+
+// EnvelopeWriter is an object that can be written in an envelope.
+type EnvelopeWriter interface {
+	io.WriterTo
+	ByteSize() int
+	ObjectType() ObjectType
+	TorusIntSize() int
+}
+
+// EnvelopeReader is an object that can be read from an envelope.
+type EnvelopeReader interface {
+	io.ReaderFrom
+	ObjectType() ObjectType
+	TorusIntSize() int
+}
+
+// WriteEnvelope writes obj to w, prefixed with a header
+// binding it to params.
+//
+// Returns [ErrTorusIntMismatch] if the TorusInt of obj does not match params.
+//
+// The encoded form is as follows:
+//
+//	[ 4] EnvelopeMagic
+//	[ 2] EnvelopeVersion
+//	[ 2] ObjectType
+//	[ 1] TorusIntSize
+//	[32] ParamsHash
+//	     Object
+func WriteEnvelope(w io.Writer, params EnvelopeParameters, obj EnvelopeWriter) (n int64, err error) {
+	var nWrite int
+	var nWrite64 int64
+	var buf [EnvelopeHeaderSize]byte
+
+	if obj.TorusIntSize() != params.LogQ()/8 {
+		return n, fmt.Errorf("%w: object has %d bytes, parameters have %d bytes", ErrTorusIntMismatch, obj.TorusIntSize(), params.LogQ()/8)
+	}
+
+	paramsHash := params.Hash()
+
+	copy(buf[0:4], EnvelopeMagic[:])
+	binary.BigEndian.PutUint16(buf[4:6], EnvelopeVersion)
+	binary.BigEndian.PutUint16(buf[6:8], uint16(obj.ObjectType()))
+	buf[8] = byte(obj.TorusIntSize())
+	copy(buf[9:], paramsHash[:])
+
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	if nWrite64, err = obj.WriteTo(w); err != nil {
+		return n + nWrite64, err
+	}
+	n += nWrite64
+
+	return
+}
+
+// ReadEnvelope reads an envelope written by [WriteEnvelope] from r to obj.
+//
+// Returns an error if the envelope holds a different type of object or TorusInt,
+// or was written under parameters other than params.
+// In this case, obj is left untouched.
+func ReadEnvelope(r io.Reader, params EnvelopeParameters, obj EnvelopeReader) (n int64, err error) {
+	var nRead int
+	var nRead64 int64
+	var buf [EnvelopeHeaderSize]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+
+	if !bytes.Equal(buf[0:4], EnvelopeMagic[:]) {
+		return n, ErrInvalidEnvelope
+	}
+
+	if version := binary.BigEndian.Uint16(buf[4:6]); version != EnvelopeVersion {
+		return n, fmt.Errorf("%w: got %d, want %d", ErrEnvelopeVersion, version, EnvelopeVersion)
+	}
+
+	if objectType := ObjectType(binary.BigEndian.Uint16(buf[6:8])); objectType != obj.ObjectType() {
+		return n, fmt.Errorf("%w: got %#04x, want %#04x", ErrObjectTypeMismatch, uint16(objectType), uint16(obj.ObjectType()))
+	}
+
+	torusIntSize := int(buf[8])
+	if torusIntSize != obj.TorusIntSize() {
+		return n, fmt.Errorf("%w: got %d bytes, object has %d bytes", ErrTorusIntMismatch, torusIntSize, obj.TorusIntSize())
+	}
+	if torusIntSize != params.LogQ()/8 {
+		return n, fmt.Errorf("%w: got %d bytes, parameters have %d bytes", ErrTorusIntMismatch, torusIntSize, params.LogQ()/8)
+	}
+
+	paramsHash := params.Hash()
+	if !bytes.Equal(buf[9:], paramsHash[:]) {
+		return n, ErrParamsMismatch
+	}
+
+	if nRead64, err = obj.ReadFrom(r); err != nil {
+		return n + nRead64, err
+	}
+	n += nRead64
+
+	return
+}
+
+// MarshalEnvelope returns obj encoded by [WriteEnvelope].
+func MarshalEnvelope(params EnvelopeParameters, obj EnvelopeWriter) (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, EnvelopeHeaderSize+obj.ByteSize()))
+	_, err = WriteEnvelope(buf, params, obj)
+	return buf.Bytes(), err
+}
+
+// UnmarshalEnvelope decodes data encoded by [MarshalEnvelope] to obj.
+func UnmarshalEnvelope(data []byte, params EnvelopeParameters, obj EnvelopeReader) error {
+	buf := bytes.NewBuffer(data)
+	_, err := ReadEnvelope(buf, params, obj)
+	return err
+}
+
+// HashParameters returns the hash of parameters encoded by p, under domain.
+// Domain separates hashes of different parameter types with the same encoding.
+//
+// This is used to implement [EnvelopeParameters].
+func HashParameters(domain string, logQ int, p io.WriterTo) [ParamsHashSize]byte {
+	h := sha256.New()
+	h.Write([]byte(domain))
+	h.Write([]byte{0, byte(logQ)})
+	p.WriteTo(h)
+
+	var hash [ParamsHashSize]byte
+	h.Sum(hash[:0])
+	return hash
+}
+
+// Hash returns the hash of the parameters.
+// Parameters compiled from the same ParametersLiteral have the same hash.
+func (p Parameters[T]) Hash() [ParamsHashSize]byte {
+	return HashParameters("tfhe.Parameters", p.logQ, p)
+}
+
+// ObjectType returns [ObjectLWESecretKey].
+func (sk LWESecretKey[T]) ObjectType() ObjectType {
+	return ObjectLWESecretKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (sk LWESecretKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectLWEPublicKey].
+func (pk LWEPublicKey[T]) ObjectType() ObjectType {
+	return ObjectLWEPublicKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (pk LWEPublicKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectLWEPlaintext].
+func (pt LWEPlaintext[T]) ObjectType() ObjectType {
+	return ObjectLWEPlaintext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (pt LWEPlaintext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectLWECiphertext].
+func (ct LWECiphertext[T]) ObjectType() ObjectType {
+	return ObjectLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct LWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectLevCiphertext].
+func (ct LevCiphertext[T]) ObjectType() ObjectType {
+	return ObjectLevCiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct LevCiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGSWCiphertext].
+func (ct GSWCiphertext[T]) ObjectType() ObjectType {
+	return ObjectGSWCiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct GSWCiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGLWESecretKey].
+func (sk GLWESecretKey[T]) ObjectType() ObjectType {
+	return ObjectGLWESecretKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (sk GLWESecretKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGLWEPublicKey].
+func (pk GLWEPublicKey[T]) ObjectType() ObjectType {
+	return ObjectGLWEPublicKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (pk GLWEPublicKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGLWEPlaintext].
+func (pt GLWEPlaintext[T]) ObjectType() ObjectType {
+	return ObjectGLWEPlaintext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (pt GLWEPlaintext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGLWECiphertext].
+func (ct GLWECiphertext[T]) ObjectType() ObjectType {
+	return ObjectGLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct GLWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGLevCiphertext].
+func (ct GLevCiphertext[T]) ObjectType() ObjectType {
+	return ObjectGLevCiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct GLevCiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGGSWCiphertext].
+func (ct GGSWCiphertext[T]) ObjectType() ObjectType {
+	return ObjectGGSWCiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct GGSWCiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectFFTGLWESecretKey].
+func (sk FFTGLWESecretKey[T]) ObjectType() ObjectType {
+	return ObjectFFTGLWESecretKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (sk FFTGLWESecretKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectFFTGLWECiphertext].
+func (ct FFTGLWECiphertext[T]) ObjectType() ObjectType {
+	return ObjectFFTGLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct FFTGLWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectFFTGLevCiphertext].
+func (ct FFTGLevCiphertext[T]) ObjectType() ObjectType {
+	return ObjectFFTGLevCiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct FFTGLevCiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectFFTGGSWCiphertext].
+func (ct FFTGGSWCiphertext[T]) ObjectType() ObjectType {
+	return ObjectFFTGGSWCiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct FFTGGSWCiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectSecretKey].
+func (sk SecretKey[T]) ObjectType() ObjectType {
+	return ObjectSecretKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (sk SecretKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectPublicKey].
+func (pk PublicKey[T]) ObjectType() ObjectType {
+	return ObjectPublicKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (pk PublicKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectEvaluationKey].
+func (evk EvaluationKey[T]) ObjectType() ObjectType {
+	return ObjectEvaluationKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (evk EvaluationKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectBlindRotateKey].
+func (brk BlindRotateKey[T]) ObjectType() ObjectType {
+	return ObjectBlindRotateKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (brk BlindRotateKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectLWEKeySwitchKey].
+func (ksk LWEKeySwitchKey[T]) ObjectType() ObjectType {
+	return ObjectLWEKeySwitchKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ksk LWEKeySwitchKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectGLWEKeySwitchKey].
+func (ksk GLWEKeySwitchKey[T]) ObjectType() ObjectType {
+	return ObjectGLWEKeySwitchKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ksk GLWEKeySwitchKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectPackingKeySwitchKey].
+func (pksk PackingKeySwitchKey[T]) ObjectType() ObjectType {
+	return ObjectPackingKeySwitchKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (pksk PackingKeySwitchKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectSeededLWECiphertext].
+func (ct SeededLWECiphertext[T]) ObjectType() ObjectType {
+	return ObjectSeededLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct SeededLWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectSeededGLWECiphertext].
+func (ct SeededGLWECiphertext[T]) ObjectType() ObjectType {
+	return ObjectSeededGLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct SeededGLWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectSeededEvaluationKey].
+func (evk SeededEvaluationKey[T]) ObjectType() ObjectType {
+	return ObjectSeededEvaluationKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (evk SeededEvaluationKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectSeededBlindRotateKey].
+func (brk SeededBlindRotateKey[T]) ObjectType() ObjectType {
+	return ObjectSeededBlindRotateKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (brk SeededBlindRotateKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectSeededLWEKeySwitchKey].
+func (ksk SeededLWEKeySwitchKey[T]) ObjectType() ObjectType {
+	return ObjectSeededLWEKeySwitchKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ksk SeededLWEKeySwitchKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectSeededGLWEKeySwitchKey].
+func (ksk SeededGLWEKeySwitchKey[T]) ObjectType() ObjectType {
+	return ObjectSeededGLWEKeySwitchKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ksk SeededGLWEKeySwitchKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectCompressedLWECiphertext].
+func (ct CompressedLWECiphertext[T]) ObjectType() ObjectType {
+	return ObjectCompressedLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct CompressedLWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectCompressedGLWECiphertext].
+func (ct CompressedGLWECiphertext[T]) ObjectType() ObjectType {
+	return ObjectCompressedGLWECiphertext
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (ct CompressedGLWECiphertext[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
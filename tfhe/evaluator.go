@@ -31,6 +31,17 @@ type Evaluator[T TorusInt] struct {
 	// modSwitchConst is a constant for modulus switching.
 	modSwitchConst float64
 
+	// lutSign is a precomputed LUT from [Evaluator.GenSignLUT], used for full-domain bootstrapping.
+	// It is never written after NewEvaluator, so it is shared with safe copies.
+	lutSign LookUpTable[T]
+
+	// batchWorkerCount is the number of workers for batch bootstrapping.
+	// If zero, runtime.NumCPU() is used.
+	batchWorkerCount int
+	// batchPool is a pool of Evaluators for batch bootstrapping.
+	// It is allocated on the first batch bootstrapping.
+	batchPool []*Evaluator[T]
+
 	buf evaluatorBuffer[T]
 }
 
@@ -61,6 +72,8 @@ type evaluatorBuffer[T TorusInt] struct {
 	ctAccFFTDcmp [][][]poly.FFTPoly
 	// fMono is a fourier transformed monomial in Blind Rotation.
 	fMono poly.FFTPoly
+	// ctFFTGGSWGroup is a sum of GGSW ciphertexts of a group in BlindRotateMultiBit.
+	ctFFTGGSWGroup FFTGGSWCiphertext[T]
 
 	// ctRotate is a blind rotated GLWE ciphertext for bootstrapping.
 	ctRotate GLWECiphertext[T]
@@ -69,8 +82,13 @@ type evaluatorBuffer[T TorusInt] struct {
 	// ctKeySwitch is an LWEDimension-sized ciphertext from keyswitching for bootstrapping.
 	ctKeySwitch LWECiphertext[T]
 
+	// ctSign is a ciphertext for full-domain bootstrapping.
+	ctSign LWECiphertext[T]
+
 	// lut is an empty lut, used for BlindRotateFunc.
 	lut LookUpTable[T]
+	// lutFullDomain is an empty full-domain lut, used for BootstrapFullDomainFunc.
+	lutFullDomain FullDomainLookUpTable[T]
 	// lutRaw is an full-sized LUT.
 	lutRaw []T
 }
@@ -83,7 +101,7 @@ func NewEvaluator[T TorusInt](params Parameters[T], evk EvaluationKey[T]) *Evalu
 	decomposer.PolyBuffer(params.blindRotateParams)
 	decomposer.FFTPolyBuffer(params.blindRotateParams)
 
-	return &Evaluator[T]{
+	e := &Evaluator[T]{
 		Encoder:         NewEncoder(params),
 		GLWETransformer: NewGLWETransformer[T](params.polyRank),
 
@@ -98,6 +116,9 @@ func NewEvaluator[T TorusInt](params Parameters[T], evk EvaluationKey[T]) *Evalu
 
 		buf: newEvaluatorBuffer(params),
 	}
+	e.lutSign = e.GenSignLUT()
+
+	return e
 }
 
 // newEvaluatorBuffer creates a new [evaluatorBuffer].
@@ -135,12 +156,17 @@ func newEvaluatorBuffer[T TorusInt](params Parameters[T]) evaluatorBuffer[T] {
 		ctAccFFTDcmp:  ctFFTAccDcmp,
 		fMono:         poly.NewFFTPoly(params.polyRank),
 
+		ctFFTGGSWGroup: NewFFTGGSWCiphertext(params, params.blindRotateParams),
+
 		ctRotate:    NewGLWECiphertext(params),
 		ctExtract:   NewLWECiphertextCustom[T](params.glweDimension),
 		ctKeySwitch: NewLWECiphertextCustom[T](params.lweDimension),
 
-		lut:    NewLUT(params),
-		lutRaw: make([]T, params.lutSize),
+		ctSign: NewLWECiphertext(params),
+
+		lut:           NewLUT(params),
+		lutFullDomain: NewFullDomainLUT(params),
+		lutRaw:        make([]T, params.lutSize),
 	}
 }
 
@@ -159,6 +185,10 @@ func (e *Evaluator[T]) SafeCopy() *Evaluator[T] {
 
 		modSwitchConst: e.modSwitchConst,
 
+		lutSign: e.lutSign,
+
+		batchWorkerCount: e.batchWorkerCount,
+
 		buf: newEvaluatorBuffer(e.Params),
 	}
 }
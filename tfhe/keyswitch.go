@@ -62,3 +62,63 @@ func (e *Evaluator[T]) KeySwitchGLWETo(ctOut, ct GLWECiphertext[T], ksk GLWEKeyS
 		e.PolyEvaluator.InvFFTToUnsafe(ctOut.Value[i+1], e.buf.ctFFTProdGLWE.Value[i+1])
 	}
 }
+
+// PackLWEs packs LWE ciphertexts into one GLWE ciphertext,
+// so that the i-th coefficient of the output encrypts the message of cts[i].
+// Input ciphertexts should be of length pksk.InputLWEDimension + 1.
+//
+// Panics if len(cts) > PolyRank.
+func (e *Evaluator[T]) PackLWEs(cts []LWECiphertext[T], pksk PackingKeySwitchKey[T]) GLWECiphertext[T] {
+	ctOut := NewGLWECiphertext(e.Params)
+	e.PackLWEsTo(ctOut, cts, pksk)
+	return ctOut
+}
+
+// PackLWEsTo packs LWE ciphertexts into one GLWE ciphertext and writes it to ctOut,
+// so that the i-th coefficient of the output encrypts the message of cts[i].
+// Input ciphertexts should be of length pksk.InputLWEDimension + 1.
+//
+// Panics if len(cts) > PolyRank.
+func (e *Evaluator[T]) PackLWEsTo(ctOut GLWECiphertext[T], cts []LWECiphertext[T], pksk PackingKeySwitchKey[T]) {
+	if len(cts) > e.Params.polyRank {
+		panic("Too many LWE ciphertexts")
+	}
+
+	cDcmp := e.Decomposer.ScalarBuffer(pksk.GadgetParams)
+	pDcmp := e.Decomposer.PolyBuffer(pksk.GadgetParams)
+	fpDcmp := e.Decomposer.FFTPolyBuffer(pksk.GadgetParams)
+
+	// Instead of keyswitching each ciphertext separately,
+	// the i-th mask elements of all ciphertexts are decomposed into a polynomial,
+	// so that only one polynomial multiplication is needed for each key element.
+	for j := 0; j < pksk.GadgetParams.level; j++ {
+		pDcmp[j].Clear()
+	}
+
+	for i := 0; i < pksk.InputLWEDimension(); i++ {
+		for k := range cts {
+			e.Decomposer.DecomposeScalarTo(cDcmp, cts[k].Value[i+1], pksk.GadgetParams)
+			for j := 0; j < pksk.GadgetParams.level; j++ {
+				pDcmp[j].Coeffs[k] = cDcmp[j]
+			}
+		}
+
+		for j := 0; j < pksk.GadgetParams.level; j++ {
+			e.PolyEvaluator.FwdFFTTo(fpDcmp[j], pDcmp[j])
+			if i == 0 && j == 0 {
+				e.FFTPolyMulFFTGLWETo(e.buf.ctFFTProdGLWE, pksk.Value[i].Value[j], fpDcmp[j])
+			} else {
+				e.FFTPolyMulAddFFTGLWETo(e.buf.ctFFTProdGLWE, pksk.Value[i].Value[j], fpDcmp[j])
+			}
+		}
+	}
+
+	ctOut.Value[0].Clear()
+	for k := range cts {
+		ctOut.Value[0].Coeffs[k] = cts[k].Value[0]
+	}
+	e.PolyEvaluator.InvFFTAddToUnsafe(ctOut.Value[0], e.buf.ctFFTProdGLWE.Value[0])
+	for i := 0; i < e.Params.glweRank; i++ {
+		e.PolyEvaluator.InvFFTToUnsafe(ctOut.Value[i+1], e.buf.ctFFTProdGLWE.Value[i+1])
+	}
+}
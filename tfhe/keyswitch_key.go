@@ -119,3 +119,59 @@ func (ksk *GLWEKeySwitchKey[T]) Clear() {
 		ksk.Value[i].Clear()
 	}
 }
+
+// PackingKeySwitchKey is a packing keyswitch key from an LWEKey to a GLWEKey.
+// It packs multiple LWE ciphertexts into one GLWE ciphertext.
+type PackingKeySwitchKey[T TorusInt] struct {
+	GadgetParams GadgetParameters[T]
+
+	// Value has length InputLWEDimension.
+	Value []FFTGLevCiphertext[T]
+}
+
+// NewPackingKeySwitchKey creates a new [PackingKeySwitchKey].
+func NewPackingKeySwitchKey[T TorusInt](params Parameters[T], inputDimension int, gadgetParams GadgetParameters[T]) PackingKeySwitchKey[T] {
+	pksk := make([]FFTGLevCiphertext[T], inputDimension)
+	for i := 0; i < inputDimension; i++ {
+		pksk[i] = NewFFTGLevCiphertext(params, gadgetParams)
+	}
+	return PackingKeySwitchKey[T]{Value: pksk, GadgetParams: gadgetParams}
+}
+
+// NewPackingKeySwitchKeyCustom creates a new [PackingKeySwitchKey] with custom parameters.
+func NewPackingKeySwitchKeyCustom[T TorusInt](inputDimension, glweRank, polyRank int, gadgetParams GadgetParameters[T]) PackingKeySwitchKey[T] {
+	pksk := make([]FFTGLevCiphertext[T], inputDimension)
+	for i := 0; i < inputDimension; i++ {
+		pksk[i] = NewFFTGLevCiphertextCustom(glweRank, polyRank, gadgetParams)
+	}
+	return PackingKeySwitchKey[T]{Value: pksk, GadgetParams: gadgetParams}
+}
+
+// InputLWEDimension returns the input LWEDimension of this key.
+func (pksk PackingKeySwitchKey[T]) InputLWEDimension() int {
+	return len(pksk.Value)
+}
+
+// Copy returns a copy of the key.
+func (pksk PackingKeySwitchKey[T]) Copy() PackingKeySwitchKey[T] {
+	pkskCopy := make([]FFTGLevCiphertext[T], len(pksk.Value))
+	for i := range pksk.Value {
+		pkskCopy[i] = pksk.Value[i].Copy()
+	}
+	return PackingKeySwitchKey[T]{Value: pkskCopy, GadgetParams: pksk.GadgetParams}
+}
+
+// CopyFrom copies values from key.
+func (pksk *PackingKeySwitchKey[T]) CopyFrom(pkskIn PackingKeySwitchKey[T]) {
+	for i := range pksk.Value {
+		pksk.Value[i].CopyFrom(pkskIn.Value[i])
+	}
+	pksk.GadgetParams = pkskIn.GadgetParams
+}
+
+// Clear clears the key.
+func (pksk *PackingKeySwitchKey[T]) Clear() {
+	for i := range pksk.Value {
+		pksk.Value[i].Clear()
+	}
+}
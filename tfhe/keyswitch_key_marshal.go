@@ -366,3 +366,199 @@ func (ksk *GLWEKeySwitchKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
 
 	return
 }
+
+// ByteSize returns the size of the key in bytes.
+func (pksk PackingKeySwitchKey[T]) ByteSize() int {
+	inputDimension := len(pksk.Value)
+	level := len(pksk.Value[0].Value)
+	glweRank := len(pksk.Value[0].Value[0].Value) - 1
+	polyRank := pksk.Value[0].Value[0].Value[0].Rank()
+
+	return 40 + inputDimension*level*(glweRank+1)*polyRank*8
+}
+
+// headerWriteTo writes the header.
+func (pksk PackingKeySwitchKey[T]) headerWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var buf [8]byte
+
+	base := pksk.GadgetParams.base
+	binary.BigEndian.PutUint64(buf[:], uint64(base))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	level := pksk.GadgetParams.level
+	binary.BigEndian.PutUint64(buf[:], uint64(level))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	inputDimension := len(pksk.Value)
+	binary.BigEndian.PutUint64(buf[:], uint64(inputDimension))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	glweRank := len(pksk.Value[0].Value[0].Value) - 1
+	binary.BigEndian.PutUint64(buf[:], uint64(glweRank))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	polyRank := pksk.Value[0].Value[0].Value[0].Rank()
+	binary.BigEndian.PutUint64(buf[:], uint64(polyRank))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// valueWriteTo writes the value.
+func (pksk PackingKeySwitchKey[T]) valueWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	polyRank := pksk.Value[0].Value[0].Value[0].Rank()
+	buf := make([]byte, polyRank*8)
+
+	for i := range pksk.Value {
+		for j := range pksk.Value[i].Value {
+			for k := range pksk.Value[i].Value[j].Value {
+				if nWrite, err = floatVecWriteToBuf(pksk.Value[i].Value[j].Value[k].Coeffs, buf, w); err != nil {
+					return n + nWrite, err
+				}
+				n += nWrite
+			}
+		}
+	}
+
+	return
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] Base
+//	[8] Level
+//	[8] InputDimension
+//	[8] GLWERank
+//	[8] PolyRank
+//	    Value
+func (pksk PackingKeySwitchKey[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = pksk.headerWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = pksk.valueWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(pksk.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// headerReadFrom reads the header, and initializes the value.
+func (pksk *PackingKeySwitchKey[T]) headerReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	base := T(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	level := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	inputDimension := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	glweRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	polyRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	*pksk = NewPackingKeySwitchKeyCustom(inputDimension, glweRank, polyRank, GadgetParametersLiteral[T]{Base: base, Level: level}.Compile())
+
+	return
+}
+
+// valueReadFrom reads the value.
+func (pksk *PackingKeySwitchKey[T]) valueReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	polyRank := pksk.Value[0].Value[0].Value[0].Rank()
+	buf := make([]byte, polyRank*8)
+
+	for i := range pksk.Value {
+		for j := range pksk.Value[i].Value {
+			for k := range pksk.Value[i].Value[j].Value {
+				if nRead, err = floatVecReadFromBuf(pksk.Value[i].Value[j].Value[k].Coeffs, buf, r); err != nil {
+					return n + nRead, err
+				}
+				n += nRead
+			}
+		}
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (pksk *PackingKeySwitchKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = pksk.headerReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = pksk.valueReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (pksk PackingKeySwitchKey[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, pksk.ByteSize()))
+	_, err = pksk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (pksk *PackingKeySwitchKey[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := pksk.ReadFrom(buf)
+	return err
+}
@@ -21,3 +21,16 @@ func (e *Encryptor[T]) GenGLWEKeySwitchKey(skIn GLWESecretKey[T], gadgetParams G
 
 	return ksk
 }
+
+// GenPackingKeySwitchKey samples a new packing keyswitch key skIn -> GLWEKey.
+func (e *Encryptor[T]) GenPackingKeySwitchKey(skIn LWESecretKey[T], gadgetParams GadgetParameters[T]) PackingKeySwitchKey[T] {
+	pksk := NewPackingKeySwitchKey(e.Params, len(skIn.Value), gadgetParams)
+
+	e.buf.ptGLWE.Value.Clear()
+	for i := 0; i < pksk.InputLWEDimension(); i++ {
+		e.buf.ptGLWE.Value.Coeffs[0] = skIn.Value[i]
+		e.EncryptFFTGLevPolyTo(pksk.Value[i], e.buf.ptGLWE.Value)
+	}
+
+	return pksk
+}
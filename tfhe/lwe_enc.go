@@ -41,6 +41,23 @@ func (e *Encryptor[T]) DecryptLWE(ct LWECiphertext[T]) int {
 	return e.DecodeLWE(e.DecryptLWEPhase(ct))
 }
 
+// DecryptLWESigned decrypts and decodes LWE ciphertext to signed integer message
+// in [-MessageModulus/2, MessageModulus/2).
+func (e *Encryptor[T]) DecryptLWESigned(ct LWECiphertext[T]) int {
+	return e.DecodeLWESigned(e.DecryptLWEPhase(ct))
+}
+
+// EncryptLWEFullDomain encodes and encrypts integer message in [0, 2*MessageModulus) to LWE ciphertext,
+// using the padding bit as a part of the message.
+func (e *Encryptor[T]) EncryptLWEFullDomain(message int) LWECiphertext[T] {
+	return e.EncryptLWEPlaintext(e.EncodeLWEFullDomain(message))
+}
+
+// DecryptLWEFullDomain decrypts and decodes LWE ciphertext to integer message in [0, 2*MessageModulus).
+func (e *Encryptor[T]) DecryptLWEFullDomain(ct LWECiphertext[T]) int {
+	return e.DecodeLWEFullDomain(e.DecryptLWEPhase(ct))
+}
+
 // DecryptLWEPhase decrypts LWE ciphertext to LWE plaintext including errors.
 func (e *Encryptor[T]) DecryptLWEPhase(ct LWECiphertext[T]) LWEPlaintext[T] {
 	ptOut := ct.Value[0] + vec.Dot(ct.Value[1:], e.DefaultLWESecretKey().Value)
@@ -201,6 +201,9 @@ func (p *GadgetParameters[T]) UnmarshalBinary(data []byte) error {
 	return err
 }
 
+// MaxGroupingFactor is the maximum GroupingFactor supported in multi-bit blind rotation.
+const MaxGroupingFactor = 3
+
 // BootstrapOrder is an enum type for the order of Programmable Bootstrapping.
 type BootstrapOrder int
 
@@ -267,6 +270,24 @@ type ParametersLiteral[T TorusInt] struct {
 	//
 	// If zero, then it is set to 1.
 	BlockSize int
+	// GroupingFactor is the number of LWE key bits grouped together in multi-bit blind rotation.
+	//
+	// In multi-bit blind rotation, each group of GroupingFactor key bits is encrypted as
+	// 2^GroupingFactor - 1 GGSW ciphertexts, one for each nonzero combination of the bits.
+	// These are summed in the Fourier domain into one GGSW ciphertext per group,
+	// so only one external product is needed per group.
+	// This divides the number of FFTs in blind rotation by GroupingFactor,
+	// but multiplies the size of the blind rotation key and
+	// the number of Fourier domain multiplications by (2^GroupingFactor - 1) / GroupingFactor.
+	// Hence it is only faster than the original blind rotation when FFTs dominate,
+	// such as when GLWERank is 1.
+	// Unlike BlockSize, it does not restrict the distribution of LWE key.
+	//
+	// It must divide LWEDimension, and it cannot be used with BlockSize > 1 or LUTSize > PolyRank.
+	// To use the original TFHE bootstrapping, set this to 1.
+	//
+	// If zero, then it is set to 1.
+	GroupingFactor int
 
 	// MessageModulus is the modulus of the encoded message.
 	MessageModulus T
@@ -341,6 +362,12 @@ func (p ParametersLiteral[T]) WithBlockSize(blockSize int) ParametersLiteral[T]
 	return p
 }
 
+// WithGroupingFactor sets the GroupingFactor and returns the new ParametersLiteral.
+func (p ParametersLiteral[T]) WithGroupingFactor(groupingFactor int) ParametersLiteral[T] {
+	p.GroupingFactor = groupingFactor
+	return p
+}
+
 // WithMessageModulus sets the MessageModulus and returns the new ParametersLiteral.
 func (p ParametersLiteral[T]) WithMessageModulus(messageModulus T) ParametersLiteral[T] {
 	p.MessageModulus = messageModulus
@@ -382,6 +409,9 @@ func (p ParametersLiteral[T]) Compile() Parameters[T] {
 	if p.BlockSize == 0 {
 		p.BlockSize = 1
 	}
+	if p.GroupingFactor == 0 {
+		p.GroupingFactor = 1
+	}
 
 	switch {
 	case p.LWEDimension <= 0:
@@ -400,6 +430,16 @@ func (p ParametersLiteral[T]) Compile() Parameters[T] {
 		panic("BlockSize smaller than zero")
 	case p.LWEDimension%p.BlockSize != 0:
 		panic("LWEDimension not multiple of BlockSize")
+	case p.GroupingFactor <= 0:
+		panic("GroupingFactor smaller than zero")
+	case p.GroupingFactor > MaxGroupingFactor:
+		panic("GroupingFactor larger than MaxGroupingFactor")
+	case p.LWEDimension%p.GroupingFactor != 0:
+		panic("LWEDimension not multiple of GroupingFactor")
+	case p.GroupingFactor > 1 && p.BlockSize > 1:
+		panic("GroupingFactor and BlockSize both larger than one")
+	case p.GroupingFactor > 1 && p.LUTSize != p.PolyRank:
+		panic("GroupingFactor larger than one with LUTSize not equal to PolyRank")
 	case p.LUTSize%p.PolyRank != 0:
 		panic("LUTSize not multiple of PolyRank")
 	case !num.IsPowerOfTwo(p.PolyRank):
@@ -423,6 +463,9 @@ func (p ParametersLiteral[T]) Compile() Parameters[T] {
 		blockSize:  p.BlockSize,
 		blockCount: p.LWEDimension / p.BlockSize,
 
+		groupingFactor: p.GroupingFactor,
+		groupCount:     p.LWEDimension / p.GroupingFactor,
+
 		messageModulus: p.MessageModulus,
 		scale:          num.DivRound(1<<(num.SizeT[T]()-1), p.MessageModulus),
 
@@ -464,6 +507,11 @@ type Parameters[T TorusInt] struct {
 	// BlockCount is the number of blocks in LWESecretKey. Equal to LWEDimension / BlockSize.
 	blockCount int
 
+	// GroupingFactor is the number of LWE key bits grouped together in multi-bit blind rotation.
+	groupingFactor int
+	// GroupCount is the number of groups in multi-bit blind rotation. Equal to LWEDimension / GroupingFactor.
+	groupCount int
+
 	// MessageModulus is the modulus of the encoded message.
 	messageModulus T
 	// Scale is the scaling factor used for message encoding.
@@ -587,6 +635,24 @@ func (p Parameters[T]) BlockCount() int {
 	return p.blockCount
 }
 
+// GroupingFactor is the number of LWE key bits grouped together in multi-bit blind rotation.
+// If this is 1, the original blind rotation is used.
+func (p Parameters[T]) GroupingFactor() int {
+	return p.groupingFactor
+}
+
+// GroupCount is the number of groups in multi-bit blind rotation. Equal to LWEDimension / GroupingFactor.
+func (p Parameters[T]) GroupCount() int {
+	return p.groupCount
+}
+
+// BlindRotateKeyCount is the number of GGSW ciphertexts in BlindRotateKey.
+// Equal to GroupCount * (2^GroupingFactor - 1),
+// which is LWEDimension if GroupingFactor is 1.
+func (p Parameters[T]) BlindRotateKeyCount() int {
+	return p.groupCount * (1<<p.groupingFactor - 1)
+}
+
 // Scale is the scaling factor used for message encoding.
 // The lower log(Scale) bits are reserved for errors.
 func (p Parameters[T]) Scale() T {
@@ -636,7 +702,8 @@ func (p Parameters[T]) Literal() ParametersLiteral[T] {
 		LWEStdDev:  p.lweStdDev,
 		GLWEStdDev: p.glweStdDev,
 
-		BlockSize: p.blockSize,
+		BlockSize:      p.blockSize,
+		GroupingFactor: p.groupingFactor,
 
 		MessageModulus: p.messageModulus,
 
@@ -672,9 +739,12 @@ func (p Parameters[T]) EstimateBlindRotateStdDev() float64 {
 	Bbr := float64(p.blindRotateParams.Base())
 	Lbr := float64(p.blindRotateParams.Level())
 
+	// In multi-bit blind rotation, errors from all GGSW ciphertexts in a group are accumulated.
+	c := float64(p.BlindRotateKeyCount())
+
 	blindRotateVar1 := h * (h + (k*N-n)/2 + 1) * (q * q) / (6 * math.Pow(Bbr, 2*Lbr))
-	blindRotateVar2 := n * (Lbr * (k + 1) * N * beta * beta * Bbr * Bbr) / 6
-	blindRotateFFTVar := n * math.Exp2(-106.6) * (k + 1) * (h + (k*N-n)/2 + 1) * N * (q * q) * Lbr * (Bbr * Bbr)
+	blindRotateVar2 := c * (Lbr * (k + 1) * N * beta * beta * Bbr * Bbr) / 6
+	blindRotateFFTVar := c * math.Exp2(-106.6) * (k + 1) * (h + (k*N-n)/2 + 1) * N * (q * q) * Lbr * (Bbr * Bbr)
 	blindRotateVar := blindRotateVar1 + blindRotateVar2 + blindRotateFFTVar
 
 	return math.Sqrt(blindRotateVar)
@@ -715,7 +785,11 @@ func (p Parameters[T]) EstimateFailureProbability() float64 {
 
 // ByteSize returns the byte size of the parameters.
 func (p Parameters[T]) ByteSize() int {
-	return 8*8 + p.blindRotateParams.ByteSize() + p.keySwitchParams.ByteSize() + 1
+	byteSize := 8*8 + p.blindRotateParams.ByteSize() + p.keySwitchParams.ByteSize() + 1
+	if p.groupingFactor > 1 {
+		byteSize += 8
+	}
+	return byteSize
 }
 
 // WriteTo implements the [io.WriterTo] interface.
@@ -732,7 +806,12 @@ func (p Parameters[T]) ByteSize() int {
 //	[ 8] MessageModulus
 //	     BlindRotateParameters
 //	     KeySwitchParameters
-//	[ 1] BootstrapOrder
+//	[ 1] BootstrapOrder | IsGroupingFactorPresent << 7
+//	[ 8] GroupingFactor
+//
+// If IsGroupingFactorPresent is 0, then GroupingFactor is omitted and is 1.
+// Hence Parameters without multi-bit blind rotation
+// have the same encoding as before GroupingFactor was added.
 func (p Parameters[T]) WriteTo(w io.Writer) (n int64, err error) {
 	var nWrite int
 	var nWrite64 int64
@@ -804,12 +883,24 @@ func (p Parameters[T]) WriteTo(w io.Writer) (n int64, err error) {
 	}
 	n += nWrite64
 
-	bootstrapOrder := p.bootstrapOrder
-	if nWrite, err = w.Write([]byte{byte(bootstrapOrder)}); err != nil {
+	bootstrapOrder := byte(p.bootstrapOrder)
+	if p.groupingFactor > 1 {
+		bootstrapOrder |= 1 << 7
+	}
+	if nWrite, err = w.Write([]byte{bootstrapOrder}); err != nil {
 		return n + int64(nWrite), err
 	}
 	n += int64(nWrite)
 
+	if p.groupingFactor > 1 {
+		groupingFactor := p.groupingFactor
+		binary.BigEndian.PutUint64(buf[:], uint64(groupingFactor))
+		if nWrite, err = w.Write(buf[:]); err != nil {
+			return n + int64(nWrite), err
+		}
+		n += int64(nWrite)
+	}
+
 	if n < int64(p.ByteSize()) {
 		return n, io.ErrShortWrite
 	}
@@ -887,7 +978,17 @@ func (p *Parameters[T]) ReadFrom(r io.Reader) (n int64, err error) {
 		return n + int64(nRead), err
 	}
 	n += int64(nRead)
-	bootstrapOrder := BootstrapOrder(buf[0])
+	bootstrapOrder := BootstrapOrder(buf[0] &^ (1 << 7))
+	isGroupingFactorPresent := buf[0]>>7 == 1
+
+	groupingFactor := 1
+	if isGroupingFactorPresent {
+		if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+			return n + int64(nRead), err
+		}
+		n += int64(nRead)
+		groupingFactor = int(binary.BigEndian.Uint64(buf[:]))
+	}
 
 	*p = ParametersLiteral[T]{
 		LWEDimension: lweDimension,
@@ -898,7 +999,8 @@ func (p *Parameters[T]) ReadFrom(r io.Reader) (n int64, err error) {
 		LWEStdDev:  lweStdDev,
 		GLWEStdDev: glweStdDev,
 
-		BlockSize: blockSize,
+		BlockSize:      blockSize,
+		GroupingFactor: groupingFactor,
 
 		MessageModulus: messageModulus,
 
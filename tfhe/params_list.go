@@ -258,4 +258,66 @@ var (
 
 		BootstrapOrder: OrderKeySwitchBlindRotate,
 	}
+
+	// ParamsUint2MultiBit is a parameter set with 2 bits of message space,
+	// using multi-bit blind rotation with GroupingFactor 2.
+	// Multi-bit blind rotation saves FFTs, but not Fourier domain multiplications,
+	// whose number grows quadratically in GLWERank. Hence GLWERank is 1.
+	ParamsUint2MultiBit = ParametersLiteral[uint64]{
+		LWEDimension: 822,
+		GLWERank:     1,
+		PolyRank:     2048,
+		LUTSize:      2048,
+
+		LWEStdDev:  0.00000251676160959795544987084234,
+		GLWEStdDev: 0.00000000000000022204460492503131,
+
+		GroupingFactor: 2,
+
+		MessageModulus: 1 << 2,
+
+		BlindRotateParams: GadgetParametersLiteral[uint64]{
+			Base:  1 << 20,
+			Level: 1,
+		},
+		KeySwitchParams: GadgetParametersLiteral[uint64]{
+			Base:  1 << 6,
+			Level: 2,
+		},
+
+		BootstrapOrder: OrderKeySwitchBlindRotate,
+	}
+
+	// ParamsUint3MultiBit is a parameter set with 3 bits of message space,
+	// using multi-bit blind rotation with GroupingFactor 2.
+	// LWEDimension is the smallest multiple of 2 and 3 not smaller than that of ParamsUint3,
+	// with the same LWEStdDev and a uniform binary key, so it is at least as secure.
+	// The noise margin is half that of ParamsUint2MultiBit, so keyswitching uses one more level
+	// to quarter its noise variance, and blind rotation uses a larger base
+	// to reduce its decomposition error, which dominates with one level.
+	// The estimated failure probability is 2^-176 for any GroupingFactor.
+	ParamsUint3MultiBit = ParametersLiteral[uint64]{
+		LWEDimension: 822,
+		GLWERank:     1,
+		PolyRank:     2048,
+		LUTSize:      2048,
+
+		LWEStdDev:  0.00000251676160959795544987084234,
+		GLWEStdDev: 0.00000000000000022204460492503131,
+
+		GroupingFactor: 2,
+
+		MessageModulus: 1 << 3,
+
+		BlindRotateParams: GadgetParametersLiteral[uint64]{
+			Base:  1 << 22,
+			Level: 1,
+		},
+		KeySwitchParams: GadgetParametersLiteral[uint64]{
+			Base:  1 << 5,
+			Level: 3,
+		},
+
+		BootstrapOrder: OrderKeySwitchBlindRotate,
+	}
 )
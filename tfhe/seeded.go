@@ -0,0 +1,152 @@
+package tfhe
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/sp301415/tfhe-go/math/csprng"
+	"github.com/sp301415/tfhe-go/math/poly"
+)
+
+// SeedSize is the size of the seeds of seeded ciphertexts and keys in bytes.
+const SeedSize = 32
+
+// sampleSeed samples a new random seed.
+//
+// Panics when reading from crypto/rand fails.
+func sampleSeed() [SeedSize]byte {
+	var seed [SeedSize]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		panic(err)
+	}
+	return seed
+}
+
+// newSeededSampler creates a new [csprng.UniformSampler] for the idx-th component of a seeded object.
+// Each component has its own sampler, so that components can be generated and decompressed independently.
+func newSeededSampler[T TorusInt](seed [SeedSize]byte, idx int) *csprng.UniformSampler[T] {
+	var s [SeedSize + 8]byte
+	copy(s[:], seed[:])
+	binary.BigEndian.PutUint64(s[SeedSize:], uint64(idx))
+	return csprng.NewUniformSamplerWithSeed[T](s[:])
+}
+
+// SeededLWECiphertext is a compressed LWE ciphertext,
+// where the mask is replaced by the seed used to sample it.
+//
+// Seeded ciphertexts can only be created by secret key encryption,
+// and should be decompressed using [SeededLWECiphertext.Decompress] before evaluation.
+type SeededLWECiphertext[T TorusInt] struct {
+	// Seed is the seed of the mask.
+	Seed [SeedSize]byte
+	// Body is the body of the ciphertext.
+	Body T
+
+	lweDimension int
+}
+
+// NewSeededLWECiphertext creates a new [SeededLWECiphertext].
+func NewSeededLWECiphertext[T TorusInt](params Parameters[T]) SeededLWECiphertext[T] {
+	return SeededLWECiphertext[T]{lweDimension: params.DefaultLWEDimension()}
+}
+
+// NewSeededLWECiphertextCustom creates a new [SeededLWECiphertext] with given dimension.
+func NewSeededLWECiphertextCustom[T TorusInt](lweDimension int) SeededLWECiphertext[T] {
+	return SeededLWECiphertext[T]{lweDimension: lweDimension}
+}
+
+// LWEDimension returns the LWEDimension of the decompressed ciphertext.
+func (ct SeededLWECiphertext[T]) LWEDimension() int {
+	return ct.lweDimension
+}
+
+// Copy returns a copy of the ciphertext.
+func (ct SeededLWECiphertext[T]) Copy() SeededLWECiphertext[T] {
+	return ct
+}
+
+// CopyFrom copies values from the ciphertext.
+func (ct *SeededLWECiphertext[T]) CopyFrom(ctIn SeededLWECiphertext[T]) {
+	*ct = ctIn
+}
+
+// Clear clears the ciphertext.
+func (ct *SeededLWECiphertext[T]) Clear() {
+	ct.Seed = [SeedSize]byte{}
+	ct.Body = 0
+}
+
+// Decompress returns the decompressed LWE ciphertext.
+func (ct SeededLWECiphertext[T]) Decompress() LWECiphertext[T] {
+	ctOut := NewLWECiphertextCustom[T](ct.lweDimension)
+	ct.DecompressTo(ctOut)
+	return ctOut
+}
+
+// DecompressTo decompresses the ciphertext and writes it to ctOut.
+func (ct SeededLWECiphertext[T]) DecompressTo(ctOut LWECiphertext[T]) {
+	ctOut.Value[0] = ct.Body
+	newSeededSampler[T](ct.Seed, 0).SampleVecTo(ctOut.Value[1:])
+}
+
+// SeededGLWECiphertext is a compressed GLWE ciphertext,
+// where the mask is replaced by the seed used to sample it.
+//
+// Seeded ciphertexts can only be created by secret key encryption,
+// and should be decompressed using [SeededGLWECiphertext.Decompress] before evaluation.
+type SeededGLWECiphertext[T TorusInt] struct {
+	// Seed is the seed of the mask.
+	Seed [SeedSize]byte
+	// Body is the body of the ciphertext.
+	Body poly.Poly[T]
+
+	glweRank int
+}
+
+// NewSeededGLWECiphertext creates a new [SeededGLWECiphertext].
+func NewSeededGLWECiphertext[T TorusInt](params Parameters[T]) SeededGLWECiphertext[T] {
+	return SeededGLWECiphertext[T]{Body: poly.NewPoly[T](params.polyRank), glweRank: params.glweRank}
+}
+
+// NewSeededGLWECiphertextCustom creates a new [SeededGLWECiphertext] with given dimension and polyRank.
+func NewSeededGLWECiphertextCustom[T TorusInt](glweRank, polyRank int) SeededGLWECiphertext[T] {
+	return SeededGLWECiphertext[T]{Body: poly.NewPoly[T](polyRank), glweRank: glweRank}
+}
+
+// GLWERank returns the GLWERank of the decompressed ciphertext.
+func (ct SeededGLWECiphertext[T]) GLWERank() int {
+	return ct.glweRank
+}
+
+// Copy returns a copy of the ciphertext.
+func (ct SeededGLWECiphertext[T]) Copy() SeededGLWECiphertext[T] {
+	return SeededGLWECiphertext[T]{Seed: ct.Seed, Body: ct.Body.Copy(), glweRank: ct.glweRank}
+}
+
+// CopyFrom copies values from the ciphertext.
+func (ct *SeededGLWECiphertext[T]) CopyFrom(ctIn SeededGLWECiphertext[T]) {
+	ct.Seed = ctIn.Seed
+	ct.Body.CopyFrom(ctIn.Body)
+}
+
+// Clear clears the ciphertext.
+func (ct *SeededGLWECiphertext[T]) Clear() {
+	ct.Seed = [SeedSize]byte{}
+	ct.Body.Clear()
+}
+
+// Decompress returns the decompressed GLWE ciphertext.
+func (ct SeededGLWECiphertext[T]) Decompress() GLWECiphertext[T] {
+	ctOut := NewGLWECiphertextCustom[T](ct.glweRank, ct.Body.Rank())
+	ct.DecompressTo(ctOut)
+	return ctOut
+}
+
+// DecompressTo decompresses the ciphertext and writes it to ctOut.
+func (ct SeededGLWECiphertext[T]) DecompressTo(ctOut GLWECiphertext[T]) {
+	ctOut.Value[0].CopyFrom(ct.Body)
+	s := newSeededSampler[T](ct.Seed, 0)
+	for i := 0; i < ct.glweRank; i++ {
+		s.SamplePolyTo(ctOut.Value[i+1])
+	}
+}
@@ -0,0 +1,82 @@
+package tfhe
+
+import (
+	"github.com/sp301415/tfhe-go/math/csprng"
+	"github.com/sp301415/tfhe-go/math/vec"
+)
+
+// EncryptSeededLWE encodes and encrypts integer message to seeded LWE ciphertext.
+func (e *Encryptor[T]) EncryptSeededLWE(message int) SeededLWECiphertext[T] {
+	return e.EncryptSeededLWEPlaintext(e.EncodeLWE(message))
+}
+
+// EncryptSeededLWETo encodes and encrypts integer message to seeded LWE ciphertext and writes it to ctOut.
+func (e *Encryptor[T]) EncryptSeededLWETo(ctOut *SeededLWECiphertext[T], message int) {
+	e.EncryptSeededLWEPlaintextTo(ctOut, e.EncodeLWE(message))
+}
+
+// EncryptSeededLWEPlaintext encrypts LWE plaintext to seeded LWE ciphertext.
+func (e *Encryptor[T]) EncryptSeededLWEPlaintext(pt LWEPlaintext[T]) SeededLWECiphertext[T] {
+	ctOut := NewSeededLWECiphertext(e.Params)
+	e.EncryptSeededLWEPlaintextTo(&ctOut, pt)
+	return ctOut
+}
+
+// EncryptSeededLWEPlaintextTo encrypts LWE plaintext to seeded LWE ciphertext and writes it to ctOut.
+// A new seed is sampled for every encryption.
+func (e *Encryptor[T]) EncryptSeededLWEPlaintextTo(ctOut *SeededLWECiphertext[T], pt LWEPlaintext[T]) {
+	ctOut.Seed = sampleSeed()
+
+	e.buf.ctLWE.Value[0] = pt.Value
+	e.encryptLWEBodyWithSampler(e.buf.ctLWE, newSeededSampler[T](ctOut.Seed, 0))
+	ctOut.Body = e.buf.ctLWE.Value[0]
+}
+
+// EncryptSeededGLWE encodes and encrypts integer messages to seeded GLWE ciphertext.
+func (e *Encryptor[T]) EncryptSeededGLWE(messages []int) SeededGLWECiphertext[T] {
+	ctOut := NewSeededGLWECiphertext(e.Params)
+	e.EncryptSeededGLWETo(&ctOut, messages)
+	return ctOut
+}
+
+// EncryptSeededGLWETo encodes and encrypts integer messages to seeded GLWE ciphertext and writes it to ctOut.
+func (e *Encryptor[T]) EncryptSeededGLWETo(ctOut *SeededGLWECiphertext[T], messages []int) {
+	e.EncodeGLWETo(e.buf.ptGLWE, messages)
+	e.EncryptSeededGLWEPlaintextTo(ctOut, e.buf.ptGLWE)
+}
+
+// EncryptSeededGLWEPlaintext encrypts GLWE plaintext to seeded GLWE ciphertext.
+func (e *Encryptor[T]) EncryptSeededGLWEPlaintext(pt GLWEPlaintext[T]) SeededGLWECiphertext[T] {
+	ctOut := NewSeededGLWECiphertext(e.Params)
+	e.EncryptSeededGLWEPlaintextTo(&ctOut, pt)
+	return ctOut
+}
+
+// EncryptSeededGLWEPlaintextTo encrypts GLWE plaintext to seeded GLWE ciphertext and writes it to ctOut.
+// A new seed is sampled for every encryption.
+func (e *Encryptor[T]) EncryptSeededGLWEPlaintextTo(ctOut *SeededGLWECiphertext[T], pt GLWEPlaintext[T]) {
+	ctOut.Seed = sampleSeed()
+
+	e.buf.ctGLWE.Value[0].CopyFrom(pt.Value)
+	e.encryptGLWEBodyWithSampler(e.buf.ctGLWE, newSeededSampler[T](ctOut.Seed, 0))
+	ctOut.Body.CopyFrom(e.buf.ctGLWE.Value[0])
+}
+
+// encryptLWEBodyWithSampler is equivalent to [Encryptor.EncryptLWEBody],
+// but samples the mask from s.
+func (e *Encryptor[T]) encryptLWEBodyWithSampler(ct LWECiphertext[T], s *csprng.UniformSampler[T]) {
+	s.SampleVecTo(ct.Value[1:])
+	ct.Value[0] += -vec.Dot(ct.Value[1:], e.DefaultLWESecretKey().Value)
+	ct.Value[0] += e.GaussianSampler.Sample(e.Params.DefaultLWEStdDevQ())
+}
+
+// encryptGLWEBodyWithSampler is equivalent to [Encryptor.EncryptGLWEBody],
+// but samples the mask from s.
+func (e *Encryptor[T]) encryptGLWEBodyWithSampler(ct GLWECiphertext[T], s *csprng.UniformSampler[T]) {
+	for i := 0; i < e.Params.glweRank; i++ {
+		s.SamplePolyTo(ct.Value[i+1])
+		e.PolyEvaluator.ShortFFTPolyMulSubPolyTo(ct.Value[0], ct.Value[i+1], e.SecretKey.FFTGLWEKey.Value[i])
+	}
+
+	e.GaussianSampler.SamplePolyAddTo(ct.Value[0], e.Params.GLWEStdDevQ())
+}
@@ -0,0 +1,350 @@
+package tfhe
+
+import (
+	"github.com/sp301415/tfhe-go/math/poly"
+)
+
+// SeededEvaluationKey is a compressed [EvaluationKey],
+// where the masks of all ciphertexts are replaced by seeds.
+// Use [SeededEvaluationKey.Decompress] to get the evaluation key.
+type SeededEvaluationKey[T TorusInt] struct {
+	// BlindRotateKey is a seeded blindrotate key.
+	BlindRotateKey SeededBlindRotateKey[T]
+	// KeySwitchKey is a seeded keyswitch key switching LWELargeKey -> LWEKey.
+	KeySwitchKey SeededLWEKeySwitchKey[T]
+}
+
+// NewSeededEvaluationKey creates a new [SeededEvaluationKey].
+func NewSeededEvaluationKey[T TorusInt](params Parameters[T]) SeededEvaluationKey[T] {
+	return SeededEvaluationKey[T]{
+		BlindRotateKey: NewSeededBlindRotateKey(params),
+		KeySwitchKey:   NewSeededLWEKeySwitchKeyCustom(params.glweDimension-params.lweDimension, params.lweDimension, params.keySwitchParams),
+	}
+}
+
+// Copy returns a copy of the key.
+func (evk SeededEvaluationKey[T]) Copy() SeededEvaluationKey[T] {
+	return SeededEvaluationKey[T]{
+		BlindRotateKey: evk.BlindRotateKey.Copy(),
+		KeySwitchKey:   evk.KeySwitchKey.Copy(),
+	}
+}
+
+// CopyFrom copies values from key.
+func (evk *SeededEvaluationKey[T]) CopyFrom(evkIn SeededEvaluationKey[T]) {
+	evk.BlindRotateKey.CopyFrom(evkIn.BlindRotateKey)
+	evk.KeySwitchKey.CopyFrom(evkIn.KeySwitchKey)
+}
+
+// Clear clears the key.
+func (evk *SeededEvaluationKey[T]) Clear() {
+	evk.BlindRotateKey.Clear()
+	evk.KeySwitchKey.Clear()
+}
+
+// Decompress returns the decompressed evaluation key.
+func (evk SeededEvaluationKey[T]) Decompress() EvaluationKey[T] {
+	return EvaluationKey[T]{
+		BlindRotateKey: evk.BlindRotateKey.Decompress(),
+		KeySwitchKey:   evk.KeySwitchKey.Decompress(),
+	}
+}
+
+// SeededBlindRotateKey is a compressed [BlindRotateKey],
+// where the masks of all GLWE ciphertexts are replaced by seeds.
+// Unlike BlindRotateKey, the bodies are stored in the standard domain.
+type SeededBlindRotateKey[T TorusInt] struct {
+	GadgetParams GadgetParameters[T]
+
+	// Seed is the seed of the masks.
+	// The masks of Value[i] are sampled from the i-th sampler derived from Seed.
+	Seed [SeedSize]byte
+
+	// Value has length BlindRotateKeyCount.
+	// Value[i][j][k] is the body of the k-th GLWE ciphertext
+	// in the j-th row of the i-th GGSW ciphertext.
+	Value [][][]poly.Poly[T]
+}
+
+// NewSeededBlindRotateKey creates a new [SeededBlindRotateKey].
+func NewSeededBlindRotateKey[T TorusInt](params Parameters[T]) SeededBlindRotateKey[T] {
+	return NewSeededBlindRotateKeyCustom(params.BlindRotateKeyCount(), params.glweRank, params.polyRank, params.blindRotateParams)
+}
+
+// NewSeededBlindRotateKeyCustom creates a new [SeededBlindRotateKey] with custom parameters.
+// For multi-bit blind rotation, lweDimension should be the number of GGSW ciphertexts in the key.
+func NewSeededBlindRotateKeyCustom[T TorusInt](lweDimension, glweRank, polyRank int, gadgetParams GadgetParameters[T]) SeededBlindRotateKey[T] {
+	brk := make([][][]poly.Poly[T], lweDimension)
+	for i := range brk {
+		brk[i] = make([][]poly.Poly[T], glweRank+1)
+		for j := range brk[i] {
+			brk[i][j] = make([]poly.Poly[T], gadgetParams.level)
+			for k := range brk[i][j] {
+				brk[i][j][k] = poly.NewPoly[T](polyRank)
+			}
+		}
+	}
+	return SeededBlindRotateKey[T]{Value: brk, GadgetParams: gadgetParams}
+}
+
+// Copy returns a copy of the key.
+func (brk SeededBlindRotateKey[T]) Copy() SeededBlindRotateKey[T] {
+	brkCopy := make([][][]poly.Poly[T], len(brk.Value))
+	for i := range brk.Value {
+		brkCopy[i] = make([][]poly.Poly[T], len(brk.Value[i]))
+		for j := range brk.Value[i] {
+			brkCopy[i][j] = make([]poly.Poly[T], len(brk.Value[i][j]))
+			for k := range brk.Value[i][j] {
+				brkCopy[i][j][k] = brk.Value[i][j][k].Copy()
+			}
+		}
+	}
+	return SeededBlindRotateKey[T]{Value: brkCopy, Seed: brk.Seed, GadgetParams: brk.GadgetParams}
+}
+
+// CopyFrom copies values from key.
+func (brk *SeededBlindRotateKey[T]) CopyFrom(brkIn SeededBlindRotateKey[T]) {
+	for i := range brk.Value {
+		for j := range brk.Value[i] {
+			for k := range brk.Value[i][j] {
+				brk.Value[i][j][k].CopyFrom(brkIn.Value[i][j][k])
+			}
+		}
+	}
+	brk.Seed = brkIn.Seed
+	brk.GadgetParams = brkIn.GadgetParams
+}
+
+// Clear clears the key.
+func (brk *SeededBlindRotateKey[T]) Clear() {
+	for i := range brk.Value {
+		for j := range brk.Value[i] {
+			for k := range brk.Value[i][j] {
+				brk.Value[i][j][k].Clear()
+			}
+		}
+	}
+	brk.Seed = [SeedSize]byte{}
+}
+
+// Decompress returns the decompressed blindrotate key.
+func (brk SeededBlindRotateKey[T]) Decompress() BlindRotateKey[T] {
+	glweRank := len(brk.Value[0]) - 1
+	polyRank := brk.Value[0][0][0].Rank()
+
+	brkOut := NewBlindRotateKeyCustom(len(brk.Value), glweRank, polyRank, brk.GadgetParams)
+	brk.DecompressTo(brkOut)
+	return brkOut
+}
+
+// DecompressTo decompresses the key and writes it to brkOut.
+func (brk SeededBlindRotateKey[T]) DecompressTo(brkOut BlindRotateKey[T]) {
+	glweRank := len(brk.Value[0]) - 1
+	polyRank := brk.Value[0][0][0].Rank()
+
+	transformer := NewGLWETransformer[T](polyRank)
+	ct := NewGLWECiphertextCustom[T](glweRank, polyRank)
+	for i := range brk.Value {
+		s := newSeededSampler[T](brk.Seed, i)
+		for j := range brk.Value[i] {
+			for k := range brk.Value[i][j] {
+				ct.Value[0].CopyFrom(brk.Value[i][j][k])
+				for l := 0; l < glweRank; l++ {
+					s.SamplePolyTo(ct.Value[l+1])
+				}
+				transformer.FwdFFTGLWECiphertextTo(brkOut.Value[i].Value[j].Value[k], ct)
+			}
+		}
+	}
+}
+
+// SeededLWEKeySwitchKey is a compressed [LWEKeySwitchKey],
+// where the masks of all LWE ciphertexts are replaced by seeds.
+type SeededLWEKeySwitchKey[T TorusInt] struct {
+	GadgetParams GadgetParameters[T]
+
+	// Seed is the seed of the masks.
+	// The masks of Value[i] are sampled from the i-th sampler derived from Seed.
+	Seed [SeedSize]byte
+
+	// Value has length InputLWEDimension.
+	// Value[i][j] is the body of the j-th LWE ciphertext of the i-th Lev ciphertext.
+	Value [][]T
+
+	outputDimension int
+}
+
+// NewSeededLWEKeySwitchKey creates a new [SeededLWEKeySwitchKey].
+func NewSeededLWEKeySwitchKey[T TorusInt](params Parameters[T], inputDimension int, gadgetParams GadgetParameters[T]) SeededLWEKeySwitchKey[T] {
+	return NewSeededLWEKeySwitchKeyCustom(inputDimension, params.DefaultLWEDimension(), gadgetParams)
+}
+
+// NewSeededLWEKeySwitchKeyCustom creates a new [SeededLWEKeySwitchKey] with custom parameters.
+func NewSeededLWEKeySwitchKeyCustom[T TorusInt](inputDimension, outputDimension int, gadgetParams GadgetParameters[T]) SeededLWEKeySwitchKey[T] {
+	ksk := make([][]T, inputDimension)
+	for i := range ksk {
+		ksk[i] = make([]T, gadgetParams.level)
+	}
+	return SeededLWEKeySwitchKey[T]{Value: ksk, GadgetParams: gadgetParams, outputDimension: outputDimension}
+}
+
+// InputLWEDimension returns the input LWEDimension of this key.
+func (ksk SeededLWEKeySwitchKey[T]) InputLWEDimension() int {
+	return len(ksk.Value)
+}
+
+// OutputLWEDimension returns the output LWEDimension of this key.
+func (ksk SeededLWEKeySwitchKey[T]) OutputLWEDimension() int {
+	return ksk.outputDimension
+}
+
+// Copy returns a copy of the key.
+func (ksk SeededLWEKeySwitchKey[T]) Copy() SeededLWEKeySwitchKey[T] {
+	kskCopy := make([][]T, len(ksk.Value))
+	for i := range ksk.Value {
+		kskCopy[i] = make([]T, len(ksk.Value[i]))
+		copy(kskCopy[i], ksk.Value[i])
+	}
+	return SeededLWEKeySwitchKey[T]{Value: kskCopy, Seed: ksk.Seed, GadgetParams: ksk.GadgetParams, outputDimension: ksk.outputDimension}
+}
+
+// CopyFrom copies values from key.
+func (ksk *SeededLWEKeySwitchKey[T]) CopyFrom(kskIn SeededLWEKeySwitchKey[T]) {
+	for i := range ksk.Value {
+		copy(ksk.Value[i], kskIn.Value[i])
+	}
+	ksk.Seed = kskIn.Seed
+	ksk.GadgetParams = kskIn.GadgetParams
+}
+
+// Clear clears the key.
+func (ksk *SeededLWEKeySwitchKey[T]) Clear() {
+	for i := range ksk.Value {
+		for j := range ksk.Value[i] {
+			ksk.Value[i][j] = 0
+		}
+	}
+	ksk.Seed = [SeedSize]byte{}
+}
+
+// Decompress returns the decompressed keyswitch key.
+func (ksk SeededLWEKeySwitchKey[T]) Decompress() LWEKeySwitchKey[T] {
+	kskOut := NewLWEKeySwitchKeyCustom(len(ksk.Value), ksk.outputDimension, ksk.GadgetParams)
+	ksk.DecompressTo(kskOut)
+	return kskOut
+}
+
+// DecompressTo decompresses the key and writes it to kskOut.
+func (ksk SeededLWEKeySwitchKey[T]) DecompressTo(kskOut LWEKeySwitchKey[T]) {
+	for i := range ksk.Value {
+		s := newSeededSampler[T](ksk.Seed, i)
+		for j := range ksk.Value[i] {
+			kskOut.Value[i].Value[j].Value[0] = ksk.Value[i][j]
+			s.SampleVecTo(kskOut.Value[i].Value[j].Value[1:])
+		}
+	}
+}
+
+// SeededGLWEKeySwitchKey is a compressed [GLWEKeySwitchKey],
+// where the masks of all GLWE ciphertexts are replaced by seeds.
+// Unlike GLWEKeySwitchKey, the bodies are stored in the standard domain.
+type SeededGLWEKeySwitchKey[T TorusInt] struct {
+	GadgetParams GadgetParameters[T]
+
+	// Seed is the seed of the masks.
+	// The masks of Value[i] are sampled from the i-th sampler derived from Seed.
+	Seed [SeedSize]byte
+
+	// Value has length InputGLWERank.
+	// Value[i][j] is the body of the j-th GLWE ciphertext of the i-th GLev ciphertext.
+	Value [][]poly.Poly[T]
+
+	outputGLWERank int
+}
+
+// NewSeededGLWEKeySwitchKey creates a new [SeededGLWEKeySwitchKey].
+func NewSeededGLWEKeySwitchKey[T TorusInt](params Parameters[T], inputGLWERank int, gadgetParams GadgetParameters[T]) SeededGLWEKeySwitchKey[T] {
+	return NewSeededGLWEKeySwitchKeyCustom(inputGLWERank, params.glweRank, params.polyRank, gadgetParams)
+}
+
+// NewSeededGLWEKeySwitchKeyCustom creates a new [SeededGLWEKeySwitchKey] with custom parameters.
+func NewSeededGLWEKeySwitchKeyCustom[T TorusInt](inputGLWERank, outputGLWERank, polyRank int, gadgetParams GadgetParameters[T]) SeededGLWEKeySwitchKey[T] {
+	ksk := make([][]poly.Poly[T], inputGLWERank)
+	for i := range ksk {
+		ksk[i] = make([]poly.Poly[T], gadgetParams.level)
+		for j := range ksk[i] {
+			ksk[i][j] = poly.NewPoly[T](polyRank)
+		}
+	}
+	return SeededGLWEKeySwitchKey[T]{Value: ksk, GadgetParams: gadgetParams, outputGLWERank: outputGLWERank}
+}
+
+// InputGLWERank returns the input GLWERank of this key.
+func (ksk SeededGLWEKeySwitchKey[T]) InputGLWERank() int {
+	return len(ksk.Value)
+}
+
+// OutputGLWERank returns the output GLWERank of this key.
+func (ksk SeededGLWEKeySwitchKey[T]) OutputGLWERank() int {
+	return ksk.outputGLWERank
+}
+
+// Copy returns a copy of the key.
+func (ksk SeededGLWEKeySwitchKey[T]) Copy() SeededGLWEKeySwitchKey[T] {
+	kskCopy := make([][]poly.Poly[T], len(ksk.Value))
+	for i := range ksk.Value {
+		kskCopy[i] = make([]poly.Poly[T], len(ksk.Value[i]))
+		for j := range ksk.Value[i] {
+			kskCopy[i][j] = ksk.Value[i][j].Copy()
+		}
+	}
+	return SeededGLWEKeySwitchKey[T]{Value: kskCopy, Seed: ksk.Seed, GadgetParams: ksk.GadgetParams, outputGLWERank: ksk.outputGLWERank}
+}
+
+// CopyFrom copies values from key.
+func (ksk *SeededGLWEKeySwitchKey[T]) CopyFrom(kskIn SeededGLWEKeySwitchKey[T]) {
+	for i := range ksk.Value {
+		for j := range ksk.Value[i] {
+			ksk.Value[i][j].CopyFrom(kskIn.Value[i][j])
+		}
+	}
+	ksk.Seed = kskIn.Seed
+	ksk.GadgetParams = kskIn.GadgetParams
+}
+
+// Clear clears the key.
+func (ksk *SeededGLWEKeySwitchKey[T]) Clear() {
+	for i := range ksk.Value {
+		for j := range ksk.Value[i] {
+			ksk.Value[i][j].Clear()
+		}
+	}
+	ksk.Seed = [SeedSize]byte{}
+}
+
+// Decompress returns the decompressed keyswitch key.
+func (ksk SeededGLWEKeySwitchKey[T]) Decompress() GLWEKeySwitchKey[T] {
+	polyRank := ksk.Value[0][0].Rank()
+
+	kskOut := NewGLWEKeySwitchKeyCustom(len(ksk.Value), ksk.outputGLWERank, polyRank, ksk.GadgetParams)
+	ksk.DecompressTo(kskOut)
+	return kskOut
+}
+
+// DecompressTo decompresses the key and writes it to kskOut.
+func (ksk SeededGLWEKeySwitchKey[T]) DecompressTo(kskOut GLWEKeySwitchKey[T]) {
+	polyRank := ksk.Value[0][0].Rank()
+
+	transformer := NewGLWETransformer[T](polyRank)
+	ct := NewGLWECiphertextCustom[T](ksk.outputGLWERank, polyRank)
+	for i := range ksk.Value {
+		s := newSeededSampler[T](ksk.Seed, i)
+		for j := range ksk.Value[i] {
+			ct.Value[0].CopyFrom(ksk.Value[i][j])
+			for k := 0; k < ksk.outputGLWERank; k++ {
+				s.SamplePolyTo(ct.Value[k+1])
+			}
+			transformer.FwdFFTGLWECiphertextTo(kskOut.Value[i].Value[j], ct)
+		}
+	}
+}
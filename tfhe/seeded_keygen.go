@@ -0,0 +1,171 @@
+package tfhe
+
+import (
+	"runtime"
+
+	"github.com/sp301415/tfhe-go/internal/parallel"
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/math/vec"
+)
+
+// GenSeededEvalKey samples a new seeded evaluation key for bootstrapping.
+//
+// This can take a long time.
+// Use [Encryptor.GenSeededEvalKeyParallel] for better key generation performance.
+func (e *Encryptor[T]) GenSeededEvalKey() SeededEvaluationKey[T] {
+	return SeededEvaluationKey[T]{
+		BlindRotateKey: e.GenSeededBlindRotateKey(),
+		KeySwitchKey:   e.GenSeededDefaultKeySwitchKey(),
+	}
+}
+
+// GenSeededEvalKeyParallel samples a new seeded evaluation key for bootstrapping in parallel.
+func (e *Encryptor[T]) GenSeededEvalKeyParallel() SeededEvaluationKey[T] {
+	return SeededEvaluationKey[T]{
+		BlindRotateKey: e.GenSeededBlindRotateKeyParallel(),
+		KeySwitchKey:   e.GenSeededDefaultKeySwitchKeyParallel(),
+	}
+}
+
+// GenSeededBlindRotateKey samples a new seeded bootstrapping key.
+//
+// This can take a long time.
+// Use [Encryptor.GenSeededBlindRotateKeyParallel] for better key generation performance.
+func (e *Encryptor[T]) GenSeededBlindRotateKey() SeededBlindRotateKey[T] {
+	brk := NewSeededBlindRotateKey(e.Params)
+	brk.Seed = sampleSeed()
+
+	for i := 0; i < len(brk.Value); i++ {
+		e.genSeededBlindRotateKeyAt(brk, i)
+	}
+
+	return brk
+}
+
+// GenSeededBlindRotateKeyParallel samples a new seeded bootstrapping key in parallel.
+func (e *Encryptor[T]) GenSeededBlindRotateKeyParallel() SeededBlindRotateKey[T] {
+	brk := NewSeededBlindRotateKey(e.Params)
+	brk.Seed = sampleSeed()
+
+	workSize := len(brk.Value)
+	chunkCount := num.Min(runtime.NumCPU(), num.Sqrt(workSize))
+
+	encryptorPool := make([]*Encryptor[T], chunkCount)
+	for i := range encryptorPool {
+		encryptorPool[i] = e.SafeCopy()
+	}
+
+	parallel.Run(chunkCount, workSize, func(worker, i int) {
+		encryptorPool[worker].genSeededBlindRotateKeyAt(brk, i)
+	})
+
+	return brk
+}
+
+// genSeededBlindRotateKeyAt samples the i-th GGSW ciphertext of the seeded bootstrapping key.
+func (e *Encryptor[T]) genSeededBlindRotateKeyAt(brk SeededBlindRotateKey[T], i int) {
+	s := newSeededSampler[T](brk.Seed, i)
+
+	m := e.blindRotateKeyMessage(i)
+	for j := 0; j < e.Params.glweRank+1; j++ {
+		if j == 0 {
+			e.buf.ptGGSW.Clear()
+			e.buf.ptGGSW.Coeffs[0] = m
+		} else {
+			e.PolyEvaluator.ScalarMulPolyTo(e.buf.ptGGSW, e.SecretKey.GLWEKey.Value[j-1], m)
+		}
+		for k := 0; k < brk.GadgetParams.level; k++ {
+			e.PolyEvaluator.ScalarMulPolyTo(e.buf.ctGLWE.Value[0], e.buf.ptGGSW, brk.GadgetParams.BaseQ(k))
+			e.encryptGLWEBodyWithSampler(e.buf.ctGLWE, s)
+			brk.Value[i][j][k].CopyFrom(e.buf.ctGLWE.Value[0])
+		}
+	}
+}
+
+// GenSeededDefaultKeySwitchKey samples a new seeded keyswitch key LWELargeKey -> LWEKey,
+// used for bootstrapping.
+//
+// This can take a long time.
+// Use [Encryptor.GenSeededDefaultKeySwitchKeyParallel] for better key generation performance.
+func (e *Encryptor[T]) GenSeededDefaultKeySwitchKey() SeededLWEKeySwitchKey[T] {
+	ksk := NewSeededLWEKeySwitchKeyCustom(e.Params.glweDimension-e.Params.lweDimension, e.Params.lweDimension, e.Params.keySwitchParams)
+	ksk.Seed = sampleSeed()
+
+	for i := 0; i < ksk.InputLWEDimension(); i++ {
+		e.genSeededDefaultKeySwitchKeyAt(ksk, i)
+	}
+
+	return ksk
+}
+
+// GenSeededDefaultKeySwitchKeyParallel samples a new seeded keyswitch key LWELargeKey -> LWEKey in parallel,
+// used for bootstrapping.
+func (e *Encryptor[T]) GenSeededDefaultKeySwitchKeyParallel() SeededLWEKeySwitchKey[T] {
+	ksk := NewSeededLWEKeySwitchKeyCustom(e.Params.glweDimension-e.Params.lweDimension, e.Params.lweDimension, e.Params.keySwitchParams)
+	ksk.Seed = sampleSeed()
+
+	workSize := ksk.InputLWEDimension()
+	chunkCount := num.Min(runtime.NumCPU(), num.Sqrt(workSize))
+
+	encryptorPool := make([]*Encryptor[T], chunkCount)
+	for i := range encryptorPool {
+		encryptorPool[i] = e.SafeCopy()
+	}
+
+	parallel.Run(chunkCount, workSize, func(worker, i int) {
+		encryptorPool[worker].genSeededDefaultKeySwitchKeyAt(ksk, i)
+	})
+
+	return ksk
+}
+
+// genSeededDefaultKeySwitchKeyAt samples the i-th Lev ciphertext of the seeded keyswitch key for bootstrapping.
+func (e *Encryptor[T]) genSeededDefaultKeySwitchKeyAt(ksk SeededLWEKeySwitchKey[T], i int) {
+	s := newSeededSampler[T](ksk.Seed, i)
+
+	// DefaultLWEDimension is either LWEDimension or GLWEDimension,
+	// so ctLWE is large enough to hold the mask.
+	mask := e.buf.ctLWE.Value[1 : e.Params.lweDimension+1]
+
+	skIn := e.SecretKey.LWELargeKey.Value[e.Params.lweDimension:]
+	for j := 0; j < ksk.GadgetParams.level; j++ {
+		ksk.Value[i][j] = skIn[i] << ksk.GadgetParams.LogBaseQ(j)
+		s.SampleVecTo(mask)
+		ksk.Value[i][j] += -vec.Dot(mask, e.SecretKey.LWEKey.Value)
+		ksk.Value[i][j] += e.GaussianSampler.Sample(e.Params.LWEStdDevQ())
+	}
+}
+
+// GenSeededLWEKeySwitchKey samples a new seeded keyswitch key skIn -> LWEKey.
+func (e *Encryptor[T]) GenSeededLWEKeySwitchKey(skIn LWESecretKey[T], gadgetParams GadgetParameters[T]) SeededLWEKeySwitchKey[T] {
+	ksk := NewSeededLWEKeySwitchKey(e.Params, len(skIn.Value), gadgetParams)
+	ksk.Seed = sampleSeed()
+
+	for i := 0; i < ksk.InputLWEDimension(); i++ {
+		s := newSeededSampler[T](ksk.Seed, i)
+		for j := 0; j < gadgetParams.level; j++ {
+			e.buf.ctLWE.Value[0] = skIn.Value[i] << gadgetParams.LogBaseQ(j)
+			e.encryptLWEBodyWithSampler(e.buf.ctLWE, s)
+			ksk.Value[i][j] = e.buf.ctLWE.Value[0]
+		}
+	}
+
+	return ksk
+}
+
+// GenSeededGLWEKeySwitchKey samples a new seeded keyswitch key skIn -> GLWEKey.
+func (e *Encryptor[T]) GenSeededGLWEKeySwitchKey(skIn GLWESecretKey[T], gadgetParams GadgetParameters[T]) SeededGLWEKeySwitchKey[T] {
+	ksk := NewSeededGLWEKeySwitchKey(e.Params, len(skIn.Value), gadgetParams)
+	ksk.Seed = sampleSeed()
+
+	for i := 0; i < ksk.InputGLWERank(); i++ {
+		s := newSeededSampler[T](ksk.Seed, i)
+		for j := 0; j < gadgetParams.level; j++ {
+			e.PolyEvaluator.ScalarMulPolyTo(e.buf.ctGLWE.Value[0], skIn.Value[i], gadgetParams.BaseQ(j))
+			e.encryptGLWEBodyWithSampler(e.buf.ctGLWE, s)
+			ksk.Value[i][j].CopyFrom(e.buf.ctGLWE.Value[0])
+		}
+	}
+
+	return ksk
+}
@@ -0,0 +1,888 @@
+package tfhe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/sp301415/tfhe-go/math/num"
+)
+
+// ByteSize returns the size of the ciphertext in bytes.
+func (ct SeededLWECiphertext[T]) ByteSize() int {
+	return 8 + SeedSize + num.ByteSizeT[T]()
+}
+
+// headerWriteTo writes the header.
+func (ct SeededLWECiphertext[T]) headerWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ct.lweDimension))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	if nWrite, err = w.Write(ct.Seed[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// valueWriteTo writes the value.
+func (ct SeededLWECiphertext[T]) valueWriteTo(w io.Writer) (n int64, err error) {
+	return vecWriteTo([]T{ct.Body}, w)
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] LWEDimension
+//	[32] Seed
+//	    Body
+func (ct SeededLWECiphertext[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = ct.headerWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = ct.valueWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(ct.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// headerReadFrom reads the header, and initializes the value.
+func (ct *SeededLWECiphertext[T]) headerReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	lweDimension := int(binary.BigEndian.Uint64(buf[:]))
+
+	*ct = NewSeededLWECiphertextCustom[T](lweDimension)
+
+	if nRead, err = io.ReadFull(r, ct.Seed[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+
+	return
+}
+
+// valueReadFrom reads the value.
+func (ct *SeededLWECiphertext[T]) valueReadFrom(r io.Reader) (n int64, err error) {
+	buf := []T{0}
+	if n, err = vecReadFrom(buf, r); err != nil {
+		return
+	}
+	ct.Body = buf[0]
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (ct *SeededLWECiphertext[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = ct.headerReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = ct.valueReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (ct SeededLWECiphertext[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, ct.ByteSize()))
+	_, err = ct.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (ct *SeededLWECiphertext[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := ct.ReadFrom(buf)
+	return err
+}
+
+// ByteSize returns the size of the ciphertext in bytes.
+func (ct SeededGLWECiphertext[T]) ByteSize() int {
+	return 16 + SeedSize + ct.Body.Rank()*num.ByteSizeT[T]()
+}
+
+// headerWriteTo writes the header.
+func (ct SeededGLWECiphertext[T]) headerWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ct.glweRank))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ct.Body.Rank()))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	if nWrite, err = w.Write(ct.Seed[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// valueWriteTo writes the value.
+func (ct SeededGLWECiphertext[T]) valueWriteTo(w io.Writer) (n int64, err error) {
+	return vecWriteTo(ct.Body.Coeffs, w)
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] GLWERank
+//	[8] PolyRank
+//	[32] Seed
+//	    Body
+func (ct SeededGLWECiphertext[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = ct.headerWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = ct.valueWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(ct.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// headerReadFrom reads the header, and initializes the value.
+func (ct *SeededGLWECiphertext[T]) headerReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	glweRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	polyRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	*ct = NewSeededGLWECiphertextCustom[T](glweRank, polyRank)
+
+	if nRead, err = io.ReadFull(r, ct.Seed[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+
+	return
+}
+
+// valueReadFrom reads the value.
+func (ct *SeededGLWECiphertext[T]) valueReadFrom(r io.Reader) (n int64, err error) {
+	return vecReadFrom(ct.Body.Coeffs, r)
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (ct *SeededGLWECiphertext[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = ct.headerReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = ct.valueReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (ct SeededGLWECiphertext[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, ct.ByteSize()))
+	_, err = ct.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (ct *SeededGLWECiphertext[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := ct.ReadFrom(buf)
+	return err
+}
+
+// ByteSize returns the size of the key in bytes.
+func (ksk SeededLWEKeySwitchKey[T]) ByteSize() int {
+	level := ksk.GadgetParams.level
+	inputDimension := len(ksk.Value)
+
+	return 32 + SeedSize + inputDimension*level*num.ByteSizeT[T]()
+}
+
+// headerWriteTo writes the header.
+func (ksk SeededLWEKeySwitchKey[T]) headerWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ksk.GadgetParams.base))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ksk.GadgetParams.level))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(len(ksk.Value)))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ksk.outputDimension))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	if nWrite, err = w.Write(ksk.Seed[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// valueWriteTo writes the value.
+func (ksk SeededLWEKeySwitchKey[T]) valueWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	for i := range ksk.Value {
+		if nWrite, err = vecWriteTo(ksk.Value[i], w); err != nil {
+			return n + nWrite, err
+		}
+		n += nWrite
+	}
+
+	return
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] Base
+//	[8] Level
+//	[8] InputDimension
+//	[8] OutputDimension
+//	[32] Seed
+//	    Value
+func (ksk SeededLWEKeySwitchKey[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = ksk.headerWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = ksk.valueWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(ksk.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// headerReadFrom reads the header, and initializes the value.
+func (ksk *SeededLWEKeySwitchKey[T]) headerReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	base := T(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	level := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	inputDimension := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	outputDimension := int(binary.BigEndian.Uint64(buf[:]))
+
+	*ksk = NewSeededLWEKeySwitchKeyCustom(inputDimension, outputDimension, GadgetParametersLiteral[T]{Base: base, Level: level}.Compile())
+
+	if nRead, err = io.ReadFull(r, ksk.Seed[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+
+	return
+}
+
+// valueReadFrom reads the value.
+func (ksk *SeededLWEKeySwitchKey[T]) valueReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	for i := range ksk.Value {
+		if nRead, err = vecReadFrom(ksk.Value[i], r); err != nil {
+			return n + nRead, err
+		}
+		n += nRead
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (ksk *SeededLWEKeySwitchKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = ksk.headerReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = ksk.valueReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (ksk SeededLWEKeySwitchKey[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, ksk.ByteSize()))
+	_, err = ksk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (ksk *SeededLWEKeySwitchKey[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := ksk.ReadFrom(buf)
+	return err
+}
+
+// ByteSize returns the size of the key in bytes.
+func (ksk SeededGLWEKeySwitchKey[T]) ByteSize() int {
+	level := ksk.GadgetParams.level
+	inputRank := len(ksk.Value)
+	polyRank := ksk.Value[0][0].Rank()
+
+	return 40 + SeedSize + inputRank*level*polyRank*num.ByteSizeT[T]()
+}
+
+// headerWriteTo writes the header.
+func (ksk SeededGLWEKeySwitchKey[T]) headerWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ksk.GadgetParams.base))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ksk.GadgetParams.level))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(len(ksk.Value)))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ksk.outputGLWERank))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(ksk.Value[0][0].Rank()))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	if nWrite, err = w.Write(ksk.Seed[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// valueWriteTo writes the value.
+func (ksk SeededGLWEKeySwitchKey[T]) valueWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	polyRank := ksk.Value[0][0].Rank()
+	buf := make([]byte, polyRank*num.ByteSizeT[T]())
+
+	for i := range ksk.Value {
+		for j := range ksk.Value[i] {
+			if nWrite, err = vecWriteToBuf(ksk.Value[i][j].Coeffs, buf, w); err != nil {
+				return n + nWrite, err
+			}
+			n += nWrite
+		}
+	}
+
+	return
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] Base
+//	[8] Level
+//	[8] InputRank
+//	[8] OutputRank
+//	[8] PolyRank
+//	[32] Seed
+//	    Value
+func (ksk SeededGLWEKeySwitchKey[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = ksk.headerWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = ksk.valueWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(ksk.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// headerReadFrom reads the header, and initializes the value.
+func (ksk *SeededGLWEKeySwitchKey[T]) headerReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	base := T(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	level := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	inputRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	outputRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	polyRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	*ksk = NewSeededGLWEKeySwitchKeyCustom(inputRank, outputRank, polyRank, GadgetParametersLiteral[T]{Base: base, Level: level}.Compile())
+
+	if nRead, err = io.ReadFull(r, ksk.Seed[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+
+	return
+}
+
+// valueReadFrom reads the value.
+func (ksk *SeededGLWEKeySwitchKey[T]) valueReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	polyRank := ksk.Value[0][0].Rank()
+	buf := make([]byte, polyRank*num.ByteSizeT[T]())
+
+	for i := range ksk.Value {
+		for j := range ksk.Value[i] {
+			if nRead, err = vecReadFromBuf(ksk.Value[i][j].Coeffs, buf, r); err != nil {
+				return n + nRead, err
+			}
+			n += nRead
+		}
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (ksk *SeededGLWEKeySwitchKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = ksk.headerReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = ksk.valueReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (ksk SeededGLWEKeySwitchKey[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, ksk.ByteSize()))
+	_, err = ksk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (ksk *SeededGLWEKeySwitchKey[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := ksk.ReadFrom(buf)
+	return err
+}
+
+// ByteSize returns the size of the key in bytes.
+func (brk SeededBlindRotateKey[T]) ByteSize() int {
+	lweDimension := len(brk.Value)
+	glweRank := len(brk.Value[0]) - 1
+	level := brk.GadgetParams.level
+	polyRank := brk.Value[0][0][0].Rank()
+
+	return 40 + SeedSize + lweDimension*(glweRank+1)*level*polyRank*num.ByteSizeT[T]()
+}
+
+// headerWriteTo writes the header.
+func (brk SeededBlindRotateKey[T]) headerWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var buf [8]byte
+
+	binary.BigEndian.PutUint64(buf[:], uint64(brk.GadgetParams.base))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(brk.GadgetParams.level))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(len(brk.Value)))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(len(brk.Value[0])-1))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(brk.Value[0][0][0].Rank()))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	if nWrite, err = w.Write(brk.Seed[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	return
+}
+
+// valueWriteTo writes the value.
+func (brk SeededBlindRotateKey[T]) valueWriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	polyRank := brk.Value[0][0][0].Rank()
+	buf := make([]byte, polyRank*num.ByteSizeT[T]())
+
+	for i := range brk.Value {
+		for j := range brk.Value[i] {
+			for k := range brk.Value[i][j] {
+				if nWrite, err = vecWriteToBuf(brk.Value[i][j][k].Coeffs, buf, w); err != nil {
+					return n + nWrite, err
+				}
+				n += nWrite
+			}
+		}
+	}
+
+	return
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] Base
+//	[8] Level
+//	[8] LWEDimension
+//	[8] GLWERank
+//	[8] PolyRank
+//	[32] Seed
+//	    Value
+func (brk SeededBlindRotateKey[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = brk.headerWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = brk.valueWriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(brk.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// headerReadFrom reads the header, and initializes the value.
+func (brk *SeededBlindRotateKey[T]) headerReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	base := T(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	level := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	lweDimension := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	glweRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	polyRank := int(binary.BigEndian.Uint64(buf[:]))
+
+	*brk = NewSeededBlindRotateKeyCustom(lweDimension, glweRank, polyRank, GadgetParametersLiteral[T]{Base: base, Level: level}.Compile())
+
+	if nRead, err = io.ReadFull(r, brk.Seed[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+
+	return
+}
+
+// valueReadFrom reads the value.
+func (brk *SeededBlindRotateKey[T]) valueReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	polyRank := brk.Value[0][0][0].Rank()
+	buf := make([]byte, polyRank*num.ByteSizeT[T]())
+
+	for i := range brk.Value {
+		for j := range brk.Value[i] {
+			for k := range brk.Value[i][j] {
+				if nRead, err = vecReadFromBuf(brk.Value[i][j][k].Coeffs, buf, r); err != nil {
+					return n + nRead, err
+				}
+				n += nRead
+			}
+		}
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (brk *SeededBlindRotateKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = brk.headerReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = brk.valueReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (brk SeededBlindRotateKey[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, brk.ByteSize()))
+	_, err = brk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (brk *SeededBlindRotateKey[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := brk.ReadFrom(buf)
+	return err
+}
+
+// ByteSize returns the size of the key in bytes.
+func (evk SeededEvaluationKey[T]) ByteSize() int {
+	return evk.BlindRotateKey.ByteSize() + evk.KeySwitchKey.ByteSize()
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	BlindRotateKey
+//	KeySwitchKey
+func (evk SeededEvaluationKey[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int64
+
+	if nWrite, err = evk.BlindRotateKey.WriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if nWrite, err = evk.KeySwitchKey.WriteTo(w); err != nil {
+		return n + nWrite, err
+	}
+	n += nWrite
+
+	if n < int64(evk.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (evk *SeededEvaluationKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int64
+
+	if nRead, err = evk.BlindRotateKey.ReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	if nRead, err = evk.KeySwitchKey.ReadFrom(r); err != nil {
+		return n + nRead, err
+	}
+	n += nRead
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (evk SeededEvaluationKey[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, evk.ByteSize()))
+	_, err = evk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (evk *SeededEvaluationKey[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := evk.ReadFrom(buf)
+	return err
+}
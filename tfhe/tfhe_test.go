@@ -27,6 +27,11 @@ var (
 		tfhe.ParamsUint7,
 		tfhe.ParamsUint8,
 	}
+
+	paramsMultiBitList = []tfhe.ParametersLiteral[uint64]{
+		tfhe.ParamsUint2MultiBit,
+		tfhe.ParamsUint3MultiBit,
+	}
 )
 
 func TestParams(t *testing.T) {
@@ -41,6 +46,25 @@ func TestParams(t *testing.T) {
 			assert.LessOrEqual(t, math.Log2(params.Compile().EstimateFailureProbability()), -64.0)
 		})
 	}
+
+	for _, params := range paramsMultiBitList {
+		t.Run(fmt.Sprintf("Compile/ParamsUint%vMultiBit", num.Log2(params.MessageModulus)), func(t *testing.T) {
+			assert.NotPanics(t, func() { params.Compile() })
+		})
+	}
+
+	for _, params := range paramsMultiBitList {
+		t.Run(fmt.Sprintf("FailureProbability/ParamsUint%vMultiBit", num.Log2(params.MessageModulus)), func(t *testing.T) {
+			assert.LessOrEqual(t, math.Log2(params.Compile().EstimateFailureProbability()), -64.0)
+		})
+	}
+
+	t.Run("Compile/GroupingFactor", func(t *testing.T) {
+		assert.Panics(t, func() { tfhe.ParamsUint2MultiBit.WithGroupingFactor(4).Compile() })
+		assert.Panics(t, func() { tfhe.ParamsUint2MultiBit.WithLWEDimension(821).Compile() })
+		assert.Panics(t, func() { tfhe.ParamsUint2MultiBit.WithBlockSize(3).Compile() })
+		assert.Panics(t, func() { tfhe.ParamsUint2MultiBit.WithLUTSize(4096).Compile() })
+	})
 }
 
 func TestEncryptor(t *testing.T) {
@@ -54,6 +78,13 @@ func TestEncryptor(t *testing.T) {
 		}
 	})
 
+	t.Run("LWESigned", func(t *testing.T) {
+		for _, m := range messages {
+			ct := enc.EncryptLWE(-m)
+			assert.Equal(t, -m, enc.DecryptLWESigned(ct))
+		}
+	})
+
 	t.Run("PublicLWE", func(t *testing.T) {
 		for _, m := range messages {
 			ct := pkEnc.EncryptLWE(m)
@@ -85,6 +116,18 @@ func TestEncryptor(t *testing.T) {
 		assert.Equal(t, messages, enc.DecryptGLWE(ct)[:len(messages)])
 	})
 
+	t.Run("SeededLWE", func(t *testing.T) {
+		for _, m := range messages {
+			ct := enc.EncryptSeededLWE(m)
+			assert.Equal(t, m, enc.DecryptLWE(ct.Decompress()))
+		}
+	})
+
+	t.Run("SeededGLWE", func(t *testing.T) {
+		ct := enc.EncryptSeededGLWE(messages)
+		assert.Equal(t, messages, enc.DecryptGLWE(ct.Decompress())[:len(messages)])
+	})
+
 	t.Run("GLev", func(t *testing.T) {
 		ct := enc.EncryptGLev(messages, gadgetParams)
 		assert.Equal(t, messages, enc.DecryptGLev(ct)[:len(messages)])
@@ -167,6 +210,79 @@ func TestEvaluator(t *testing.T) {
 		assert.Equal(t, messages, encOut.DecryptGLWE(ctGLWEOut)[:len(messages)])
 	})
 
+	t.Run("SeededKeySwitch", func(t *testing.T) {
+		kskGLWEParams := tfhe.GadgetParametersLiteral[uint64]{
+			Base:  1 << 12,
+			Level: 3,
+		}.Compile()
+
+		encOut := tfhe.NewEncryptor(params)
+
+		ctLWEIn := enc.EncryptLWE(messages[0])
+		ctGLWEIn := enc.EncryptGLWE(messages)
+
+		kskLWE := encOut.GenSeededLWEKeySwitchKey(enc.DefaultLWESecretKey(), params.KeySwitchParams()).Decompress()
+		kskGLWE := encOut.GenSeededGLWEKeySwitchKey(enc.SecretKey.GLWEKey, kskGLWEParams).Decompress()
+
+		ctLWEOut := eval.KeySwitchLWE(ctLWEIn, kskLWE)
+		ctGLWEOut := eval.KeySwitchGLWE(ctGLWEIn, kskGLWE)
+
+		assert.Equal(t, messages[0], encOut.DecryptLWE(ctLWEOut))
+		assert.Equal(t, messages, encOut.DecryptGLWE(ctGLWEOut)[:len(messages)])
+	})
+
+	t.Run("SeededEvalKey", func(t *testing.T) {
+		f := func(x int) int { return 2 * x }
+
+		evalSeeded := tfhe.NewEvaluator(params, enc.GenSeededEvalKeyParallel().Decompress())
+
+		for _, m := range messages {
+			ct := enc.EncryptLWE(m)
+			ctOut := evalSeeded.BootstrapFunc(ct, f)
+			assert.Equal(t, f(m), enc.DecryptLWE(ctOut))
+		}
+	})
+
+	t.Run("PackLWEs", func(t *testing.T) {
+		pkskParams := tfhe.GadgetParametersLiteral[uint64]{
+			Base:  1 << 12,
+			Level: 3,
+		}.Compile()
+
+		pksk := enc.GenPackingKeySwitchKey(enc.DefaultLWESecretKey(), pkskParams)
+
+		cts := make([]tfhe.LWECiphertext[uint64], len(messages))
+		for i, m := range messages {
+			cts[i] = eval.BootstrapFunc(enc.EncryptLWE(m), func(x int) int { return 2 * x })
+		}
+
+		ctOut := eval.PackLWEs(cts, pksk)
+		messagesOut := enc.DecryptGLWE(ctOut)
+		for i, m := range messages {
+			assert.Equal(t, (2*m)%int(params.MessageModulus()), messagesOut[i])
+		}
+		for i := len(messages); i < params.PolyRank(); i++ {
+			assert.Equal(t, 0, messagesOut[i])
+		}
+
+		assert.Panics(t, func() { eval.PackLWEs(make([]tfhe.LWECiphertext[uint64], params.PolyRank()+1), pksk) })
+	})
+
+	t.Run("CompressLWE", func(t *testing.T) {
+		for _, m := range messages {
+			ct := eval.CompressLWE(enc.EncryptLWE(m), 16)
+			assert.Equal(t, m, enc.DecryptLWE(ct.Decompress()))
+		}
+
+		assert.Panics(t, func() { eval.CompressLWE(enc.EncryptLWE(0), 0) })
+		assert.Panics(t, func() { eval.CompressLWE(enc.EncryptLWE(0), 65) })
+	})
+
+	t.Run("CompressGLWE", func(t *testing.T) {
+		ct := eval.CompressGLWE(enc.EncryptGLWE(messages), 16)
+		assert.Equal(t, messages, enc.DecryptGLWE(ct.Decompress())[:len(messages)])
+	})
+
 	t.Run("BootstrapOriginalFunc", func(t *testing.T) {
 		f := func(x int) int { return 2 * x }
 
@@ -190,6 +306,68 @@ func TestEvaluator(t *testing.T) {
 		}
 	})
 
+	t.Run("BootstrapLUTBatch", func(t *testing.T) {
+		fs := []func(int) int{
+			func(x int) int { return 2 * x },
+			func(x int) int { return x + 1 },
+			func(x int) int { return 3 - x },
+		}
+		luts := make([]tfhe.LookUpTable[uint64], len(messages))
+		cts := make([]tfhe.LWECiphertext[uint64], len(messages))
+		for i, m := range messages {
+			luts[i] = eval.GenLUT(fs[i%len(fs)])
+			cts[i] = enc.EncryptLWE(m)
+		}
+
+		evalBatch := eval.SafeCopy()
+		evalBatch.SetBatchWorkerCount(2)
+
+		ctOut := evalBatch.BootstrapLUTBatch(cts, luts)
+		for i, m := range messages {
+			assert.Equal(t, fs[i%len(fs)](m), enc.DecryptLWE(ctOut[i]))
+		}
+
+		ctOut = evalBatch.BootstrapLUTBatch(cts, luts[:1])
+		for i, m := range messages {
+			assert.Equal(t, fs[0](m), enc.DecryptLWE(ctOut[i]))
+		}
+
+		assert.Panics(t, func() { evalBatch.BootstrapLUTBatch(cts, luts[:2]) })
+	})
+
+	t.Run("BootstrapFuncBatch", func(t *testing.T) {
+		fs := make([]func(int) int, len(messages))
+		cts := make([]tfhe.LWECiphertext[uint64], len(messages))
+		for i, m := range messages {
+			j := i
+			fs[i] = func(x int) int { return x + j }
+			cts[i] = enc.EncryptLWE(m)
+		}
+
+		ctOut := eval.BootstrapFuncBatch(cts, fs)
+		for i, m := range messages {
+			assert.Equal(t, fs[i](m)%int(params.MessageModulus()), enc.DecryptLWE(ctOut[i]))
+		}
+	})
+
+	t.Run("BootstrapMultiBitFunc", func(t *testing.T) {
+		f := func(x int) int { return 2 * x }
+
+		for _, groupingFactor := range []int{2, 3} {
+			paramsMultiBit := tfhe.ParamsUint3MultiBit.WithGroupingFactor(groupingFactor).Compile()
+			encMultiBit := tfhe.NewEncryptor(paramsMultiBit)
+			evalMultiBit := tfhe.NewEvaluator(paramsMultiBit, encMultiBit.GenEvalKeyParallel())
+
+			assert.Equal(t, paramsMultiBit.BlindRotateKeyCount(), len(evalMultiBit.EvalKey.BlindRotateKey.Value))
+
+			for _, m := range messages {
+				ct := encMultiBit.EncryptLWE(m)
+				ctOut := evalMultiBit.BootstrapFunc(ct, f)
+				assert.Equal(t, f(m), encMultiBit.DecryptLWE(ctOut))
+			}
+		}
+	})
+
 	t.Run("BootstrapExtendedFunc", func(t *testing.T) {
 		f := func(x int) int { return 2 * x }
 
@@ -204,6 +382,29 @@ func TestEvaluator(t *testing.T) {
 	})
 }
 
+func TestFullDomainBootstrap(t *testing.T) {
+	for _, params := range paramsList {
+		params := params.Compile()
+		enc := tfhe.NewEncryptor(params)
+		eval := tfhe.NewEvaluator(params, enc.GenEvalKeyParallel())
+
+		p := int(params.MessageModulus())
+		f := func(x int) int { return 3*x + 1 }
+		messages := []int{0, 1, p - 1, p, p + 1, 2*p - 1}
+
+		t.Run(fmt.Sprintf("ParamsUint%v", num.Log2(params.MessageModulus())), func(t *testing.T) {
+			for _, m := range messages {
+				ct := enc.EncryptLWEFullDomain(m)
+				ctOut := eval.BootstrapFullDomainFunc(ct, f)
+				assert.Equal(t, f(m)%(2*p), enc.DecryptLWEFullDomain(ctOut))
+
+				eval.BootstrapFullDomainFuncTo(ctOut, ctOut, f)
+				assert.Equal(t, f(f(m))%(2*p), enc.DecryptLWEFullDomain(ctOut))
+			}
+		})
+	}
+}
+
 func TestMarshal(t *testing.T) {
 	var n int64
 	var err error
@@ -224,6 +425,24 @@ func TestMarshal(t *testing.T) {
 		assert.Equal(t, paramsIn, paramsOut)
 	})
 
+	t.Run("Parameters/GroupingFactor", func(t *testing.T) {
+		var paramsIn, paramsOut tfhe.Parameters[uint64]
+
+		// Parameters without multi-bit blind rotation keep the encoding without GroupingFactor.
+		assert.Equal(t, params.ByteSize(), 8*8+16+16+1)
+
+		paramsIn = tfhe.ParamsUint2MultiBit.Compile()
+		n, err := paramsIn.WriteTo(&buf)
+		assert.Equal(t, int(n), paramsIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = paramsOut.ReadFrom(&buf)
+		assert.Equal(t, int(n), paramsIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, paramsIn, paramsOut)
+	})
+
 	t.Run("LWECiphertext", func(t *testing.T) {
 		var ctIn, ctOut tfhe.LWECiphertext[uint64]
 
@@ -404,6 +623,68 @@ func TestMarshal(t *testing.T) {
 		assert.Equal(t, evkIn, evkOut)
 	})
 
+	t.Run("SeededLWECiphertext", func(t *testing.T) {
+		var ctIn, ctOut tfhe.SeededLWECiphertext[uint64]
+
+		ctIn = enc.EncryptSeededLWE(0)
+		n, err = ctIn.WriteTo(&buf)
+		assert.Equal(t, int(n), ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = ctOut.ReadFrom(&buf)
+		assert.Equal(t, int(n), ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, ctIn, ctOut)
+	})
+
+	t.Run("SeededGLWECiphertext", func(t *testing.T) {
+		var ctIn, ctOut tfhe.SeededGLWECiphertext[uint64]
+
+		ctIn = enc.EncryptSeededGLWE([]int{0})
+		n, err = ctIn.WriteTo(&buf)
+		assert.Equal(t, int(n), ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = ctOut.ReadFrom(&buf)
+		assert.Equal(t, int(n), ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, ctIn, ctOut)
+	})
+
+	t.Run("SeededEvaluationKey", func(t *testing.T) {
+		var evkIn, evkOut tfhe.SeededEvaluationKey[uint64]
+
+		evkIn = enc.GenSeededEvalKeyParallel()
+		assert.Less(t, 2*evkIn.ByteSize(), eval.EvalKey.ByteSize())
+
+		n, err = evkIn.WriteTo(&buf)
+		assert.Equal(t, int(n), evkIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = evkOut.ReadFrom(&buf)
+		assert.Equal(t, int(n), evkIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, evkIn, evkOut)
+	})
+
+	t.Run("SeededGLWEKeySwitchKey", func(t *testing.T) {
+		var kskIn, kskOut tfhe.SeededGLWEKeySwitchKey[uint64]
+
+		kskIn = enc.GenSeededGLWEKeySwitchKey(enc.SecretKey.GLWEKey, params.KeySwitchParams())
+		n, err = kskIn.WriteTo(&buf)
+		assert.Equal(t, int(n), kskIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = kskOut.ReadFrom(&buf)
+		assert.Equal(t, int(n), kskIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, kskIn, kskOut)
+	})
+
 	t.Run("GLWEKeySwitchKey", func(t *testing.T) {
 		var kskIn, kskOut tfhe.GLWEKeySwitchKey[uint64]
 
@@ -418,6 +699,105 @@ func TestMarshal(t *testing.T) {
 
 		assert.Equal(t, kskIn, kskOut)
 	})
+
+	t.Run("PackingKeySwitchKey", func(t *testing.T) {
+		var pkskIn, pkskOut tfhe.PackingKeySwitchKey[uint64]
+
+		pkskIn = enc.GenPackingKeySwitchKey(enc.DefaultLWESecretKey(), params.KeySwitchParams())
+		n, err = pkskIn.WriteTo(&buf)
+		assert.Equal(t, int(n), pkskIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = pkskOut.ReadFrom(&buf)
+		assert.Equal(t, int(n), pkskIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, pkskIn, pkskOut)
+	})
+
+	t.Run("CompressedLWECiphertext", func(t *testing.T) {
+		var ctIn, ctOut tfhe.CompressedLWECiphertext[uint64]
+
+		ctIn = eval.CompressLWE(enc.EncryptLWE(0), 13)
+		assert.Less(t, ctIn.ByteSize(), enc.EncryptLWE(0).ByteSize())
+
+		n, err = ctIn.WriteTo(&buf)
+		assert.Equal(t, int(n), ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = ctOut.ReadFrom(&buf)
+		assert.Equal(t, int(n), ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, ctIn, ctOut)
+
+		data, err := ctIn.MarshalBinary()
+		assert.NoError(t, err)
+		data[7] = 99
+		assert.Error(t, ctOut.UnmarshalBinary(data))
+	})
+
+	t.Run("CompressedGLWECiphertext", func(t *testing.T) {
+		var ctIn, ctOut tfhe.CompressedGLWECiphertext[uint64]
+
+		ctIn = eval.CompressGLWE(enc.EncryptGLWE([]int{0}), 13)
+		assert.Less(t, ctIn.ByteSize(), enc.EncryptGLWE([]int{0}).ByteSize())
+
+		n, err = ctIn.WriteTo(&buf)
+		assert.Equal(t, int(n), ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = ctOut.ReadFrom(&buf)
+		assert.Equal(t, int(n), ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, ctIn, ctOut)
+
+		data, err := ctIn.MarshalBinary()
+		assert.NoError(t, err)
+		data[7] = 99
+		assert.Error(t, ctOut.UnmarshalBinary(data))
+	})
+
+	t.Run("Envelope", func(t *testing.T) {
+		var ctIn, ctOut tfhe.LWECiphertext[uint64]
+
+		assert.Equal(t, params.Hash(), tfhe.ParamsUint3.Compile().Hash())
+		assert.NotEqual(t, params.Hash(), tfhe.ParamsUint2.Compile().Hash())
+
+		ctIn = enc.EncryptLWE(0)
+		n, err = tfhe.WriteEnvelope(&buf, params, ctIn)
+		assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		n, err = tfhe.ReadEnvelope(&buf, params, &ctOut)
+		assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+ctIn.ByteSize())
+		assert.NoError(t, err)
+
+		assert.Equal(t, ctIn, ctOut)
+
+		data, err := tfhe.MarshalEnvelope(params, ctIn)
+		assert.NoError(t, err)
+		assert.Equal(t, data[6:8], []byte{0x01, 0x03})
+
+		assert.Equal(t, tfhe.ObjectLWESecretKey, tfhe.ObjectType(0x0100))
+		assert.Equal(t, tfhe.ObjectGLWECiphertext, tfhe.ObjectType(0x0109))
+		assert.Equal(t, tfhe.ObjectEvaluationKey, tfhe.ObjectType(0x0112))
+		assert.Equal(t, tfhe.ObjectCompressedGLWECiphertext, tfhe.ObjectType(0x011E))
+
+		ctGLWE := tfhe.NewGLWECiphertext(params)
+		ctUint32 := tfhe.NewLWECiphertext(tfhe.ParamsBinary.Compile())
+		assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, tfhe.ParamsUint2.Compile(), &ctOut), tfhe.ErrParamsMismatch)
+		assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, tfhe.ParamsBinary.Compile(), &ctOut), tfhe.ErrTorusIntMismatch)
+		assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, params, &ctUint32), tfhe.ErrTorusIntMismatch)
+		assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, params, &ctGLWE), tfhe.ErrObjectTypeMismatch)
+
+		_, err = tfhe.MarshalEnvelope(params, ctUint32)
+		assert.ErrorIs(t, err, tfhe.ErrTorusIntMismatch)
+
+		data[0] ^= 1
+		assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, params, &ctOut), tfhe.ErrInvalidEnvelope)
+	})
 }
 
 func BenchmarkEvaluationKeyGen(b *testing.B) {
@@ -451,6 +831,26 @@ func BenchmarkProgrammableBootstrap(b *testing.B) {
 	}
 }
 
+func BenchmarkMultiBitBootstrap(b *testing.B) {
+	for _, paramsLiteral := range paramsMultiBitList {
+		for _, groupingFactor := range []int{1, paramsLiteral.GroupingFactor} {
+			params := paramsLiteral.WithGroupingFactor(groupingFactor).Compile()
+			enc := tfhe.NewEncryptor(params)
+			eval := tfhe.NewEvaluator(params, enc.GenEvalKeyParallel())
+
+			ct := enc.EncryptLWE(0)
+			ctOut := ct.Copy()
+			lut := eval.GenLUT(func(x int) int { return 2*x + 1 })
+
+			b.Run(fmt.Sprintf("Uint%vMultiBit/GroupingFactor=%v", num.Log2(params.MessageModulus()), groupingFactor), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					eval.BootstrapLUTTo(ctOut, ct, lut)
+				}
+			})
+		}
+	}
+}
+
 func ExampleEncryptor() {
 	// Parameters must be compiled before use.
 	params := tfhe.ParamsUint4.Compile()
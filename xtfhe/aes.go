@@ -0,0 +1,513 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+const (
+	// AESBlockBits is the number of bits in an AES block.
+	AESBlockBits = 128
+	// AESKeyBits is the number of bits in an AES-128 key.
+	AESKeyBits = 128
+	// AESRoundKeyBits is the number of bits of the expanded AES-128 round keys.
+	AESRoundKeyBits = (aesRounds + 1) * AESBlockBits
+
+	// aesRounds is the number of rounds of AES-128.
+	aesRounds = 10
+)
+
+// AESEvaluator evaluates AES-128 on encrypted bits using [tfhe.BinaryEvaluator].
+// This is meant to be public, usually for servers.
+//
+// All keys and blocks are arrays of LWE ciphertexts encrypted with [tfhe.BinaryEncryptor].
+// Bit 8*i+j is the j-th least significant bit of the i-th byte,
+// so that each byte can be encrypted using [tfhe.BinaryEncryptor.EncryptLWEBits].
+//
+// The AES circuits are built from [AESKeyExpansionCircuit], [AESEncryptCircuit] and [AESDecryptCircuit],
+// and compiled using [circuit.Compile].
+// Gates of each level are evaluated in parallel.
+// Key expansion takes 6400 gate bootstraps, and encryption and decryption of a block
+// take 26064 and 33344 gate bootstraps respectively.
+//
+// AESEvaluator is not safe for concurrent use.
+// Use [AESEvaluator.SafeCopy] to get a safe copy.
+type AESEvaluator[T tfhe.TorusInt] struct {
+	// Evaluator is a circuit Evaluator for this AESEvaluator.
+	Evaluator *circuit.Evaluator[T]
+	// Params is the parameter set for this AESEvaluator.
+	Params tfhe.Parameters[T]
+
+	// keyExpansion is the compiled key expansion circuit.
+	keyExpansion *circuit.Program
+	// encrypt is the compiled encryption circuit.
+	encrypt *circuit.Program
+	// decrypt is the compiled decryption circuit.
+	decrypt *circuit.Program
+}
+
+// NewAESEvaluator creates a new [AESEvaluator].
+// This does not copy evaluation keys, since they are large.
+func NewAESEvaluator[T tfhe.TorusInt](params tfhe.Parameters[T], evk tfhe.EvaluationKey[T]) *AESEvaluator[T] {
+	return &AESEvaluator[T]{
+		Evaluator: circuit.NewEvaluator(params, evk),
+		Params:    params,
+
+		keyExpansion: circuit.Compile(AESKeyExpansionCircuit()),
+		encrypt:      circuit.Compile(AESEncryptCircuit()),
+		decrypt:      circuit.Compile(AESDecryptCircuit()),
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *AESEvaluator[T]) SafeCopy() *AESEvaluator[T] {
+	return &AESEvaluator[T]{
+		Evaluator: e.Evaluator.SafeCopy(),
+		Params:    e.Params,
+
+		keyExpansion: e.keyExpansion,
+		encrypt:      e.encrypt,
+		decrypt:      e.decrypt,
+	}
+}
+
+// ExpandKey expands the encrypted AES-128 key to the round keys.
+//
+// Panics if len(ctKey) != AESKeyBits.
+func (e *AESEvaluator[T]) ExpandKey(ctKey []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newBits(AESRoundKeyBits)
+	e.ExpandKeyTo(ctOut, ctKey)
+	return ctOut
+}
+
+// ExpandKeyTo expands the encrypted AES-128 key to the round keys and writes them to ctOut.
+//
+// Panics if len(ctKey) != AESKeyBits or len(ctOut) != AESRoundKeyBits.
+func (e *AESEvaluator[T]) ExpandKeyTo(ctOut, ctKey []tfhe.LWECiphertext[T]) {
+	e.Evaluator.EvaluateProgramParallelTo([][]tfhe.LWECiphertext[T]{ctOut}, e.keyExpansion, [][]tfhe.LWECiphertext[T]{ctKey})
+}
+
+// EncryptBlock encrypts a block of AES-128 using the round keys from [AESEvaluator.ExpandKey].
+//
+// Panics if len(ctRoundKey) != AESRoundKeyBits or len(ctBlock) != AESBlockBits.
+func (e *AESEvaluator[T]) EncryptBlock(ctRoundKey, ctBlock []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newBits(AESBlockBits)
+	e.EncryptBlockTo(ctOut, ctRoundKey, ctBlock)
+	return ctOut
+}
+
+// EncryptBlockTo encrypts a block of AES-128 using the round keys from [AESEvaluator.ExpandKey]
+// and writes it to ctOut.
+//
+// Panics if len(ctRoundKey) != AESRoundKeyBits, or len(ctBlock) or len(ctOut) != AESBlockBits.
+func (e *AESEvaluator[T]) EncryptBlockTo(ctOut, ctRoundKey, ctBlock []tfhe.LWECiphertext[T]) {
+	e.Evaluator.EvaluateProgramParallelTo([][]tfhe.LWECiphertext[T]{ctOut}, e.encrypt, [][]tfhe.LWECiphertext[T]{ctRoundKey, ctBlock})
+}
+
+// DecryptBlock decrypts a block of AES-128 using the round keys from [AESEvaluator.ExpandKey].
+//
+// Panics if len(ctRoundKey) != AESRoundKeyBits or len(ctBlock) != AESBlockBits.
+func (e *AESEvaluator[T]) DecryptBlock(ctRoundKey, ctBlock []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newBits(AESBlockBits)
+	e.DecryptBlockTo(ctOut, ctRoundKey, ctBlock)
+	return ctOut
+}
+
+// DecryptBlockTo decrypts a block of AES-128 using the round keys from [AESEvaluator.ExpandKey]
+// and writes it to ctOut.
+//
+// Panics if len(ctRoundKey) != AESRoundKeyBits, or len(ctBlock) or len(ctOut) != AESBlockBits.
+func (e *AESEvaluator[T]) DecryptBlockTo(ctOut, ctRoundKey, ctBlock []tfhe.LWECiphertext[T]) {
+	e.Evaluator.EvaluateProgramParallelTo([][]tfhe.LWECiphertext[T]{ctOut}, e.decrypt, [][]tfhe.LWECiphertext[T]{ctRoundKey, ctBlock})
+}
+
+// newBits allocates n LWE ciphertexts.
+func (e *AESEvaluator[T]) newBits(n int) []tfhe.LWECiphertext[T] {
+	ct := make([]tfhe.LWECiphertext[T], n)
+	for i := range ct {
+		ct[i] = tfhe.NewLWECiphertext(e.Params)
+	}
+	return ct
+}
+
+// AESSBoxCircuit returns a boolean circuit for the AES S-box.
+// It has one 8-bit input "x" and one 8-bit output "y", both from the least significant bit.
+//
+// The S-box is the depth-16 circuit of Boyar and Peralta,
+// which uses 32 AND gates and 96 XOR/XNOR gates.
+func AESSBoxCircuit() circuit.Circuit {
+	b := newAESCircuitBuilder(8)
+	return b.build([]string{"x"}, []string{"y"}, aesBits([]aesByte{b.sbox(b.inputByte(0))}))
+}
+
+// AESKeyExpansionCircuit returns a boolean circuit for the AES-128 key expansion.
+// It has one input "key" of AESKeyBits bits, and one output "round_keys" of AESRoundKeyBits bits.
+func AESKeyExpansionCircuit() circuit.Circuit {
+	b := newAESCircuitBuilder(AESKeyBits)
+
+	var w [4 * (aesRounds + 1)][4]aesByte
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			w[i][j] = b.inputByte(4*i + j)
+		}
+	}
+
+	rcon := byte(0x01)
+	for i := 4; i < len(w); i++ {
+		temp := w[i-1]
+		if i%4 == 0 {
+			temp = [4]aesByte{b.sbox(temp[1]), b.sbox(temp[2]), b.sbox(temp[3]), b.sbox(temp[0])}
+			temp[0] = b.xorConstByte(temp[0], rcon)
+			rcon = aesXTime(rcon)
+		}
+		for j := 0; j < 4; j++ {
+			w[i][j] = b.xorByte(w[i-4][j], temp[j])
+		}
+	}
+
+	roundKeys := make([]aesByte, 0, len(w)*4)
+	for i := range w {
+		roundKeys = append(roundKeys, w[i][:]...)
+	}
+	return b.build([]string{"key"}, []string{"round_keys"}, aesBits(roundKeys))
+}
+
+// AESEncryptCircuit returns a boolean circuit for the AES-128 encryption of a block.
+// It has two inputs "round_keys" of AESRoundKeyBits bits from [AESKeyExpansionCircuit] and "block" of AESBlockBits bits,
+// and one output "block" of AESBlockBits bits.
+func AESEncryptCircuit() circuit.Circuit {
+	b := newAESCircuitBuilder(AESRoundKeyBits, AESBlockBits)
+
+	var s aesState
+	for i := range s {
+		s[i] = b.inputByte(AESRoundKeyBits/8 + i)
+	}
+
+	s = b.addRoundKey(s, 0)
+	for r := 1; r <= aesRounds; r++ {
+		for i := range s {
+			s[i] = b.sbox(s[i])
+		}
+		s = aesShiftRows(s)
+		if r != aesRounds {
+			s = b.mixColumns(s)
+		}
+		s = b.addRoundKey(s, r)
+	}
+
+	return b.build([]string{"round_keys", "block"}, []string{"block"}, aesBits(s[:]))
+}
+
+// AESDecryptCircuit returns a boolean circuit for the AES-128 decryption of a block.
+// It has two inputs "round_keys" of AESRoundKeyBits bits from [AESKeyExpansionCircuit] and "block" of AESBlockBits bits,
+// and one output "block" of AESBlockBits bits.
+func AESDecryptCircuit() circuit.Circuit {
+	b := newAESCircuitBuilder(AESRoundKeyBits, AESBlockBits)
+
+	var s aesState
+	for i := range s {
+		s[i] = b.inputByte(AESRoundKeyBits/8 + i)
+	}
+
+	s = b.addRoundKey(s, aesRounds)
+	for r := aesRounds - 1; r >= 0; r-- {
+		s = aesInvShiftRows(s)
+		for i := range s {
+			s[i] = b.invSBox(s[i])
+		}
+		s = b.addRoundKey(s, r)
+		if r != 0 {
+			s = b.invMixColumns(s)
+		}
+	}
+
+	return b.build([]string{"round_keys", "block"}, []string{"block"}, aesBits(s[:]))
+}
+
+// aesByte is a byte of wires, from the least significant bit.
+type aesByte [8]int
+
+// aesState is the AES state of 16 bytes, in column-major order.
+type aesState [16]aesByte
+
+// aesCircuitBuilder builds AES circuits.
+type aesCircuitBuilder struct {
+	*circuitBuilder
+}
+
+// newAESCircuitBuilder creates a new aesCircuitBuilder with given input sizes.
+func newAESCircuitBuilder(inputSizes ...int) aesCircuitBuilder {
+	return aesCircuitBuilder{circuitBuilder: newCircuitBuilder(inputSizes...)}
+}
+
+// inputByte returns the i-th byte of the concatenated inputs.
+func (b aesCircuitBuilder) inputByte(i int) aesByte {
+	var x aesByte
+	for j := range x {
+		x[j] = 8*i + j
+	}
+	return x
+}
+
+// xorByte returns x XOR y.
+func (b aesCircuitBuilder) xorByte(x, y aesByte) aesByte {
+	var z aesByte
+	for i := range z {
+		z[i] = b.xor(x[i], y[i])
+	}
+	return z
+}
+
+// xorConstByte returns x XOR c, using only NOT gates.
+func (b aesCircuitBuilder) xorConstByte(x aesByte, c byte) aesByte {
+	for i := range x {
+		if (c>>i)&1 == 1 {
+			x[i] = b.not(x[i])
+		}
+	}
+	return x
+}
+
+// xtime returns x multiplied by 0x02 in GF(2^8).
+func (b aesCircuitBuilder) xtime(x aesByte) aesByte {
+	return aesByte{x[7], b.xor(x[0], x[7]), x[1], b.xor(x[2], x[7]), b.xor(x[3], x[7]), x[4], x[5], x[6]}
+}
+
+// addRoundKey returns s XOR the r-th round key.
+func (b aesCircuitBuilder) addRoundKey(s aesState, r int) aesState {
+	for i := range s {
+		s[i] = b.xorByte(s[i], b.inputByte(16*r+i))
+	}
+	return s
+}
+
+// mixColumns applies MixColumns to s.
+func (b aesCircuitBuilder) mixColumns(s aesState) aesState {
+	for c := 0; c < 4; c++ {
+		a := [4]aesByte{s[4*c], s[4*c+1], s[4*c+2], s[4*c+3]}
+
+		// out[i] = a[i] XOR t XOR xtime(a[i] XOR a[i+1]), where t = a[0] XOR a[1] XOR a[2] XOR a[3].
+		var u [4]aesByte
+		for i := range u {
+			u[i] = b.xorByte(a[i], a[(i+1)%4])
+		}
+		t := b.xorByte(u[0], u[2])
+		for i := range u {
+			s[4*c+i] = b.xorByte(b.xorByte(a[i], t), b.xtime(u[i]))
+		}
+	}
+	return s
+}
+
+// invMixColumns applies InvMixColumns to s.
+func (b aesCircuitBuilder) invMixColumns(s aesState) aesState {
+	// InvMixColumns is MixColumns after multiplying each column by 0x04*x^2 + 0x05.
+	for c := 0; c < 4; c++ {
+		u := b.xtime(b.xtime(b.xorByte(s[4*c], s[4*c+2])))
+		v := b.xtime(b.xtime(b.xorByte(s[4*c+1], s[4*c+3])))
+		s[4*c] = b.xorByte(s[4*c], u)
+		s[4*c+1] = b.xorByte(s[4*c+1], v)
+		s[4*c+2] = b.xorByte(s[4*c+2], u)
+		s[4*c+3] = b.xorByte(s[4*c+3], v)
+	}
+	return b.mixColumns(s)
+}
+
+// aesShiftRows applies ShiftRows to s.
+func aesShiftRows(s aesState) aesState {
+	var sOut aesState
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			sOut[r+4*c] = s[r+4*((c+r)%4)]
+		}
+	}
+	return sOut
+}
+
+// aesInvShiftRows applies InvShiftRows to s.
+func aesInvShiftRows(s aesState) aesState {
+	var sOut aesState
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			sOut[r+4*((c+r)%4)] = s[r+4*c]
+		}
+	}
+	return sOut
+}
+
+// aesXTime returns x multiplied by 0x02 in GF(2^8).
+func aesXTime(x byte) byte {
+	if x&0x80 != 0 {
+		return x<<1 ^ 0x1b
+	}
+	return x << 1
+}
+
+// invAffine applies the linear part of the inverse of the affine map of the S-box.
+func (b aesCircuitBuilder) invAffine(x aesByte) aesByte {
+	var y aesByte
+	for i := range y {
+		y[i] = b.xor(b.xor(x[(i+2)%8], x[(i+5)%8]), x[(i+7)%8])
+	}
+	return y
+}
+
+// invSBox applies the inverse S-box to x.
+func (b aesCircuitBuilder) invSBox(x aesByte) aesByte {
+	// Let A be the affine map of the S-box, and L be its linear part.
+	// Then, InvSBox(x) = A^-1(SBox(A^-1(x))) XOR L^-1(0x63),
+	// and L^-1(0x63) = 0x05.
+	y := b.xorConstByte(b.invAffine(x), 0x05)
+	return b.xorConstByte(b.invAffine(b.sbox(y)), 0x05)
+}
+
+// sbox applies the S-box to x.
+func (b aesCircuitBuilder) sbox(x aesByte) aesByte {
+	// Inputs and outputs are indexed from the most significant bit.
+	U0, U1, U2, U3, U4, U5, U6, U7 := x[7], x[6], x[5], x[4], x[3], x[2], x[1], x[0]
+
+	// Top linear transform
+	T1 := b.xor(U0, U3)
+	T2 := b.xor(U0, U5)
+	T3 := b.xor(U0, U6)
+	T4 := b.xor(U3, U5)
+	T5 := b.xor(U4, U6)
+	T6 := b.xor(T1, T5)
+	T7 := b.xor(U1, U2)
+	T8 := b.xor(U7, T6)
+	T9 := b.xor(U7, T7)
+	T10 := b.xor(T6, T7)
+	T11 := b.xor(U1, U5)
+	T12 := b.xor(U2, U5)
+	T13 := b.xor(T3, T4)
+	T14 := b.xor(T6, T11)
+	T15 := b.xor(T5, T11)
+	T16 := b.xor(T5, T12)
+	T17 := b.xor(T9, T16)
+	T18 := b.xor(U3, U7)
+	T19 := b.xor(T7, T18)
+	T20 := b.xor(T1, T19)
+	T21 := b.xor(U6, U7)
+	T22 := b.xor(T7, T21)
+	T23 := b.xor(T2, T22)
+	T24 := b.xor(T2, T10)
+	T25 := b.xor(T20, T17)
+	T26 := b.xor(T3, T16)
+	T27 := b.xor(T1, T12)
+
+	// Shared non-linear part
+	M1 := b.and(T13, T6)
+	M2 := b.and(T23, T8)
+	M3 := b.xor(T14, M1)
+	M4 := b.and(T19, U7)
+	M5 := b.xor(M4, M1)
+	M6 := b.and(T3, T16)
+	M7 := b.and(T22, T9)
+	M8 := b.xor(T26, M6)
+	M9 := b.and(T20, T17)
+	M10 := b.xor(M9, M6)
+	M11 := b.and(T1, T15)
+	M12 := b.and(T4, T27)
+	M13 := b.xor(M12, M11)
+	M14 := b.and(T2, T10)
+	M15 := b.xor(M14, M11)
+	M16 := b.xor(M3, M2)
+	M17 := b.xor(M5, T24)
+	M18 := b.xor(M8, M7)
+	M19 := b.xor(M10, M15)
+	M20 := b.xor(M16, M13)
+	M21 := b.xor(M17, M15)
+	M22 := b.xor(M18, M13)
+	M23 := b.xor(M19, T25)
+	M24 := b.xor(M22, M23)
+	M25 := b.and(M22, M20)
+	M26 := b.xor(M21, M25)
+	M27 := b.xor(M20, M21)
+	M28 := b.xor(M23, M25)
+	M29 := b.and(M28, M27)
+	M30 := b.and(M26, M24)
+	M31 := b.and(M20, M23)
+	M32 := b.and(M27, M31)
+	M33 := b.xor(M27, M25)
+	M34 := b.and(M21, M22)
+	M35 := b.and(M24, M34)
+	M36 := b.xor(M24, M25)
+	M37 := b.xor(M21, M29)
+	M38 := b.xor(M32, M33)
+	M39 := b.xor(M23, M30)
+	M40 := b.xor(M35, M36)
+	M41 := b.xor(M38, M40)
+	M42 := b.xor(M37, M39)
+	M43 := b.xor(M37, M38)
+	M44 := b.xor(M39, M40)
+	M45 := b.xor(M42, M41)
+	M46 := b.and(M44, T6)
+	M47 := b.and(M40, T8)
+	M48 := b.and(M39, U7)
+	M49 := b.and(M43, T16)
+	M50 := b.and(M38, T9)
+	M51 := b.and(M37, T17)
+	M52 := b.and(M42, T15)
+	M53 := b.and(M45, T27)
+	M54 := b.and(M41, T10)
+	M55 := b.and(M44, T13)
+	M56 := b.and(M40, T23)
+	M57 := b.and(M39, T19)
+	M58 := b.and(M43, T3)
+	M59 := b.and(M38, T22)
+	M60 := b.and(M37, T20)
+	M61 := b.and(M42, T1)
+	M62 := b.and(M45, T4)
+	M63 := b.and(M41, T2)
+
+	// Bottom linear transform
+	L0 := b.xor(M61, M62)
+	L1 := b.xor(M50, M56)
+	L2 := b.xor(M46, M48)
+	L3 := b.xor(M47, M55)
+	L4 := b.xor(M54, M58)
+	L5 := b.xor(M49, M61)
+	L6 := b.xor(M62, L5)
+	L7 := b.xor(M46, L3)
+	L8 := b.xor(M51, M59)
+	L9 := b.xor(M52, M53)
+	L10 := b.xor(M53, L4)
+	L11 := b.xor(M60, L2)
+	L12 := b.xor(M48, M51)
+	L13 := b.xor(M50, L0)
+	L14 := b.xor(M52, M61)
+	L15 := b.xor(M55, L1)
+	L16 := b.xor(M56, L0)
+	L17 := b.xor(M57, L1)
+	L18 := b.xor(M58, L8)
+	L19 := b.xor(M63, L4)
+	L20 := b.xor(L0, L1)
+	L21 := b.xor(L1, L7)
+	L22 := b.xor(L3, L12)
+	L23 := b.xor(L18, L2)
+	L24 := b.xor(L15, L9)
+	L25 := b.xor(L6, L10)
+	L26 := b.xor(L7, L9)
+	L27 := b.xor(L8, L10)
+	L28 := b.xor(L11, L14)
+	L29 := b.xor(L11, L17)
+	S0 := b.xor(L6, L24)
+	S1 := b.xnor(L16, L26)
+	S2 := b.xnor(L19, L28)
+	S3 := b.xor(L6, L21)
+	S4 := b.xor(L20, L22)
+	S5 := b.xor(L25, L29)
+	S6 := b.xnor(L13, L27)
+	S7 := b.xnor(L6, L23)
+
+	return aesByte{S7, S6, S5, S4, S3, S2, S1, S0}
+}
+
+// aesBits flattens bytes to wires.
+func aesBits(x []aesByte) []int {
+	bits := make([]int, 0, 8*len(x))
+	for i := range x {
+		bits = append(bits, x[i][:]...)
+	}
+	return bits
+}
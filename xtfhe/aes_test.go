@@ -0,0 +1,143 @@
+package xtfhe_test
+
+import (
+	"crypto/aes"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/tfhe"
+	"github.com/sp301415/tfhe-go/xtfhe"
+	"github.com/stretchr/testify/assert"
+)
+
+var aesEval = xtfhe.NewAESEvaluator(binaryParams, binaryEvk)
+
+// aesTestVectors are the AES-128 test vectors from FIPS-197, Appendix B and C.1.
+var aesTestVectors = []struct {
+	key        string
+	plaintext  string
+	ciphertext string
+}{
+	{
+		key:        "2b7e151628aed2a6abf7158809cf4f3c",
+		plaintext:  "3243f6a8885a308d313198a2e0370734",
+		ciphertext: "3925841d02dc09fbdc118597196a0b32",
+	},
+	{
+		key:        "000102030405060708090a0b0c0d0e0f",
+		plaintext:  "00112233445566778899aabbccddeeff",
+		ciphertext: "69c4e0d86a7b0430d8cdb78070b4c55a",
+	},
+}
+
+func TestAES(t *testing.T) {
+	keyExpansion := circuit.Compile(xtfhe.AESKeyExpansionCircuit())
+	encrypt := circuit.Compile(xtfhe.AESEncryptCircuit())
+	decrypt := circuit.Compile(xtfhe.AESDecryptCircuit())
+
+	evalAES := func(key, block []byte) ([]byte, []byte) {
+		roundKey := evaluateBytes(keyExpansion, key)
+		return evaluateBytes(encrypt, roundKey, block), evaluateBytes(decrypt, roundKey, block)
+	}
+
+	t.Run("TestVector", func(t *testing.T) {
+		for _, tv := range aesTestVectors {
+			key, _ := hex.DecodeString(tv.key)
+			pt, _ := hex.DecodeString(tv.plaintext)
+			ct, _ := hex.DecodeString(tv.ciphertext)
+
+			ctOut, _ := evalAES(key, pt)
+			assert.Equal(t, ctOut, ct)
+
+			_, ptOut := evalAES(key, ct)
+			assert.Equal(t, ptOut, pt)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		key := make([]byte, 16)
+		block := make([]byte, 16)
+		for i := 0; i < 16; i++ {
+			rand.Read(key)
+			rand.Read(block)
+
+			cipher, _ := aes.NewCipher(key)
+			ctBlock := make([]byte, 16)
+			ptBlock := make([]byte, 16)
+			cipher.Encrypt(ctBlock, block)
+			cipher.Decrypt(ptBlock, block)
+
+			ctOut, ptOut := evalAES(key, block)
+			assert.Equal(t, ctOut, ctBlock)
+			assert.Equal(t, ptOut, ptBlock)
+		}
+	})
+
+	t.Run("SBox", func(t *testing.T) {
+		sbox := circuit.Compile(xtfhe.AESSBoxCircuit())
+		for x, y := range map[int]int{0x00: 0x63, 0x53: 0xed} {
+			ctOut := aesEval.Evaluator.EvaluateProgram(sbox, [][]tfhe.LWECiphertext[uint32]{binaryEnc.EncryptLWEBits(x, 8)})[0]
+			assert.Equal(t, binaryEnc.DecryptLWEBits(ctOut), y)
+		}
+	})
+
+	t.Run("Homomorphic", func(t *testing.T) {
+		key := make([]byte, 16)
+		block := make([]byte, 16)
+		rand.Read(key)
+		rand.Read(block)
+
+		cipher, _ := aes.NewCipher(key)
+		ctBlock := make([]byte, 16)
+		cipher.Encrypt(ctBlock, block)
+
+		ctRoundKey := aesEval.ExpandKey(encryptBytes(binaryEnc, key))
+		ctOut := aesEval.EncryptBlock(ctRoundKey, encryptBytes(binaryEnc, block))
+		assert.Equal(t, decryptBytes(binaryEnc, ctOut), ctBlock)
+
+		ptOut := aesEval.DecryptBlock(ctRoundKey, ctOut)
+		assert.Equal(t, decryptBytes(binaryEnc, ptOut), block)
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		assert.Panics(t, func() { aesEval.ExpandKey(encryptBytes(binaryEnc, make([]byte, 15))) })
+	})
+}
+
+func BenchmarkAES(b *testing.B) {
+	enc, evk := benchmarkBinaryKeys()
+	eval := xtfhe.NewAESEvaluator(enc.Params, evk)
+
+	tv := aesTestVectors[0]
+	key, _ := hex.DecodeString(tv.key)
+	pt, _ := hex.DecodeString(tv.plaintext)
+	ct, _ := hex.DecodeString(tv.ciphertext)
+
+	ctKey := encryptBytes(enc, key)
+	ctBlock := encryptBytes(enc, pt)
+	ctRoundKey := eval.ExpandKey(ctKey)
+	ctOut := eval.EncryptBlock(ctRoundKey, ctBlock)
+	if hex.EncodeToString(decryptBytes(enc, ctOut)) != hex.EncodeToString(ct) {
+		b.Fatal("AES output mismatch")
+	}
+
+	b.Run("ExpandKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			eval.ExpandKeyTo(ctRoundKey, ctKey)
+		}
+	})
+
+	b.Run("EncryptBlock", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			eval.EncryptBlockTo(ctOut, ctRoundKey, ctBlock)
+		}
+	})
+
+	b.Run("DecryptBlock", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			eval.DecryptBlockTo(ctOut, ctRoundKey, ctBlock)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package xtfhe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// galoisKeysByteSize returns the size of the map of Galois keys in bytes.
+func galoisKeysByteSize[T tfhe.TorusInt](galKeys map[int]tfhe.GLWEKeySwitchKey[T]) int {
+	byteSize := 8
+	for _, galKey := range galKeys {
+		byteSize += 8 + galKey.ByteSize()
+	}
+	return byteSize
+}
+
+// galoisKeysWriteTo writes the map of Galois keys, sorted by index.
+//
+// The encoded form is as follows:
+//
+//	[8] GaloisKeyCount
+//	    for each Galois key:
+//	[8]     Index
+//	        GLWEKeySwitchKey
+func galoisKeysWriteTo[T tfhe.TorusInt](w io.Writer, galKeys map[int]tfhe.GLWEKeySwitchKey[T]) (n int64, err error) {
+	var nWrite int
+	var nWrite64 int64
+	var buf [8]byte
+
+	idx := make([]int, 0, len(galKeys))
+	for i := range galKeys {
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+
+	binary.BigEndian.PutUint64(buf[:], uint64(len(idx)))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	for _, i := range idx {
+		binary.BigEndian.PutUint64(buf[:], uint64(i))
+		if nWrite, err = w.Write(buf[:]); err != nil {
+			return n + int64(nWrite), err
+		}
+		n += int64(nWrite)
+
+		if nWrite64, err = galKeys[i].WriteTo(w); err != nil {
+			return n + nWrite64, err
+		}
+		n += nWrite64
+	}
+
+	return
+}
+
+// galoisKeysReadFrom reads the map of Galois keys written by galoisKeysWriteTo.
+func galoisKeysReadFrom[T tfhe.TorusInt](r io.Reader) (galKeys map[int]tfhe.GLWEKeySwitchKey[T], n int64, err error) {
+	var nRead int
+	var nRead64 int64
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return nil, n + int64(nRead), err
+	}
+	n += int64(nRead)
+	galKeyCount := int(binary.BigEndian.Uint64(buf[:]))
+
+	galKeys = make(map[int]tfhe.GLWEKeySwitchKey[T], galKeyCount)
+	for j := 0; j < galKeyCount; j++ {
+		if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+			return nil, n + int64(nRead), err
+		}
+		n += int64(nRead)
+		i := int(binary.BigEndian.Uint64(buf[:]))
+
+		var galKey tfhe.GLWEKeySwitchKey[T]
+		if nRead64, err = galKey.ReadFrom(r); err != nil {
+			return nil, n + nRead64, err
+		}
+		n += nRead64
+
+		galKeys[i] = galKey
+	}
+
+	return
+}
+
+// ByteSize returns the size of the key in bytes.
+func (evk BFVEvaluationKey[T]) ByteSize() int {
+	if len(evk.RelinKey.Value) > 0 {
+		return 1 + evk.RelinKey.ByteSize() + galoisKeysByteSize(evk.GaloisKeys)
+	} else {
+		return 1 + galoisKeysByteSize(evk.GaloisKeys)
+	}
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[1] IsRelinKeyPresent
+//	    RelinKey
+//	[8] GaloisKeyCount
+//	    for each Galois key, sorted by index:
+//	[8]     Index
+//	        GLWEKeySwitchKey
+//
+// If IsRelinKeyPresent is 0, then RelinKey is omitted.
+func (evk BFVEvaluationKey[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var nWrite64 int64
+
+	var isRelinKeyPresent byte
+	if len(evk.RelinKey.Value) > 0 {
+		isRelinKeyPresent = 1
+	}
+
+	if nWrite, err = w.Write([]byte{isRelinKeyPresent}); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	if isRelinKeyPresent == 1 {
+		if nWrite64, err = evk.RelinKey.WriteTo(w); err != nil {
+			return n + nWrite64, err
+		}
+		n += nWrite64
+	}
+
+	if nWrite64, err = galoisKeysWriteTo(w, evk.GaloisKeys); err != nil {
+		return n + nWrite64, err
+	}
+	n += nWrite64
+
+	if n < int64(evk.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (evk *BFVEvaluationKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var nRead64 int64
+
+	var buf [1]byte
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	isRelinKeyPresent := buf[0]
+
+	evk.RelinKey = tfhe.GLWEKeySwitchKey[T]{}
+	if isRelinKeyPresent == 1 {
+		if nRead64, err = evk.RelinKey.ReadFrom(r); err != nil {
+			return n + nRead64, err
+		}
+		n += nRead64
+	}
+
+	if evk.GaloisKeys, nRead64, err = galoisKeysReadFrom[T](r); err != nil {
+		return n + nRead64, err
+	}
+	n += nRead64
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (evk BFVEvaluationKey[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, evk.ByteSize()))
+	_, err = evk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (evk *BFVEvaluationKey[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := evk.ReadFrom(buf)
+	return err
+}
@@ -1,6 +1,7 @@
 package xtfhe_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/sp301415/tfhe-go/math/num"
@@ -72,3 +73,30 @@ func BenchmarkBFVRingPack(b *testing.B) {
 		bfvEval.PackTo(ctGLWE, ctLWE)
 	}
 }
+
+func TestBFVMarshal(t *testing.T) {
+	var buf bytes.Buffer
+	var evkOut xtfhe.BFVEvaluationKey[uint64]
+
+	evkIn := bfvEval.EvalKey
+	n, err := tfhe.WriteEnvelope(&buf, bfvParams, evkIn)
+	assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+evkIn.ByteSize())
+	assert.NoError(t, err)
+
+	n, err = tfhe.ReadEnvelope(&buf, bfvParams, &evkOut)
+	assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+evkIn.ByteSize())
+	assert.NoError(t, err)
+
+	assert.Equal(t, evkIn, evkOut)
+
+	data, err := tfhe.MarshalEnvelope(bfvParams, evkIn)
+	assert.NoError(t, err)
+	assert.Equal(t, data[6:8], []byte{0x03, 0x00})
+
+	assert.Equal(t, xtfhe.ObjectCircuitBootstrapKey, tfhe.ObjectType(0x0301))
+	assert.Equal(t, xtfhe.ObjectFHEWEvaluationKey, tfhe.ObjectType(0x0302))
+
+	var cbkOut xtfhe.CircuitBootstrapKey[uint64]
+	assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, tfhe.ParamsUint2.Compile(), &evkOut), tfhe.ErrParamsMismatch)
+	assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, bfvParams, &cbkOut), tfhe.ErrObjectTypeMismatch)
+}
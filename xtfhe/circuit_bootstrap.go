@@ -48,6 +48,21 @@ type circuitBootstrapBuffer[T tfhe.TorusInt] struct {
 	ctFFTGLWEOut tfhe.FFTGLWECiphertext[T]
 	// ctGGSWOut is the output GGSW ciphertext of circuit bootstrapping.
 	ctGGSWOut tfhe.GGSWCiphertext[T]
+
+	// lutTable is the packed table for table lookup.
+	// It is allocated on the first table lookup, and grows as needed.
+	lutTable []poly.Poly[T]
+	// ctTree holds the intermediate results of the CMux tree in table lookup.
+	// It is allocated on the first table lookup, and grows as needed.
+	ctTree []tfhe.GLWECiphertext[T]
+	// ctTreeDiff is the difference of two leaves of the CMux tree.
+	ctTreeDiff tfhe.GLWECiphertext[T]
+	// ctAccRotate is a rotated accumulator for table lookup.
+	ctAccRotate tfhe.GLWECiphertext[T]
+	// ctTable is the output GLWE ciphertext of table lookup.
+	ctTable tfhe.GLWECiphertext[T]
+	// ctExtract is the extracted LWE ciphertext after table lookup.
+	ctExtract tfhe.LWECiphertext[T]
 }
 
 // NewCircuitBootstrapper creates a new [CircuitBootstrapper].
@@ -95,6 +110,11 @@ func newCircuitBootstrapBuffer[T tfhe.TorusInt](params CircuitBootstrapParameter
 		ctRotate:     tfhe.NewGLWECiphertext(params.BaseParams()),
 		ctFFTGLWEOut: tfhe.NewFFTGLWECiphertext(params.BaseParams()),
 		ctGGSWOut:    tfhe.NewGGSWCiphertext(params.BaseParams(), params.outputParameters),
+
+		ctTreeDiff:  tfhe.NewGLWECiphertext(params.BaseParams()),
+		ctAccRotate: tfhe.NewGLWECiphertext(params.BaseParams()),
+		ctTable:     tfhe.NewGLWECiphertext(params.BaseParams()),
+		ctExtract:   tfhe.NewLWECiphertextCustom[T](params.BaseParams().GLWEDimension()),
 	}
 }
 
@@ -0,0 +1,101 @@
+package xtfhe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// ByteSize returns the size of the key in bytes.
+func (cbk CircuitBootstrapKey[T]) ByteSize() int {
+	byteSize := 8
+	for _, ct := range cbk.SchemeSwitchKey {
+		byteSize += ct.ByteSize()
+	}
+	return byteSize + galoisKeysByteSize(cbk.TraceKeys)
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	[8] SchemeSwitchKeyCount
+//	    SchemeSwitchKey
+//	[8] TraceKeyCount
+//	    for each trace key, sorted by index:
+//	[8]     Index
+//	        GLWEKeySwitchKey
+func (cbk CircuitBootstrapKey[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var nWrite64 int64
+	var buf [8]byte
+
+	schemeSwitchKeyCount := len(cbk.SchemeSwitchKey)
+	binary.BigEndian.PutUint64(buf[:], uint64(schemeSwitchKeyCount))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	for _, ct := range cbk.SchemeSwitchKey {
+		if nWrite64, err = ct.WriteTo(w); err != nil {
+			return n + nWrite64, err
+		}
+		n += nWrite64
+	}
+
+	if nWrite64, err = galoisKeysWriteTo(w, cbk.TraceKeys); err != nil {
+		return n + nWrite64, err
+	}
+	n += nWrite64
+
+	if n < int64(cbk.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (cbk *CircuitBootstrapKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var nRead64 int64
+	var buf [8]byte
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	schemeSwitchKeyCount := int(binary.BigEndian.Uint64(buf[:]))
+
+	cbk.SchemeSwitchKey = make([]tfhe.FFTGGSWCiphertext[T], schemeSwitchKeyCount)
+	for i := range cbk.SchemeSwitchKey {
+		if nRead64, err = cbk.SchemeSwitchKey[i].ReadFrom(r); err != nil {
+			return n + nRead64, err
+		}
+		n += nRead64
+	}
+
+	if cbk.TraceKeys, nRead64, err = galoisKeysReadFrom[T](r); err != nil {
+		return n + nRead64, err
+	}
+	n += nRead64
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (cbk CircuitBootstrapKey[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, cbk.ByteSize()))
+	_, err = cbk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (cbk *CircuitBootstrapKey[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := cbk.ReadFrom(buf)
+	return err
+}
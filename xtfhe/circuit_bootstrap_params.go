@@ -53,6 +53,11 @@ func (p CircuitBootstrapParameters[T]) BaseParams() tfhe.Parameters[T] {
 	return p.manyLUTParameters.baseParams
 }
 
+// LogQ returns log(Q), where Q is the modulus of the ciphertext.
+func (p CircuitBootstrapParameters[T]) LogQ() int {
+	return p.manyLUTParameters.baseParams.LogQ()
+}
+
 // SchemeSwitchParams returns the gadget parameters for scheme switching.
 func (p CircuitBootstrapParameters[T]) SchemeSwitchParams() tfhe.GadgetParameters[T] {
 	return p.schemeSwitchParameters
@@ -0,0 +1,121 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/math/poly"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// TableLookup evaluates a plaintext table on the message whose bits are encrypted in ctBits,
+// and returns the bits of the output, from the least significant bit.
+// ctBits should be ordered from the least significant bit, usually from [CircuitBootstrapper.CircuitBootstrap].
+//
+// The output bits are packed horizontally in the coefficients of GLWE plaintexts,
+// and the entries of the table are packed vertically across the coefficients and the polynomials.
+// The upper bits of the message select the polynomial using a CMux tree,
+// and the lower bits blind rotate the selected polynomial.
+// Each output is an LWE ciphertext encrypting a bit under the default LWE key,
+// so that it can be circuit bootstrapped again.
+//
+// Entries of the table beyond len(table) are regarded as zero.
+// Panics if len(table) > 2^len(ctBits), or outputBits > PolyRank.
+func (e *CircuitBootstrapper[T]) TableLookup(ctBits []tfhe.FFTGGSWCiphertext[T], table []int, outputBits int) []tfhe.LWECiphertext[T] {
+	ctOut := make([]tfhe.LWECiphertext[T], outputBits)
+	for i := 0; i < outputBits; i++ {
+		ctOut[i] = tfhe.NewLWECiphertext(e.Params.BaseParams())
+	}
+	e.TableLookupTo(ctOut, ctBits, table)
+	return ctOut
+}
+
+// TableLookupTo evaluates a plaintext table on the message whose bits are encrypted in ctBits,
+// and writes the bits of the output to ctOut, from the least significant bit.
+// The number of output bits is given by len(ctOut).
+// See [CircuitBootstrapper.TableLookup] for details.
+//
+// Panics if len(table) > 2^len(ctBits), or len(ctOut) > PolyRank.
+func (e *CircuitBootstrapper[T]) TableLookupTo(ctOut []tfhe.LWECiphertext[T], ctBits []tfhe.FFTGGSWCiphertext[T], table []int) {
+	if len(table) > 1<<len(ctBits) {
+		panic("Table size larger than 2^len(ctBits)")
+	}
+
+	logStride := 0
+	if len(ctOut) > 1 {
+		logStride = num.Log2(len(ctOut)-1) + 1
+	}
+	if logStride > e.Params.BaseParams().LogPolyRank() {
+		panic("Output bit count larger than PolyRank")
+	}
+
+	lowBits := num.Min(len(ctBits), e.Params.BaseParams().LogPolyRank()-logStride)
+	polyCount := 1 << (len(ctBits) - lowBits)
+	for len(e.buf.lutTable) < polyCount {
+		e.buf.lutTable = append(e.buf.lutTable, poly.NewPoly[T](e.Params.BaseParams().PolyRank()))
+	}
+
+	lutTable := e.buf.lutTable[:polyCount]
+	for i := range lutTable {
+		lutTable[i].Clear()
+	}
+	for x, y := range table {
+		offset := (x & (1<<lowBits - 1)) << logStride
+		for j := range ctOut {
+			lutTable[x>>lowBits].Coeffs[offset+j] = e.EncodeLWE((y >> j) & 1).Value
+		}
+	}
+
+	e.tableLookupGLWETo(e.buf.ctTable, ctBits, lutTable, logStride)
+
+	for j := range ctOut {
+		switch e.Params.BaseParams().BootstrapOrder() {
+		case tfhe.OrderBlindRotateKeySwitch:
+			e.buf.ctTable.AsLWECiphertextTo(j, e.buf.ctExtract)
+			e.DefaultKeySwitchTo(ctOut[j], e.buf.ctExtract)
+		case tfhe.OrderKeySwitchBlindRotate:
+			e.buf.ctTable.AsLWECiphertextTo(j, ctOut[j])
+		}
+	}
+}
+
+// tableLookupGLWETo evaluates the packed table lut on the message whose bits are encrypted in ctBits,
+// and writes the rotated GLWE ciphertext to ctOut.
+// Each entry of lut occupies 2^logStride coefficients,
+// so that the entry of the message is moved to the first 2^logStride coefficients of ctOut.
+//
+// lut should have length 2^(len(ctBits) - lowBits),
+// where lowBits = min(len(ctBits), LogPolyRank - logStride).
+func (e *CircuitBootstrapper[T]) tableLookupGLWETo(ctOut tfhe.GLWECiphertext[T], ctBits []tfhe.FFTGGSWCiphertext[T], lut []poly.Poly[T], logStride int) {
+	lowBits := num.Min(len(ctBits), e.Params.BaseParams().LogPolyRank()-logStride)
+	highBits := len(ctBits) - lowBits
+
+	if highBits == 0 {
+		ctOut.Clear()
+		ctOut.Value[0].CopyFrom(lut[0])
+	} else {
+		for len(e.buf.ctTree) < len(lut)/2 {
+			e.buf.ctTree = append(e.buf.ctTree, tfhe.NewGLWECiphertext(e.Params.BaseParams()))
+		}
+
+		// The first level of the CMux tree is computed directly from the plaintexts,
+		// which saves decomposition of zero masks.
+		e.buf.ctTreeDiff.Clear()
+		for i := 0; i < len(lut)/2; i++ {
+			e.buf.ctTree[i].Clear()
+			e.buf.ctTree[i].Value[0].CopyFrom(lut[2*i])
+			e.PolyEvaluator.SubPolyTo(e.buf.ctTreeDiff.Value[0], lut[2*i+1], lut[2*i])
+			e.ExternalProdAddGLWETo(e.buf.ctTree[i], ctBits[lowBits], e.buf.ctTreeDiff)
+		}
+
+		for i := 1; i < highBits; i++ {
+			for j := 0; j < len(lut)>>(i+1); j++ {
+				e.CMuxTo(e.buf.ctTree[j], ctBits[lowBits+i], e.buf.ctTree[2*j], e.buf.ctTree[2*j+1])
+			}
+		}
+		ctOut.CopyFrom(e.buf.ctTree[0])
+	}
+
+	for i := 0; i < lowBits; i++ {
+		e.MonomialMulGLWETo(e.buf.ctAccRotate, ctOut, -(1 << (i + logStride)))
+		e.CMuxTo(ctOut, ctBits[i], ctOut, e.buf.ctAccRotate)
+	}
+}
@@ -1,6 +1,7 @@
 package xtfhe_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/sp301415/tfhe-go/math/vec"
@@ -29,3 +30,48 @@ func TestCircuitBootstrap(t *testing.T) {
 		assert.Equal(t, msgGLWEOut, vec.ScalarMul(msgGLWE, c))
 	}
 }
+
+func TestTableLookup(t *testing.T) {
+	for _, tc := range []struct {
+		inputBits  int
+		outputBits int
+	}{{8, 8}, {12, 3}} {
+		table := make([]int, 1<<tc.inputBits)
+		for x := range table {
+			table[x] = (x*x + 7*x + 3) >> 2
+		}
+
+		for _, m := range []int{0, 1, len(table)/2 + 3, len(table) - 1} {
+			ctBits := make([]tfhe.FFTGGSWCiphertext[uint64], tc.inputBits)
+			for i := range ctBits {
+				ctBits[i] = cbEval.CircuitBootstrap(cbEnc.EncryptLWE((m >> i) & 1))
+			}
+
+			ctOut := cbEval.TableLookup(ctBits, table, tc.outputBits)
+			for j := range ctOut {
+				assert.Equal(t, (table[m]>>j)&1, cbEnc.DecryptLWE(ctOut[j]))
+			}
+		}
+	}
+
+	t.Run("Panic", func(t *testing.T) {
+		ctBits := []tfhe.FFTGGSWCiphertext[uint64]{cbEval.CircuitBootstrap(cbEnc.EncryptLWE(0))}
+		assert.Panics(t, func() { cbEval.TableLookup(ctBits, []int{0, 1, 2}, 1) })
+	})
+}
+
+func TestCircuitBootstrapMarshal(t *testing.T) {
+	var buf bytes.Buffer
+	var cbkOut xtfhe.CircuitBootstrapKey[uint64]
+
+	cbkIn := cbEval.EvalKey
+	n, err := tfhe.WriteEnvelope(&buf, cbParams, cbkIn)
+	assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+cbkIn.ByteSize())
+	assert.NoError(t, err)
+
+	n, err = tfhe.ReadEnvelope(&buf, cbParams, &cbkOut)
+	assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+cbkIn.ByteSize())
+	assert.NoError(t, err)
+
+	assert.Equal(t, cbkIn, cbkOut)
+}
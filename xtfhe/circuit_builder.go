@@ -0,0 +1,103 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/circuit"
+)
+
+// circuitBuilder builds boolean circuits gate by gate.
+type circuitBuilder struct {
+	// inputSizes is the number of wires of each input.
+	inputSizes []int
+	// wireCount is the number of wires allocated so far.
+	wireCount int
+	// gates is the gates added so far.
+	gates []circuit.Gate
+	// constWire is the wires of constant 0 and 1.
+	// It is -1 if not allocated yet.
+	constWire [2]int
+}
+
+// newCircuitBuilder creates a new circuitBuilder with given input sizes.
+func newCircuitBuilder(inputSizes ...int) *circuitBuilder {
+	b := &circuitBuilder{inputSizes: inputSizes, constWire: [2]int{-1, -1}}
+	for _, size := range inputSizes {
+		b.wireCount += size
+	}
+	return b
+}
+
+// gate adds a gate and returns its output wire.
+func (b *circuitBuilder) gate(gateType circuit.GateType, in ...int) int {
+	b.gates = append(b.gates, circuit.Gate{Type: gateType, Input: in, Output: b.wireCount})
+	b.wireCount++
+	return b.wireCount - 1
+}
+
+// constant returns a wire of constant c.
+func (b *circuitBuilder) constant(c bool) int {
+	i := 0
+	if c {
+		i = 1
+	}
+	if b.constWire[i] == -1 {
+		b.constWire[i] = b.gate(circuit.GateEQ, i)
+	}
+	return b.constWire[i]
+}
+
+// not adds a NOT gate.
+func (b *circuitBuilder) not(x int) int {
+	return b.gate(circuit.GateINV, x)
+}
+
+// xor adds an XOR gate.
+func (b *circuitBuilder) xor(x, y int) int {
+	return b.gate(circuit.GateXOR, x, y)
+}
+
+// xnor adds an XNOR gate.
+func (b *circuitBuilder) xnor(x, y int) int {
+	return b.gate(circuit.GateXNOR, x, y)
+}
+
+// and adds an AND gate.
+func (b *circuitBuilder) and(x, y int) int {
+	return b.gate(circuit.GateAND, x, y)
+}
+
+// or adds an OR gate.
+func (b *circuitBuilder) or(x, y int) int {
+	return b.gate(circuit.GateOR, x, y)
+}
+
+// mux adds a MUX gate computing s ? x : y.
+func (b *circuitBuilder) mux(s, x, y int) int {
+	return b.gate(circuit.GateMUX, s, x, y)
+}
+
+// maj adds a MAJ gate computing the majority of x, y and z.
+func (b *circuitBuilder) maj(x, y, z int) int {
+	return b.gate(circuit.GateMAJ, x, y, z)
+}
+
+// build returns the circuit with given outputs.
+// Output wires are copied to the end, as required by [circuit.Circuit].
+func (b *circuitBuilder) build(inputNames, outputNames []string, outputs ...[]int) circuit.Circuit {
+	outputSizes := make([]int, len(outputs))
+	for i, output := range outputs {
+		for _, w := range output {
+			b.gate(circuit.GateEQW, w)
+		}
+		outputSizes[i] = len(output)
+	}
+
+	return circuit.Circuit{
+		WireCount:   b.wireCount,
+		InputSizes:  b.inputSizes,
+		OutputSizes: outputSizes,
+		Gates:       b.gates,
+
+		InputNames:  inputNames,
+		OutputNames: outputNames,
+	}
+}
@@ -0,0 +1,131 @@
+package xtfhe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// Object types of the xtfhe package.
+const (
+	ObjectBFVEvaluationKey    tfhe.ObjectType = 0x0300
+	ObjectCircuitBootstrapKey tfhe.ObjectType = 0x0301
+	ObjectFHEWEvaluationKey   tfhe.ObjectType = 0x0302
+)
+
+// Hash returns the hash of the parameters.
+// Parameters compiled from the same FHEWParametersLiteral have the same hash.
+func (p FHEWParameters[T]) Hash() [tfhe.ParamsHashSize]byte {
+	var buf bytes.Buffer
+	var b [8]byte
+
+	p.baseParams.WriteTo(&buf)
+
+	binary.BigEndian.PutUint64(b[:], math.Float64bits(p.secretKeyStdDev))
+	buf.Write(b[:])
+
+	binary.BigEndian.PutUint64(b[:], uint64(p.windowSize))
+	buf.Write(b[:])
+
+	return tfhe.HashParameters("xtfhe.FHEWParameters", p.LogQ(), &buf)
+}
+
+// Hash returns the hash of the parameters.
+// Parameters compiled from the same ManyLUTParametersLiteral have the same hash.
+func (p ManyLUTParameters[T]) Hash() [tfhe.ParamsHashSize]byte {
+	var buf bytes.Buffer
+	p.hashWriteTo(&buf)
+	return tfhe.HashParameters("xtfhe.ManyLUTParameters", p.LogQ(), &buf)
+}
+
+// hashWriteTo writes the encoding of the parameters hashed by [ManyLUTParameters.Hash].
+func (p ManyLUTParameters[T]) hashWriteTo(buf *bytes.Buffer) {
+	var b [8]byte
+
+	p.baseParams.WriteTo(buf)
+
+	binary.BigEndian.PutUint64(b[:], uint64(p.lutCount))
+	buf.Write(b[:])
+}
+
+// Hash returns the hash of the parameters.
+// Parameters compiled from the same CircuitBootstrapParametersLiteral have the same hash.
+func (p CircuitBootstrapParameters[T]) Hash() [tfhe.ParamsHashSize]byte {
+	var buf bytes.Buffer
+	p.hashWriteTo(&buf)
+	return tfhe.HashParameters("xtfhe.CircuitBootstrapParameters", p.LogQ(), &buf)
+}
+
+// hashWriteTo writes the encoding of the parameters hashed by [CircuitBootstrapParameters.Hash].
+func (p CircuitBootstrapParameters[T]) hashWriteTo(buf *bytes.Buffer) {
+	p.manyLUTParameters.hashWriteTo(buf)
+	p.schemeSwitchParameters.WriteTo(buf)
+	p.traceKeySwitchParameters.WriteTo(buf)
+	p.outputParameters.WriteTo(buf)
+}
+
+// Hash returns the hash of the parameters.
+// Parameters compiled from the same WoPBootstrapParametersLiteral have the same hash.
+func (p WoPBootstrapParameters[T]) Hash() [tfhe.ParamsHashSize]byte {
+	var buf bytes.Buffer
+	var b [8]byte
+
+	p.circuitBootstrapParameters.hashWriteTo(&buf)
+
+	binary.BigEndian.PutUint64(b[:], uint64(p.inputBits))
+	buf.Write(b[:])
+
+	binary.BigEndian.PutUint64(b[:], uint64(p.outputBits))
+	buf.Write(b[:])
+
+	return tfhe.HashParameters("xtfhe.WoPBootstrapParameters", p.LogQ(), &buf)
+}
+
+// Hash returns the hash of the parameters.
+// Parameters compiled from the same SanitizationParametersLiteral have the same hash.
+func (p SanitizationParameters[T]) Hash() [tfhe.ParamsHashSize]byte {
+	var buf bytes.Buffer
+	var b [8]byte
+
+	p.baseParams.WriteTo(&buf)
+
+	for _, f := range []float64{p.randSigma, p.randTau, p.linEvalSigma, p.linEvalTau} {
+		binary.BigEndian.PutUint64(b[:], math.Float64bits(f))
+		buf.Write(b[:])
+	}
+
+	return tfhe.HashParameters("xtfhe.SanitizationParameters", p.LogQ(), &buf)
+}
+
+// ObjectType returns [ObjectBFVEvaluationKey].
+func (evk BFVEvaluationKey[T]) ObjectType() tfhe.ObjectType {
+	return ObjectBFVEvaluationKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (evk BFVEvaluationKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectCircuitBootstrapKey].
+func (cbk CircuitBootstrapKey[T]) ObjectType() tfhe.ObjectType {
+	return ObjectCircuitBootstrapKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (cbk CircuitBootstrapKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
+
+// ObjectType returns [ObjectFHEWEvaluationKey].
+func (evk FHEWEvaluationKey[T]) ObjectType() tfhe.ObjectType {
+	return ObjectFHEWEvaluationKey
+}
+
+// TorusIntSize returns the size of T in bytes.
+func (evk FHEWEvaluationKey[T]) TorusIntSize() int {
+	return num.SizeT[T]() / 8
+}
@@ -1,6 +1,7 @@
 package xtfhe
 
 import (
+	"github.com/sp301415/tfhe-go/internal/parallel"
 	"github.com/sp301415/tfhe-go/math/num"
 	"github.com/sp301415/tfhe-go/tfhe"
 )
@@ -38,6 +39,122 @@ func (e *FHEWEvaluator[T]) BootstrapLUTTo(ctOut tfhe.LWECiphertext[T], ct tfhe.L
 	}
 }
 
+// BootstrapFullDomainFunc returns a bootstrapped LWE ciphertext with respect to the given function,
+// using full-domain bootstrapping.
+// Input and output ciphertexts are encoded by [tfhe.Encoder.EncodeLWEFullDomain].
+func (e *FHEWEvaluator[T]) BootstrapFullDomainFunc(ct tfhe.LWECiphertext[T], f func(int) int) tfhe.LWECiphertext[T] {
+	e.GenFullDomainLUTTo(e.buf.lutFullDomain, f)
+	return e.BootstrapFullDomainLUT(ct, e.buf.lutFullDomain)
+}
+
+// BootstrapFullDomainFuncTo bootstraps LWE ciphertext with respect to the given function and writes it to ctOut,
+// using full-domain bootstrapping.
+// Input and output ciphertexts are encoded by [tfhe.Encoder.EncodeLWEFullDomain].
+func (e *FHEWEvaluator[T]) BootstrapFullDomainFuncTo(ctOut, ct tfhe.LWECiphertext[T], f func(int) int) {
+	e.GenFullDomainLUTTo(e.buf.lutFullDomain, f)
+	e.BootstrapFullDomainLUTTo(ctOut, ct, e.buf.lutFullDomain)
+}
+
+// BootstrapFullDomainLUT returns a bootstrapped LWE ciphertext with respect to the given full-domain LUT.
+// Input and output ciphertexts are encoded by [tfhe.Encoder.EncodeLWEFullDomain].
+func (e *FHEWEvaluator[T]) BootstrapFullDomainLUT(ct tfhe.LWECiphertext[T], lut tfhe.FullDomainLookUpTable[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertext(e.Params.baseParams)
+	e.BootstrapFullDomainLUTTo(ctOut, ct, lut)
+	return ctOut
+}
+
+// BootstrapFullDomainLUTTo bootstraps LWE ciphertext with respect to the given full-domain LUT and writes it to ctOut.
+// Input and output ciphertexts are encoded by [tfhe.Encoder.EncodeLWEFullDomain].
+func (e *FHEWEvaluator[T]) BootstrapFullDomainLUTTo(ctOut, ct tfhe.LWECiphertext[T], lut tfhe.FullDomainLookUpTable[T]) {
+	e.BootstrapLUTTo(e.buf.ctSign, ct, e.lutSign)
+	e.buf.ctSign.Value[0] += 1 << (e.Params.baseParams.LogQ() - 2)
+	e.AddLWETo(e.buf.ctSign, ct, e.buf.ctSign)
+
+	e.BootstrapLUTTo(e.buf.ctSign, e.buf.ctSign, lut.Sum)
+	e.BootstrapLUTTo(ctOut, ct, lut.Diff)
+	e.AddLWETo(ctOut, ctOut, e.buf.ctSign)
+}
+
+// BootstrapFuncBatch is a parallel version of [FHEWEvaluator.BootstrapFunc].
+// If len(f) == 1, the same function is used for all ciphertexts.
+// Otherwise, ct[i] is bootstrapped with respect to f[i].
+// Each worker is a [FHEWEvaluator.SafeCopy] of e sharing its FHEWEvaluationKey,
+// and the number of workers is set by [tfhe.Evaluator.SetBatchWorkerCount].
+//
+// Panics if len(f) != 1 and len(f) != len(ct).
+func (e *FHEWEvaluator[T]) BootstrapFuncBatch(ct []tfhe.LWECiphertext[T], f []func(int) int) []tfhe.LWECiphertext[T] {
+	ctOut := make([]tfhe.LWECiphertext[T], len(ct))
+	for i := range ctOut {
+		ctOut[i] = tfhe.NewLWECiphertext(e.Params.baseParams)
+	}
+	e.BootstrapFuncBatchTo(ctOut, ct, f)
+	return ctOut
+}
+
+// BootstrapFuncBatchTo is a parallel version of [FHEWEvaluator.BootstrapFuncTo].
+// If len(f) == 1, the same function is used for all ciphertexts.
+// Otherwise, ct[i] is bootstrapped with respect to f[i].
+//
+// Panics if len(f) != 1 and len(f) != len(ct), or len(ctOut) != len(ct).
+func (e *FHEWEvaluator[T]) BootstrapFuncBatchTo(ctOut, ct []tfhe.LWECiphertext[T], f []func(int) int) {
+	if len(f) == 1 {
+		e.GenLUTTo(e.buf.lut, f[0])
+		e.BootstrapLUTBatchTo(ctOut, ct, []tfhe.LookUpTable[T]{e.buf.lut})
+		return
+	}
+
+	if len(f) != len(ct) {
+		panic("Function count mismatch")
+	}
+	if len(ctOut) != len(ct) {
+		panic("Ciphertext count mismatch")
+	}
+
+	pool := parallel.Workers(&e.batchPool, e.BatchWorkerCount(), len(ct), e.SafeCopy)
+	parallel.Run(len(pool), len(ct), func(worker, i int) {
+		pool[worker].BootstrapFuncTo(ctOut[i], ct[i], f[i])
+	})
+}
+
+// BootstrapLUTBatch is a parallel version of [FHEWEvaluator.BootstrapLUT].
+// If len(lut) == 1, the same LUT is used for all ciphertexts.
+// Otherwise, ct[i] is bootstrapped with respect to lut[i].
+// Each worker is a [FHEWEvaluator.SafeCopy] of e sharing its FHEWEvaluationKey,
+// and the number of workers is set by [tfhe.Evaluator.SetBatchWorkerCount].
+//
+// Panics if len(lut) != 1 and len(lut) != len(ct).
+func (e *FHEWEvaluator[T]) BootstrapLUTBatch(ct []tfhe.LWECiphertext[T], lut []tfhe.LookUpTable[T]) []tfhe.LWECiphertext[T] {
+	ctOut := make([]tfhe.LWECiphertext[T], len(ct))
+	for i := range ctOut {
+		ctOut[i] = tfhe.NewLWECiphertext(e.Params.baseParams)
+	}
+	e.BootstrapLUTBatchTo(ctOut, ct, lut)
+	return ctOut
+}
+
+// BootstrapLUTBatchTo is a parallel version of [FHEWEvaluator.BootstrapLUTTo].
+// If len(lut) == 1, the same LUT is used for all ciphertexts.
+// Otherwise, ct[i] is bootstrapped with respect to lut[i].
+//
+// Panics if len(lut) != 1 and len(lut) != len(ct), or len(ctOut) != len(ct).
+func (e *FHEWEvaluator[T]) BootstrapLUTBatchTo(ctOut, ct []tfhe.LWECiphertext[T], lut []tfhe.LookUpTable[T]) {
+	if len(lut) != 1 && len(lut) != len(ct) {
+		panic("LUT count mismatch")
+	}
+	if len(ctOut) != len(ct) {
+		panic("Ciphertext count mismatch")
+	}
+
+	pool := parallel.Workers(&e.batchPool, e.BatchWorkerCount(), len(ct), e.SafeCopy)
+	parallel.Run(len(pool), len(ct), func(worker, i int) {
+		if len(lut) == 1 {
+			pool[worker].BootstrapLUTTo(ctOut[i], ct[i], lut[0])
+		} else {
+			pool[worker].BootstrapLUTTo(ctOut[i], ct[i], lut[i])
+		}
+	})
+}
+
 // ModSwitch switches the modulus of x from Q to 2 * LUTSize.
 func (e *FHEWEvaluator[T]) ModSwitch(x T) int {
 	return e.Evaluator.ModSwitch(x) | 1
@@ -0,0 +1,111 @@
+package xtfhe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// baseEvalKey returns the blind rotation and keyswitching keys as a [tfhe.EvaluationKey].
+func (evk FHEWEvaluationKey[T]) baseEvalKey() tfhe.EvaluationKey[T] {
+	return tfhe.EvaluationKey[T]{
+		BlindRotateKey: evk.BlindRotateKey,
+		KeySwitchKey:   evk.KeySwitchKey,
+	}
+}
+
+// ByteSize returns the size of the key in bytes.
+func (evk FHEWEvaluationKey[T]) ByteSize() int {
+	byteSize := evk.baseEvalKey().ByteSize() + 8
+	for _, galKey := range evk.GaloisKey {
+		byteSize += galKey.ByteSize()
+	}
+	return byteSize
+}
+
+// WriteTo implements the [io.WriterTo] interface.
+//
+// The encoded form is as follows:
+//
+//	    EvaluationKey
+//	[8] GaloisKeyCount
+//	    GaloisKey
+//
+// EvaluationKey is a [tfhe.EvaluationKey] holding BlindRotateKey and KeySwitchKey.
+func (evk FHEWEvaluationKey[T]) WriteTo(w io.Writer) (n int64, err error) {
+	var nWrite int
+	var nWrite64 int64
+	var buf [8]byte
+
+	if nWrite64, err = evk.baseEvalKey().WriteTo(w); err != nil {
+		return n + nWrite64, err
+	}
+	n += nWrite64
+
+	galKeyCount := len(evk.GaloisKey)
+	binary.BigEndian.PutUint64(buf[:], uint64(galKeyCount))
+	if nWrite, err = w.Write(buf[:]); err != nil {
+		return n + int64(nWrite), err
+	}
+	n += int64(nWrite)
+
+	for _, galKey := range evk.GaloisKey {
+		if nWrite64, err = galKey.WriteTo(w); err != nil {
+			return n + nWrite64, err
+		}
+		n += nWrite64
+	}
+
+	if n < int64(evk.ByteSize()) {
+		return n, io.ErrShortWrite
+	}
+
+	return
+}
+
+// ReadFrom implements the [io.ReaderFrom] interface.
+func (evk *FHEWEvaluationKey[T]) ReadFrom(r io.Reader) (n int64, err error) {
+	var nRead int
+	var nRead64 int64
+	var buf [8]byte
+
+	var baseEvalKey tfhe.EvaluationKey[T]
+	if nRead64, err = baseEvalKey.ReadFrom(r); err != nil {
+		return n + nRead64, err
+	}
+	n += nRead64
+	evk.BlindRotateKey = baseEvalKey.BlindRotateKey
+	evk.KeySwitchKey = baseEvalKey.KeySwitchKey
+
+	if nRead, err = io.ReadFull(r, buf[:]); err != nil {
+		return n + int64(nRead), err
+	}
+	n += int64(nRead)
+	galKeyCount := int(binary.BigEndian.Uint64(buf[:]))
+
+	evk.GaloisKey = make([]tfhe.GLWEKeySwitchKey[T], galKeyCount)
+	for i := range evk.GaloisKey {
+		if nRead64, err = evk.GaloisKey[i].ReadFrom(r); err != nil {
+			return n + nRead64, err
+		}
+		n += nRead64
+	}
+
+	return
+}
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+func (evk FHEWEvaluationKey[T]) MarshalBinary() (data []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, evk.ByteSize()))
+	_, err = evk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+func (evk *FHEWEvaluationKey[T]) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+	_, err := evk.ReadFrom(buf)
+	return err
+}
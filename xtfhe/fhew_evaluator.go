@@ -22,6 +22,14 @@ type FHEWEvaluator[T tfhe.TorusInt] struct {
 	// autIdxMap holds the map ±5^i -> ±i mod 2N.
 	autIdxMap []int
 
+	// lutSign is a precomputed LUT from [tfhe.Evaluator.GenSignLUT], used for full-domain bootstrapping.
+	// It is never written after NewFHEWEvaluator, so it is shared with safe copies.
+	lutSign tfhe.LookUpTable[T]
+
+	// batchPool is a pool of FHEWEvaluators for batch bootstrapping.
+	// It is allocated on the first batch bootstrapping.
+	batchPool []*FHEWEvaluator[T]
+
 	buf fhewEvaluatorBuffer[T]
 }
 
@@ -41,8 +49,13 @@ type fhewEvaluatorBuffer[T tfhe.TorusInt] struct {
 	// ctKeySwitch is the LWEDimension-sized ciphertext from keyswitching for bootstrapping.
 	ctKeySwitch tfhe.LWECiphertext[T]
 
+	// ctSign is a ciphertext for full-domain bootstrapping.
+	ctSign tfhe.LWECiphertext[T]
+
 	// lut is an empty lut, used for BlindRotateFunc.
 	lut tfhe.LookUpTable[T]
+	// lutFullDomain is an empty full-domain lut, used for BootstrapFullDomainFunc.
+	lutFullDomain tfhe.FullDomainLookUpTable[T]
 }
 
 // NewFHEWEvaluator creates a new [FHEWEvaluator].
@@ -56,7 +69,7 @@ func NewFHEWEvaluator[T tfhe.TorusInt](params FHEWParameters[T], evk FHEWEvaluat
 	}
 	autIdxMap[2*params.baseParams.PolyRank()-1] = 2 * params.baseParams.PolyRank()
 
-	return &FHEWEvaluator[T]{
+	e := &FHEWEvaluator[T]{
 		Evaluator: tfhe.NewEvaluator(params.baseParams, tfhe.EvaluationKey[T]{KeySwitchKey: evk.KeySwitchKey}),
 
 		Params: params,
@@ -67,6 +80,9 @@ func NewFHEWEvaluator[T tfhe.TorusInt](params FHEWParameters[T], evk FHEWEvaluat
 
 		buf: newFHEWEvaluatorBuffer(params),
 	}
+	e.lutSign = e.GenSignLUT()
+
+	return e
 }
 
 // newFHEWEvaluatorBuffer creates a new [fhewEvaluatorBuffer].
@@ -88,7 +104,10 @@ func newFHEWEvaluatorBuffer[T tfhe.TorusInt](params FHEWParameters[T]) fhewEvalu
 		ctExtract:   tfhe.NewLWECiphertextCustom[T](params.baseParams.GLWEDimension()),
 		ctKeySwitch: tfhe.NewLWECiphertextCustom[T](params.baseParams.LWEDimension()),
 
-		lut: tfhe.NewLUT(params.baseParams),
+		ctSign: tfhe.NewLWECiphertext(params.baseParams),
+
+		lut:           tfhe.NewLUT(params.baseParams),
+		lutFullDomain: tfhe.NewFullDomainLUT(params.baseParams),
 	}
 }
 
@@ -104,6 +123,8 @@ func (e *FHEWEvaluator[T]) SafeCopy() *FHEWEvaluator[T] {
 
 		autIdxMap: e.autIdxMap,
 
+		lutSign: e.lutSign,
+
 		buf: newFHEWEvaluatorBuffer(e.Params),
 	}
 }
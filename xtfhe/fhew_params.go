@@ -33,6 +33,8 @@ func (p FHEWParametersLiteral[T]) Compile() FHEWParameters[T] {
 	switch {
 	case baseParams.BlockSize() != 1:
 		panic("BlockSize not 1")
+	case baseParams.GroupingFactor() != 1:
+		panic("GroupingFactor not 1")
 	case baseParams.PolyRank() != baseParams.LUTSize():
 		panic("PolyRank does not equal LUTSize")
 	case p.SecretKeyStdDev <= 0:
@@ -69,6 +71,11 @@ func (p FHEWParameters[T]) BaseParams() tfhe.Parameters[T] {
 	return p.baseParams
 }
 
+// LogQ returns log(Q), where Q is the modulus of the ciphertext.
+func (p FHEWParameters[T]) LogQ() int {
+	return p.baseParams.LogQ()
+}
+
 // SecretKeyStdDev returns the standard deviation of the secret key.
 //
 // This is a normalized standard deviation.
@@ -1,8 +1,10 @@
 package xtfhe_test
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/sp301415/tfhe-go/tfhe"
 	"github.com/sp301415/tfhe-go/xtfhe"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,3 +22,65 @@ func TestFHEW(t *testing.T) {
 		assert.Equal(t, fhewEnc.DecryptLWE(ctOut), msg^1)
 	}
 }
+
+func TestFHEWBatch(t *testing.T) {
+	msgs := []int{0, 1, 1, 0}
+	cts := make([]tfhe.LWECiphertext[uint64], len(msgs))
+	for i, msg := range msgs {
+		cts[i] = fhewEnc.EncryptLWE(msg)
+	}
+
+	t.Run("BootstrapFuncBatch", func(t *testing.T) {
+		ctOut := fhewEval.BootstrapFuncBatch(cts, []func(int) int{func(x int) int { return x ^ 1 }})
+		for i, msg := range msgs {
+			assert.Equal(t, fhewEnc.DecryptLWE(ctOut[i]), msg^1)
+		}
+	})
+
+	t.Run("BootstrapLUTBatch", func(t *testing.T) {
+		fs := []func(int) int{
+			func(x int) int { return x ^ 1 },
+			func(x int) int { return x },
+		}
+		luts := make([]tfhe.LookUpTable[uint64], len(msgs))
+		for i := range msgs {
+			luts[i] = fhewEval.GenLUT(fs[i%len(fs)])
+		}
+
+		ctOut := fhewEval.BootstrapLUTBatch(cts, luts)
+		for i, msg := range msgs {
+			assert.Equal(t, fhewEnc.DecryptLWE(ctOut[i]), fs[i%len(fs)](msg))
+		}
+
+		assert.Panics(t, func() { fhewEval.BootstrapLUTBatch(cts, luts[:2]) })
+	})
+}
+
+func TestFHEWFullDomain(t *testing.T) {
+	f := func(x int) int { return 3*x + 1 }
+	for _, msg := range []int{0, 1, 2, 3} {
+		ct := fhewEnc.EncryptLWEFullDomain(msg)
+		ctOut := fhewEval.BootstrapFullDomainFunc(ct, f)
+		assert.Equal(t, fhewEnc.DecryptLWEFullDomain(ctOut), f(msg)%4)
+	}
+}
+
+func TestFHEWMarshal(t *testing.T) {
+	var buf bytes.Buffer
+	var evkOut xtfhe.FHEWEvaluationKey[uint64]
+
+	evkIn := fhewEval.EvalKey
+	n, err := tfhe.WriteEnvelope(&buf, fhewParams, evkIn)
+	assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+evkIn.ByteSize())
+	assert.NoError(t, err)
+
+	n, err = tfhe.ReadEnvelope(&buf, fhewParams, &evkOut)
+	assert.Equal(t, int(n), tfhe.EnvelopeHeaderSize+evkIn.ByteSize())
+	assert.NoError(t, err)
+
+	assert.Equal(t, evkIn, evkOut)
+
+	data, err := tfhe.MarshalEnvelope(fhewParams, evkIn)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, tfhe.UnmarshalEnvelope(data, fhewParams.BaseParams(), &evkOut), tfhe.ErrParamsMismatch)
+}
@@ -0,0 +1,363 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+const (
+	// HashDigestBits is the number of bits in the digest of SHA-256 and BLAKE2s-256.
+	HashDigestBits = 256
+	// HashBlockBits is the number of bits in a message block of SHA-256 and BLAKE2s.
+	HashBlockBits = 512
+)
+
+// sha256IV is the initial hash value of SHA-256, which is also the IV of BLAKE2s.
+var sha256IV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+// sha256K is the round constants of SHA-256.
+var sha256K = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+// blake2sSigma is the message schedule of BLAKE2s.
+var blake2sSigma = [10][16]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// HashEvaluator evaluates SHA-256 and BLAKE2s-256 on encrypted messages using [tfhe.BinaryEvaluator].
+// This is meant to be public, usually for servers.
+//
+// Messages are slices of LWE ciphertexts encrypted with [tfhe.BinaryEncryptor],
+// where bit i is the (i%8)-th least significant bit of the (i/8)-th byte,
+// so that each byte can be encrypted using EncryptLWEBits.
+// Digests are returned in the same format.
+// The length of the message is public.
+//
+// The hash circuits are built from [SHA256Circuit] and [BLAKE2sCircuit],
+// and compiled using [circuit.Compile].
+// Gates of each level are evaluated in parallel.
+//
+// HashEvaluator is not safe for concurrent use.
+// Use [HashEvaluator.SafeCopy] to get a safe copy.
+type HashEvaluator[T tfhe.TorusInt] struct {
+	// Evaluator is a circuit Evaluator for this HashEvaluator.
+	Evaluator *circuit.Evaluator[T]
+	// Params is the parameter set for this HashEvaluator.
+	Params tfhe.Parameters[T]
+}
+
+// NewHashEvaluator creates a new [HashEvaluator].
+// This does not copy evaluation keys, since they are large.
+func NewHashEvaluator[T tfhe.TorusInt](params tfhe.Parameters[T], evk tfhe.EvaluationKey[T]) *HashEvaluator[T] {
+	return &HashEvaluator[T]{
+		Evaluator: circuit.NewEvaluator(params, evk),
+		Params:    params,
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *HashEvaluator[T]) SafeCopy() *HashEvaluator[T] {
+	return &HashEvaluator[T]{
+		Evaluator: e.Evaluator.SafeCopy(),
+		Params:    e.Params,
+	}
+}
+
+// newDigest allocates a new digest.
+func (e *HashEvaluator[T]) newDigest() []tfhe.LWECiphertext[T] {
+	ct := make([]tfhe.LWECiphertext[T], HashDigestBits)
+	for i := range ct {
+		ct[i] = tfhe.NewLWECiphertext(e.Params)
+	}
+	return ct
+}
+
+// SHA256 returns the SHA-256 digest of the encrypted message.
+//
+// Panics if len(ctMsg) is not a multiple of 8.
+func (e *HashEvaluator[T]) SHA256(ctMsg []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newDigest()
+	e.SHA256To(ctOut, ctMsg)
+	return ctOut
+}
+
+// SHA256To computes the SHA-256 digest of the encrypted message and writes it to ctOut.
+//
+// Panics if len(ctMsg) is not a multiple of 8, or len(ctOut) != HashDigestBits.
+func (e *HashEvaluator[T]) SHA256To(ctOut, ctMsg []tfhe.LWECiphertext[T]) {
+	if len(ctMsg)%8 != 0 {
+		panic("Message length not multiple of 8")
+	}
+	e.Evaluator.EvaluateProgramParallelTo([][]tfhe.LWECiphertext[T]{ctOut}, circuit.Compile(SHA256Circuit(len(ctMsg)/8)), [][]tfhe.LWECiphertext[T]{ctMsg})
+}
+
+// BLAKE2s returns the unkeyed BLAKE2s-256 digest of the encrypted message.
+//
+// Panics if len(ctMsg) is not a multiple of 8.
+func (e *HashEvaluator[T]) BLAKE2s(ctMsg []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newDigest()
+	e.BLAKE2sTo(ctOut, ctMsg)
+	return ctOut
+}
+
+// BLAKE2sTo computes the unkeyed BLAKE2s-256 digest of the encrypted message and writes it to ctOut.
+//
+// Panics if len(ctMsg) is not a multiple of 8, or len(ctOut) != HashDigestBits.
+func (e *HashEvaluator[T]) BLAKE2sTo(ctOut, ctMsg []tfhe.LWECiphertext[T]) {
+	if len(ctMsg)%8 != 0 {
+		panic("Message length not multiple of 8")
+	}
+	e.Evaluator.EvaluateProgramParallelTo([][]tfhe.LWECiphertext[T]{ctOut}, circuit.Compile(BLAKE2sCircuit(len(ctMsg)/8)), [][]tfhe.LWECiphertext[T]{ctMsg})
+}
+
+// SHA256Circuit returns a boolean circuit for SHA-256 of messages with msgLen bytes.
+// It has one input "message" of 8*msgLen bits, and one output "digest" of HashDigestBits bits,
+// both from the least significant bit of the first byte.
+// The padding is computed in the circuit as constants.
+func SHA256Circuit(msgLen int) circuit.Circuit {
+	b := newCircuitBuilder(8 * msgLen)
+
+	padLen := (msgLen+8)/64*64 + 64
+	msg := make([][8]int, padLen)
+	for i := 0; i < padLen; i++ {
+		var c byte
+		switch {
+		case i < msgLen:
+			for j := 0; j < 8; j++ {
+				msg[i][j] = 8*i + j
+			}
+			continue
+		case i == msgLen:
+			c = 0x80
+		case i >= padLen-8:
+			c = byte(uint64(8*msgLen) >> (8 * (padLen - 1 - i)))
+		}
+		for j := 0; j < 8; j++ {
+			msg[i][j] = b.constant((c>>j)&1 == 1)
+		}
+	}
+
+	var h [8]word
+	for i := range h {
+		h[i] = b.constWord(sha256IV[i])
+	}
+	for k := 0; k < padLen; k += 64 {
+		var m [16]word
+		for i := range m {
+			m[i] = bytesToWordBE(msg[k+4*i : k+4*i+4])
+		}
+		h = b.sha256Compress(h, m)
+	}
+
+	digest := make([]int, 0, HashDigestBits)
+	for i := range h {
+		for j := 3; j >= 0; j-- {
+			digest = append(digest, h[i][8*j:8*j+8]...)
+		}
+	}
+	return b.build([]string{"message"}, []string{"digest"}, digest)
+}
+
+// SHA256CompressCircuit returns a boolean circuit for the compression function of SHA-256.
+// It has two inputs "state" of HashDigestBits bits and "block" of HashBlockBits bits,
+// and one output "state" of HashDigestBits bits.
+// Each input and output is a sequence of 32-bit words, each from the least significant bit.
+func SHA256CompressCircuit() circuit.Circuit {
+	b := newCircuitBuilder(HashDigestBits, HashBlockBits)
+
+	var h [8]word
+	for i := range h {
+		h[i] = b.inputWord(WordBits * i)
+	}
+	var m [16]word
+	for i := range m {
+		m[i] = b.inputWord(HashDigestBits + WordBits*i)
+	}
+	h = b.sha256Compress(h, m)
+
+	return b.build([]string{"state", "block"}, []string{"state"}, wordsToBits(h[:]))
+}
+
+// BLAKE2sCircuit returns a boolean circuit for the unkeyed BLAKE2s-256 of messages with msgLen bytes.
+// It has one input "message" of 8*msgLen bits, and one output "digest" of HashDigestBits bits,
+// both from the least significant bit of the first byte.
+func BLAKE2sCircuit(msgLen int) circuit.Circuit {
+	b := newCircuitBuilder(8 * msgLen)
+
+	blockCount := (msgLen + 63) / 64
+	if blockCount == 0 {
+		blockCount = 1
+	}
+	msg := make([][8]int, 64*blockCount)
+	for i := range msg {
+		for j := 0; j < 8; j++ {
+			if i < msgLen {
+				msg[i][j] = 8*i + j
+			} else {
+				msg[i][j] = b.constant(false)
+			}
+		}
+	}
+
+	var h [8]word
+	for i := range h {
+		h[i] = b.constWord(sha256IV[i])
+	}
+	// Parameter block for 32-byte digest without key.
+	h[0] = b.constWord(sha256IV[0] ^ 0x01010020)
+
+	for k := 0; k < blockCount; k++ {
+		var m [16]word
+		for i := range m {
+			m[i] = bytesToWordLE(msg[64*k+4*i : 64*k+4*i+4])
+		}
+
+		counter := uint64(64 * (k + 1))
+		final := k == blockCount-1
+		if final {
+			counter = uint64(msgLen)
+		}
+		h = b.blake2sCompress(h, m, counter, final)
+	}
+
+	return b.build([]string{"message"}, []string{"digest"}, wordsToBits(h[:]))
+}
+
+// BLAKE2sCompressCircuit returns a boolean circuit for the compression function of BLAKE2s,
+// with the byte counter and the final block flag.
+// It has two inputs "state" of HashDigestBits bits and "block" of HashBlockBits bits,
+// and one output "state" of HashDigestBits bits.
+// Each input and output is a sequence of 32-bit words, each from the least significant bit.
+func BLAKE2sCompressCircuit(counter uint64, final bool) circuit.Circuit {
+	b := newCircuitBuilder(HashDigestBits, HashBlockBits)
+
+	var h [8]word
+	for i := range h {
+		h[i] = b.inputWord(WordBits * i)
+	}
+	var m [16]word
+	for i := range m {
+		m[i] = b.inputWord(HashDigestBits + WordBits*i)
+	}
+	h = b.blake2sCompress(h, m, counter, final)
+
+	return b.build([]string{"state", "block"}, []string{"state"}, wordsToBits(h[:]))
+}
+
+// bytesToWordBE returns a word from 4 bytes in big endian.
+func bytesToWordBE(x [][8]int) word {
+	var w word
+	for i := 0; i < 4; i++ {
+		copy(w[8*(3-i):], x[i][:])
+	}
+	return w
+}
+
+// bytesToWordLE returns a word from 4 bytes in little endian.
+func bytesToWordLE(x [][8]int) word {
+	var w word
+	for i := 0; i < 4; i++ {
+		copy(w[8*i:], x[i][:])
+	}
+	return w
+}
+
+// wordsToBits flattens words to wires.
+// For little endian words, this is equivalent to converting them to bytes.
+func wordsToBits(x []word) []int {
+	bits := make([]int, 0, WordBits*len(x))
+	for i := range x {
+		bits = append(bits, x[i][:]...)
+	}
+	return bits
+}
+
+// sha256Compress applies the compression function of SHA-256.
+func (b *circuitBuilder) sha256Compress(h [8]word, m [16]word) [8]word {
+	var w [64]word
+	copy(w[:], m[:])
+	for t := 16; t < 64; t++ {
+		s0 := b.xorWord(b.xorWord(rotateRightWord(w[t-15], 7), rotateRightWord(w[t-15], 18)), b.shiftRightWord(w[t-15], 3))
+		s1 := b.xorWord(b.xorWord(rotateRightWord(w[t-2], 17), rotateRightWord(w[t-2], 19)), b.shiftRightWord(w[t-2], 10))
+		w[t] = b.addWord(b.addWord(s1, w[t-7]), b.addWord(s0, w[t-16]))
+	}
+
+	v := h
+	for t := 0; t < 64; t++ {
+		sum1 := b.xorWord(b.xorWord(rotateRightWord(v[4], 6), rotateRightWord(v[4], 11)), rotateRightWord(v[4], 25))
+		ch := b.chooseWord(v[4], v[5], v[6])
+		t1 := b.addWord(b.addWord(v[7], sum1), b.addWord(ch, b.addWord(b.constWord(sha256K[t]), w[t])))
+
+		sum0 := b.xorWord(b.xorWord(rotateRightWord(v[0], 2), rotateRightWord(v[0], 13)), rotateRightWord(v[0], 22))
+		maj := b.majorityWord(v[0], v[1], v[2])
+		t2 := b.addWord(sum0, maj)
+
+		v = [8]word{b.addWord(t1, t2), v[0], v[1], v[2], b.addWord(v[3], t1), v[4], v[5], v[6]}
+	}
+
+	for i := range h {
+		h[i] = b.addWord(h[i], v[i])
+	}
+	return h
+}
+
+// blake2sCompress applies the compression function of BLAKE2s.
+func (b *circuitBuilder) blake2sCompress(h [8]word, m [16]word, counter uint64, final bool) [8]word {
+	var v [16]word
+	copy(v[:8], h[:])
+	for i := 0; i < 8; i++ {
+		v[8+i] = b.constWord(sha256IV[i])
+	}
+	v[12] = b.constWord(sha256IV[4] ^ uint32(counter))
+	v[13] = b.constWord(sha256IV[5] ^ uint32(counter>>32))
+	if final {
+		v[14] = b.notWord(v[14])
+	}
+
+	mix := func(ia, ib, ic, id int, x, y word) {
+		v[ia] = b.addWord(b.addWord(v[ia], v[ib]), x)
+		v[id] = rotateRightWord(b.xorWord(v[id], v[ia]), 16)
+		v[ic] = b.addWord(v[ic], v[id])
+		v[ib] = rotateRightWord(b.xorWord(v[ib], v[ic]), 12)
+		v[ia] = b.addWord(b.addWord(v[ia], v[ib]), y)
+		v[id] = rotateRightWord(b.xorWord(v[id], v[ia]), 8)
+		v[ic] = b.addWord(v[ic], v[id])
+		v[ib] = rotateRightWord(b.xorWord(v[ib], v[ic]), 7)
+	}
+
+	for r := 0; r < 10; r++ {
+		s := blake2sSigma[r]
+		mix(0, 4, 8, 12, m[s[0]], m[s[1]])
+		mix(1, 5, 9, 13, m[s[2]], m[s[3]])
+		mix(2, 6, 10, 14, m[s[4]], m[s[5]])
+		mix(3, 7, 11, 15, m[s[6]], m[s[7]])
+		mix(0, 5, 10, 15, m[s[8]], m[s[9]])
+		mix(1, 6, 11, 12, m[s[10]], m[s[11]])
+		mix(2, 7, 8, 13, m[s[12]], m[s[13]])
+		mix(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range h {
+		h[i] = b.xorWord(b.xorWord(h[i], v[i]), v[i+8])
+	}
+	return h
+}
@@ -0,0 +1,142 @@
+package xtfhe_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/xtfhe"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	hashEval = xtfhe.NewHashEvaluator(binaryParams, binaryEvk)
+)
+
+// hashTestVectors are the test vectors of SHA-256 and BLAKE2s-256.
+// The BLAKE2s-256 digest of "abc" is from RFC 7693, Appendix B.
+var hashTestVectors = []struct {
+	msg     string
+	sha256  string
+	blake2s string
+}{
+	{
+		msg:     "",
+		sha256:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		blake2s: "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9",
+	},
+	{
+		msg:     "abc",
+		sha256:  "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		blake2s: "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982",
+	},
+	{
+		msg:     "abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq",
+		sha256:  "248d6a61d20638b8e5c026930c3e6039a33ce45964ff2167f6ecedd419db06c1",
+		blake2s: "6f4df5116a6f332edab1d9e10ee87df6557beab6259d7663f3bcd5722c13f189",
+	},
+}
+
+func wordsToBools(w []uint32) []bool {
+	b := make([]byte, 4*len(w))
+	for i := range w {
+		binary.LittleEndian.PutUint32(b[4*i:], w[i])
+	}
+	return bytesToBools(b)
+}
+
+func TestHash(t *testing.T) {
+	evalHash := func(c circuit.Circuit, msg []byte) string {
+		return hex.EncodeToString(evaluateBytes(circuit.Compile(c), msg))
+	}
+
+	t.Run("TestVector", func(t *testing.T) {
+		for _, tv := range hashTestVectors {
+			assert.Equal(t, evalHash(xtfhe.SHA256Circuit(len(tv.msg)), []byte(tv.msg)), tv.sha256)
+			assert.Equal(t, evalHash(xtfhe.BLAKE2sCircuit(len(tv.msg)), []byte(tv.msg)), tv.blake2s)
+		}
+	})
+
+	t.Run("SHA256", func(t *testing.T) {
+		for _, msgLen := range []int{1, 55, 56, 64, 100} {
+			msg := make([]byte, msgLen)
+			rand.Read(msg)
+			digest := sha256.Sum256(msg)
+			assert.Equal(t, evalHash(xtfhe.SHA256Circuit(msgLen), msg), hex.EncodeToString(digest[:]))
+		}
+	})
+
+	t.Run("SHA256Compress", func(t *testing.T) {
+		// SHA-256 of "abc" is a single compression of the padded block.
+		block := make([]uint32, 16)
+		block[0] = 0x61626380
+		block[15] = 24
+		state := []uint32{0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19}
+
+		stateOut := circuit.Compile(xtfhe.SHA256CompressCircuit()).EvaluateBool([][]bool{wordsToBools(state), wordsToBools(block)})[0]
+		digest := make([]byte, 32)
+		for i, b := range boolsToBytes(stateOut) {
+			digest[4*(i/4)+3-i%4] = b
+		}
+		assert.Equal(t, hex.EncodeToString(digest), hashTestVectors[1].sha256)
+	})
+
+	t.Run("BLAKE2sCompress", func(t *testing.T) {
+		// BLAKE2s of "abc" is a single compression of the zero padded block.
+		block := make([]uint32, 16)
+		block[0] = 0x636261
+		state := []uint32{0x6a09e667 ^ 0x01010020, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19}
+
+		stateOut := circuit.Compile(xtfhe.BLAKE2sCompressCircuit(3, true)).EvaluateBool([][]bool{wordsToBools(state), wordsToBools(block)})[0]
+		assert.Equal(t, hex.EncodeToString(boolsToBytes(stateOut)), hashTestVectors[1].blake2s)
+	})
+
+	t.Run("Homomorphic", func(t *testing.T) {
+		msg := []byte("abc")
+		ctMsg := encryptBytes(binaryEnc, msg)
+
+		sha256Digest := sha256.Sum256(msg)
+		assert.Equal(t, decryptBytes(binaryEnc, hashEval.SHA256(ctMsg)), sha256Digest[:])
+
+		assert.Equal(t, hex.EncodeToString(decryptBytes(binaryEnc, hashEval.BLAKE2s(ctMsg))), hashTestVectors[1].blake2s)
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		assert.Panics(t, func() { hashEval.SHA256(encryptBytes(binaryEnc, []byte("abc"))[:20]) })
+		assert.Panics(t, func() { hashEval.BLAKE2s(encryptBytes(binaryEnc, []byte("abc"))[:20]) })
+	})
+}
+
+func BenchmarkHash(b *testing.B) {
+	enc, evk := benchmarkBinaryKeys()
+	eval := xtfhe.NewHashEvaluator(enc.Params, evk)
+
+	msg := []byte("abc")
+	ctMsg := encryptBytes(enc, msg)
+	ctOut := eval.SHA256(ctMsg)
+
+	b.Run("SHA256", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			eval.SHA256To(ctOut, ctMsg)
+		}
+		b.StopTimer()
+
+		if hex.EncodeToString(decryptBytes(enc, ctOut)) != hashTestVectors[1].sha256 {
+			b.Fatal("SHA-256 output mismatch")
+		}
+	})
+
+	b.Run("BLAKE2s", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			eval.BLAKE2sTo(ctOut, ctMsg)
+		}
+		b.StopTimer()
+
+		if hex.EncodeToString(decryptBytes(enc, ctOut)) != hashTestVectors[1].blake2s {
+			b.Fatal("BLAKE2s output mismatch")
+		}
+	})
+}
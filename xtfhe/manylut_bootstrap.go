@@ -1,6 +1,7 @@
 package xtfhe
 
 import (
+	"github.com/sp301415/tfhe-go/internal/parallel"
 	"github.com/sp301415/tfhe-go/math/num"
 	"github.com/sp301415/tfhe-go/math/vec"
 	"github.com/sp301415/tfhe-go/tfhe"
@@ -155,6 +156,94 @@ func (e *ManyLUTEvaluator[T]) BootstrapLUTTo(ctOut []tfhe.LWECiphertext[T], ct t
 	}
 }
 
+// BootstrapFuncBatch is a parallel version of [ManyLUTEvaluator.BootstrapFunc].
+// ctOut[i] holds LUTCount ciphertexts, one for each function in f[i].
+// If len(f) == 1, the same LUTCount functions are used for all ciphertexts.
+// Workers are safe copies of e sharing its evaluation key,
+// and the number of workers is set by [tfhe.Evaluator.SetBatchWorkerCount].
+//
+// Panics if len(f) != 1 and len(f) != len(ct), or len(f[i]) > LUTCount.
+func (e *ManyLUTEvaluator[T]) BootstrapFuncBatch(ct []tfhe.LWECiphertext[T], f [][]func(int) int) [][]tfhe.LWECiphertext[T] {
+	ctOut := e.newBatchOutputs(len(ct))
+	e.BootstrapFuncBatchTo(ctOut, ct, f)
+	return ctOut
+}
+
+// BootstrapFuncBatchTo is a parallel version of [ManyLUTEvaluator.BootstrapFuncTo].
+// ctOut[i] is written with LUTCount ciphertexts, one for each function in f[i].
+// If len(f) == 1, the same LUTCount functions are used for all ciphertexts.
+//
+// Panics if len(f) != 1 and len(f) != len(ct), len(f[i]) > LUTCount, or len(ctOut) != len(ct).
+func (e *ManyLUTEvaluator[T]) BootstrapFuncBatchTo(ctOut [][]tfhe.LWECiphertext[T], ct []tfhe.LWECiphertext[T], f [][]func(int) int) {
+	if len(f) == 1 {
+		e.GenLUTTo(e.buf.lut, f[0])
+		e.BootstrapLUTBatchTo(ctOut, ct, []tfhe.LookUpTable[T]{e.buf.lut})
+		return
+	}
+
+	if len(f) != len(ct) {
+		panic("Function count mismatch")
+	}
+	if len(ctOut) != len(ct) {
+		panic("Ciphertext count mismatch")
+	}
+
+	pool := parallel.Workers(&e.batchPool, e.BatchWorkerCount(), len(ct), e.SafeCopy)
+	parallel.Run(len(pool), len(ct), func(worker, i int) {
+		pool[worker].BootstrapFuncTo(ctOut[i], ct[i], f[i])
+	})
+}
+
+// BootstrapLUTBatch is a parallel version of [ManyLUTEvaluator.BootstrapLUT].
+// lut[i] should be generated by [ManyLUTEvaluator.GenLUT],
+// and ctOut[i] holds LUTCount ciphertexts.
+// If len(lut) == 1, the same LUT is used for all ciphertexts.
+// Workers are safe copies of e sharing its evaluation key,
+// and the number of workers is set by [tfhe.Evaluator.SetBatchWorkerCount].
+//
+// Panics if len(lut) != 1 and len(lut) != len(ct).
+func (e *ManyLUTEvaluator[T]) BootstrapLUTBatch(ct []tfhe.LWECiphertext[T], lut []tfhe.LookUpTable[T]) [][]tfhe.LWECiphertext[T] {
+	ctOut := e.newBatchOutputs(len(ct))
+	e.BootstrapLUTBatchTo(ctOut, ct, lut)
+	return ctOut
+}
+
+// BootstrapLUTBatchTo is a parallel version of [ManyLUTEvaluator.BootstrapLUTTo].
+// lut[i] should be generated by [ManyLUTEvaluator.GenLUT],
+// and ctOut[i] is written with LUTCount ciphertexts.
+// If len(lut) == 1, the same LUT is used for all ciphertexts.
+//
+// Panics if len(lut) != 1 and len(lut) != len(ct), or len(ctOut) != len(ct).
+func (e *ManyLUTEvaluator[T]) BootstrapLUTBatchTo(ctOut [][]tfhe.LWECiphertext[T], ct []tfhe.LWECiphertext[T], lut []tfhe.LookUpTable[T]) {
+	if len(lut) != 1 && len(lut) != len(ct) {
+		panic("LUT count mismatch")
+	}
+	if len(ctOut) != len(ct) {
+		panic("Ciphertext count mismatch")
+	}
+
+	pool := parallel.Workers(&e.batchPool, e.BatchWorkerCount(), len(ct), e.SafeCopy)
+	parallel.Run(len(pool), len(ct), func(worker, i int) {
+		if len(lut) == 1 {
+			pool[worker].BootstrapLUTTo(ctOut[i], ct[i], lut[0])
+		} else {
+			pool[worker].BootstrapLUTTo(ctOut[i], ct[i], lut[i])
+		}
+	})
+}
+
+// newBatchOutputs allocates the outputs of batch bootstrapping of count ciphertexts.
+func (e *ManyLUTEvaluator[T]) newBatchOutputs(count int) [][]tfhe.LWECiphertext[T] {
+	ctOut := make([][]tfhe.LWECiphertext[T], count)
+	for i := range ctOut {
+		ctOut[i] = make([]tfhe.LWECiphertext[T], e.Params.lutCount)
+		for j := range ctOut[i] {
+			ctOut[i][j] = tfhe.NewLWECiphertext(e.Params.baseParams)
+		}
+	}
+	return ctOut
+}
+
 // ModSwitch switches the modulus of x from Q to 2 * LUTSize.
 func (e *ManyLUTEvaluator[T]) ModSwitch(x T) int {
 	return int(num.DivRoundBits(x, e.Params.baseParams.LogQ()-e.Params.baseParams.LogPolyRank()-1+e.Params.logLUTCount) << e.Params.logLUTCount)
@@ -17,6 +17,10 @@ type ManyLUTEvaluator[T tfhe.TorusInt] struct {
 	// Params is the parameter set for this ManyLUTEvaluator.
 	Params ManyLUTParameters[T]
 
+	// batchPool is a pool of ManyLUTEvaluators for batch bootstrapping.
+	// It is allocated on the first batch bootstrapping.
+	batchPool []*ManyLUTEvaluator[T]
+
 	buf manyLUTEvaluatorBuffer[T]
 }
 
@@ -26,6 +26,8 @@ func (p ManyLUTParametersLiteral[T]) Compile() ManyLUTParameters[T] {
 	switch {
 	case baseParams.PolyRank() != baseParams.LUTSize():
 		panic("PolyRank does not equal LUTSize")
+	case baseParams.GroupingFactor() != 1:
+		panic("GroupingFactor not 1")
 	case !num.IsPowerOfTwo(p.LUTCount):
 		panic("lutCount not power of two")
 	}
@@ -55,6 +57,11 @@ func (p ManyLUTParameters[T]) BaseParams() tfhe.Parameters[T] {
 	return p.baseParams
 }
 
+// LogQ returns log(Q), where Q is the modulus of the ciphertext.
+func (p ManyLUTParameters[T]) LogQ() int {
+	return p.baseParams.LogQ()
+}
+
 // LUTCount returns the number of LUTs that can be evaluated at once.
 func (p ManyLUTParameters[T]) LUTCount() int {
 	return p.lutCount
@@ -30,3 +30,43 @@ func TestManyLUT(t *testing.T) {
 		assert.Equal(t, manyLUTEnc.DecryptLWE(ctOut[i]), fs[i](m)%int(manyLUTParams.BaseParams().MessageModulus()))
 	}
 }
+
+func TestManyLUTBatch(t *testing.T) {
+	messageModulus := int(manyLUTParams.BaseParams().MessageModulus())
+	msgs := []int{0, 1, messageModulus - 1}
+	cts := make([]tfhe.LWECiphertext[uint64], len(msgs))
+	fs := make([][]func(int) int, len(msgs))
+	for i, msg := range msgs {
+		cts[i] = manyLUTEnc.EncryptLWE(msg)
+		fs[i] = make([]func(int) int, manyLUTParams.LUTCount())
+		for j := range fs[i] {
+			k := i + j
+			fs[i][j] = func(x int) int { return x + k }
+		}
+	}
+
+	t.Run("BootstrapFuncBatch", func(t *testing.T) {
+		ctOut := manyLUTEval.BootstrapFuncBatch(cts, fs[:1])
+		for i, msg := range msgs {
+			for j := 0; j < manyLUTParams.LUTCount(); j++ {
+				assert.Equal(t, manyLUTEnc.DecryptLWE(ctOut[i][j]), fs[0][j](msg)%messageModulus)
+			}
+		}
+	})
+
+	t.Run("BootstrapLUTBatch", func(t *testing.T) {
+		luts := make([]tfhe.LookUpTable[uint64], len(msgs))
+		for i := range msgs {
+			luts[i] = manyLUTEval.GenLUT(fs[i])
+		}
+
+		ctOut := manyLUTEval.BootstrapLUTBatch(cts, luts)
+		for i, msg := range msgs {
+			for j := 0; j < manyLUTParams.LUTCount(); j++ {
+				assert.Equal(t, manyLUTEnc.DecryptLWE(ctOut[i][j]), fs[i][j](msg)%messageModulus)
+			}
+		}
+
+		assert.Panics(t, func() { manyLUTEval.BootstrapLUTBatch(cts, luts[:2]) })
+	})
+}
@@ -0,0 +1,274 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// PIRClient wraps around [tfhe.Encryptor], and encrypts indices for [PIRServer].
+type PIRClient[T tfhe.TorusInt] struct {
+	// Encryptor is an embedded [tfhe.Encryptor] for this PIRClient.
+	*tfhe.Encryptor[T]
+
+	// Params is the parameter set for this PIRClient.
+	Params CircuitBootstrapParameters[T]
+}
+
+// NewPIRClient creates a new [PIRClient].
+func NewPIRClient[T tfhe.TorusInt](params CircuitBootstrapParameters[T]) *PIRClient[T] {
+	return &PIRClient[T]{
+		Encryptor: tfhe.NewEncryptor(params.BaseParams()),
+		Params:    params,
+	}
+}
+
+// NewPIRClientWithKey creates a new [PIRClient] with given parameters and secret key.
+func NewPIRClientWithKey[T tfhe.TorusInt](params CircuitBootstrapParameters[T], sk tfhe.SecretKey[T]) *PIRClient[T] {
+	return &PIRClient[T]{
+		Encryptor: tfhe.NewEncryptorWithKey(params.BaseParams(), sk),
+		Params:    params,
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (c *PIRClient[T]) SafeCopy() *PIRClient[T] {
+	return &PIRClient[T]{
+		Encryptor: c.Encryptor.SafeCopy(),
+		Params:    c.Params,
+	}
+}
+
+// EncryptIndex encrypts index bitwise to bitCount LWE ciphertexts, from the least significant bit.
+func (c *PIRClient[T]) EncryptIndex(index, bitCount int) []tfhe.LWECiphertext[T] {
+	ctOut := make([]tfhe.LWECiphertext[T], bitCount)
+	for i := 0; i < bitCount; i++ {
+		ctOut[i] = tfhe.NewLWECiphertext(c.Params.BaseParams())
+	}
+	c.EncryptIndexTo(ctOut, index)
+	return ctOut
+}
+
+// EncryptIndexTo encrypts index bitwise and writes it to ctOut, from the least significant bit.
+// The number of bits is given by len(ctOut).
+func (c *PIRClient[T]) EncryptIndexTo(ctOut []tfhe.LWECiphertext[T], index int) {
+	for i := range ctOut {
+		c.EncryptLWETo(ctOut[i], (index>>i)&1)
+	}
+}
+
+// PIRServer wraps around [CircuitBootstrapper], and implements encrypted array indexing,
+// which can be used for private information retrieval.
+//
+// A database is an array of records, where each record is a GLWE plaintext or ciphertext.
+// The index is encrypted bitwise, usually by [PIRClient],
+// and expanded to GGSW ciphertexts using circuit bootstrapping.
+// Then, the records are selected obliviously using a CMux tree,
+// so the server does not learn which record is read or written.
+//
+// PIRServer is not safe for concurrent use.
+// Use [PIRServer.SafeCopy] to get a safe copy.
+type PIRServer[T tfhe.TorusInt] struct {
+	// CircuitBootstrapper is an embedded CircuitBootstrapper for this PIRServer.
+	*CircuitBootstrapper[T]
+
+	buf pirServerBuffer[T]
+}
+
+// pirServerBuffer is a buffer for PIRServer.
+type pirServerBuffer[T tfhe.TorusInt] struct {
+	// ctTree holds the intermediate results of the CMux tree.
+	// It is allocated on the first read, and grows as needed.
+	ctTree []tfhe.GLWECiphertext[T]
+	// ctDiff is the difference of two plaintext records.
+	ctDiff tfhe.GLWECiphertext[T]
+
+	// ctDemux holds the intermediate results of the demultiplexer tree.
+	// It is allocated on the first write, and grows as needed.
+	ctDemux []tfhe.GLWECiphertext[T]
+}
+
+// NewPIRServer creates a new [PIRServer].
+func NewPIRServer[T tfhe.TorusInt](params CircuitBootstrapParameters[T], evk tfhe.EvaluationKey[T], cbk CircuitBootstrapKey[T]) *PIRServer[T] {
+	return &PIRServer[T]{
+		CircuitBootstrapper: NewCircuitBootstrapper(params, evk, cbk),
+
+		buf: newPIRServerBuffer(params),
+	}
+}
+
+// newPIRServerBuffer creates a new [pirServerBuffer].
+func newPIRServerBuffer[T tfhe.TorusInt](params CircuitBootstrapParameters[T]) pirServerBuffer[T] {
+	return pirServerBuffer[T]{
+		ctDiff: tfhe.NewGLWECiphertext(params.BaseParams()),
+	}
+}
+
+// SafeCopy creates a shallow copy of this PIRServer.
+// Returned PIRServer is safe for concurrent use.
+func (e *PIRServer[T]) SafeCopy() *PIRServer[T] {
+	return &PIRServer[T]{
+		CircuitBootstrapper: e.CircuitBootstrapper.SafeCopy(),
+
+		buf: newPIRServerBuffer(e.Params),
+	}
+}
+
+// ExpandIndex circuit bootstraps the bitwise encrypted index to GGSW ciphertexts.
+func (e *PIRServer[T]) ExpandIndex(ctIndex []tfhe.LWECiphertext[T]) []tfhe.FFTGGSWCiphertext[T] {
+	ctOut := make([]tfhe.FFTGGSWCiphertext[T], len(ctIndex))
+	for i := range ctOut {
+		ctOut[i] = tfhe.NewFFTGGSWCiphertext(e.Params.BaseParams(), e.Params.outputParameters)
+	}
+	e.ExpandIndexTo(ctOut, ctIndex)
+	return ctOut
+}
+
+// ExpandIndexTo circuit bootstraps the bitwise encrypted index to GGSW ciphertexts and writes them to ctOut.
+//
+// Panics if len(ctOut) != len(ctIndex).
+func (e *PIRServer[T]) ExpandIndexTo(ctOut []tfhe.FFTGGSWCiphertext[T], ctIndex []tfhe.LWECiphertext[T]) {
+	if len(ctOut) != len(ctIndex) {
+		panic("Index length mismatch")
+	}
+
+	for i := range ctIndex {
+		e.CircuitBootstrapTo(ctOut[i], ctIndex[i])
+	}
+}
+
+// ReadPlain returns the record of db at the encrypted index.
+// If the index is out of range, the result is undefined.
+//
+// Panics if len(db) == 0 or len(db) > 2^len(ctIndex).
+func (e *PIRServer[T]) ReadPlain(ctIndex []tfhe.FFTGGSWCiphertext[T], db []tfhe.GLWEPlaintext[T]) tfhe.GLWECiphertext[T] {
+	ctOut := tfhe.NewGLWECiphertext(e.Params.BaseParams())
+	e.ReadPlainTo(ctOut, ctIndex, db)
+	return ctOut
+}
+
+// ReadPlainTo computes the record of db at the encrypted index and writes it to ctOut.
+// If the index is out of range, the result is undefined.
+//
+// Panics if len(db) == 0 or len(db) > 2^len(ctIndex).
+func (e *PIRServer[T]) ReadPlainTo(ctOut tfhe.GLWECiphertext[T], ctIndex []tfhe.FFTGGSWCiphertext[T], db []tfhe.GLWEPlaintext[T]) {
+	e.checkDatabaseSize(ctIndex, len(db))
+
+	if len(ctIndex) == 0 {
+		ctOut.Clear()
+		ctOut.Value[0].CopyFrom(db[0].Value)
+		return
+	}
+
+	// The first level of the CMux tree is computed directly from the plaintexts,
+	// which saves decomposition of zero masks.
+	count := (len(db) + 1) / 2
+	e.growTree(count)
+	e.buf.ctDiff.Clear()
+	for i := 0; i < count; i++ {
+		e.buf.ctTree[i].Clear()
+		e.buf.ctTree[i].Value[0].CopyFrom(db[2*i].Value)
+		if 2*i+1 < len(db) {
+			e.PolyEvaluator.SubPolyTo(e.buf.ctDiff.Value[0], db[2*i+1].Value, db[2*i].Value)
+			e.ExternalProdAddGLWETo(e.buf.ctTree[i], ctIndex[0], e.buf.ctDiff)
+		}
+	}
+	e.readTreeTo(ctOut, ctIndex, count)
+}
+
+// Read returns the record of db at the encrypted index.
+// If the index is out of range, the result is undefined.
+//
+// Panics if len(db) == 0 or len(db) > 2^len(ctIndex).
+func (e *PIRServer[T]) Read(ctIndex []tfhe.FFTGGSWCiphertext[T], db []tfhe.GLWECiphertext[T]) tfhe.GLWECiphertext[T] {
+	ctOut := tfhe.NewGLWECiphertext(e.Params.BaseParams())
+	e.ReadTo(ctOut, ctIndex, db)
+	return ctOut
+}
+
+// ReadTo computes the record of db at the encrypted index and writes it to ctOut.
+// If the index is out of range, the result is undefined.
+//
+// Panics if len(db) == 0 or len(db) > 2^len(ctIndex).
+func (e *PIRServer[T]) ReadTo(ctOut tfhe.GLWECiphertext[T], ctIndex []tfhe.FFTGGSWCiphertext[T], db []tfhe.GLWECiphertext[T]) {
+	e.checkDatabaseSize(ctIndex, len(db))
+
+	if len(ctIndex) == 0 {
+		ctOut.CopyFrom(db[0])
+		return
+	}
+
+	count := (len(db) + 1) / 2
+	e.growTree(count)
+	for i := 0; i < count; i++ {
+		if 2*i+1 < len(db) {
+			e.CMuxTo(e.buf.ctTree[i], ctIndex[0], db[2*i], db[2*i+1])
+		} else {
+			e.buf.ctTree[i].CopyFrom(db[2*i])
+		}
+	}
+	e.readTreeTo(ctOut, ctIndex, count)
+}
+
+// Write obliviously replaces the record of db at the encrypted index with ct.
+// All records of db are updated in place, so that the server does not learn which record is written.
+// If the index is out of range, the result is undefined.
+//
+// Panics if len(db) == 0 or len(db) > 2^len(ctIndex).
+func (e *PIRServer[T]) Write(ctIndex []tfhe.FFTGGSWCiphertext[T], db []tfhe.GLWECiphertext[T], ct tfhe.GLWECiphertext[T]) {
+	e.checkDatabaseSize(ctIndex, len(db))
+
+	for len(e.buf.ctDemux) < 1<<len(ctIndex) {
+		e.buf.ctDemux = append(e.buf.ctDemux, tfhe.NewGLWECiphertext(e.Params.BaseParams()))
+	}
+
+	// We demultiplex ct - db[index] to ctDemux, so that
+	// ctDemux[i] = ct - db[index] if i = index, and 0 otherwise.
+	e.ReadTo(e.buf.ctDemux[0], ctIndex, db)
+	e.SubGLWETo(e.buf.ctDemux[0], ct, e.buf.ctDemux[0])
+
+	for i := len(ctIndex) - 1; i >= 0; i-- {
+		// Each node at this level covers 2^(i+1) records.
+		// Nodes are processed in reverse order, so that children do not overwrite unprocessed nodes.
+		for j := (len(db) - 1) >> (i + 1); j >= 0; j-- {
+			e.ExternalProdGLWETo(e.buf.ctDemux[2*j+1], ctIndex[i], e.buf.ctDemux[j])
+			e.SubGLWETo(e.buf.ctDemux[2*j], e.buf.ctDemux[j], e.buf.ctDemux[2*j+1])
+		}
+	}
+
+	for i := range db {
+		e.AddGLWETo(db[i], db[i], e.buf.ctDemux[i])
+	}
+}
+
+// checkDatabaseSize panics if the database of size dbSize cannot be indexed by ctIndex.
+func (e *PIRServer[T]) checkDatabaseSize(ctIndex []tfhe.FFTGGSWCiphertext[T], dbSize int) {
+	switch {
+	case dbSize == 0:
+		panic("Empty database")
+	case dbSize > 1<<len(ctIndex):
+		panic("Database size larger than 2^len(ctIndex)")
+	}
+}
+
+// growTree grows ctTree to have at least count GLWE ciphertexts.
+func (e *PIRServer[T]) growTree(count int) {
+	for len(e.buf.ctTree) < count {
+		e.buf.ctTree = append(e.buf.ctTree, tfhe.NewGLWECiphertext(e.Params.BaseParams()))
+	}
+}
+
+// readTreeTo computes the rest of the CMux tree, where the first level of count nodes is in ctTree,
+// and writes the root to ctOut.
+func (e *PIRServer[T]) readTreeTo(ctOut tfhe.GLWECiphertext[T], ctIndex []tfhe.FFTGGSWCiphertext[T], count int) {
+	for i := 1; i < len(ctIndex); i++ {
+		countNext := (count + 1) / 2
+		for j := 0; j < countNext; j++ {
+			if 2*j+1 < count {
+				e.CMuxTo(e.buf.ctTree[j], ctIndex[i], e.buf.ctTree[2*j], e.buf.ctTree[2*j+1])
+			} else {
+				e.buf.ctTree[j].CopyFrom(e.buf.ctTree[2*j])
+			}
+		}
+		count = countNext
+	}
+	ctOut.CopyFrom(e.buf.ctTree[0])
+}
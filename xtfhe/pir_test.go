@@ -0,0 +1,89 @@
+package xtfhe_test
+
+import (
+	"testing"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+	"github.com/sp301415/tfhe-go/xtfhe"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pirClient = xtfhe.NewPIRClientWithKey(cbParams, cbEnc.SecretKey)
+	pirServer = xtfhe.NewPIRServer(cbParams, cbEval.ManyLUTEvaluator.EvalKey, cbEval.EvalKey)
+)
+
+func TestPIR(t *testing.T) {
+	indexBits := 3
+	msgs := make([][]int, 6)
+	for i := range msgs {
+		msgs[i] = []int{i & 1, (i >> 1) & 1, (i >> 2) & 1, 1}
+	}
+
+	dbPlain := make([]tfhe.GLWEPlaintext[uint64], len(msgs))
+	for i := range dbPlain {
+		dbPlain[i] = pirClient.EncodeGLWE(msgs[i])
+	}
+
+	t.Run("ReadPlain", func(t *testing.T) {
+		for i := range msgs {
+			ctIndex := pirServer.ExpandIndex(pirClient.EncryptIndex(i, indexBits))
+			ctOut := pirServer.ReadPlain(ctIndex, dbPlain)
+			assert.Equal(t, pirClient.DecryptGLWE(ctOut)[:len(msgs[i])], msgs[i])
+		}
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		db := make([]tfhe.GLWECiphertext[uint64], len(msgs))
+		for i := range db {
+			db[i] = pirClient.EncryptGLWE(msgs[i])
+		}
+
+		for i := range msgs {
+			ctIndex := pirServer.ExpandIndex(pirClient.EncryptIndex(i, indexBits))
+			ctOut := pirServer.Read(ctIndex, db)
+			assert.Equal(t, pirClient.DecryptGLWE(ctOut)[:len(msgs[i])], msgs[i])
+		}
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		db := make([]tfhe.GLWECiphertext[uint64], len(msgs))
+		for i := range db {
+			db[i] = pirClient.EncryptGLWE(msgs[i])
+		}
+
+		index := 4
+		msgWrite := []int{0, 1, 1, 0}
+		ctIndex := pirServer.ExpandIndex(pirClient.EncryptIndex(index, indexBits))
+		pirServer.Write(ctIndex, db, pirClient.EncryptGLWE(msgWrite))
+
+		for i := range db {
+			if i == index {
+				assert.Equal(t, pirClient.DecryptGLWE(db[i])[:len(msgWrite)], msgWrite)
+			} else {
+				assert.Equal(t, pirClient.DecryptGLWE(db[i])[:len(msgs[i])], msgs[i])
+			}
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		ctIndex := pirServer.ExpandIndex(pirClient.EncryptIndex(0, 2))
+		assert.Panics(t, func() { pirServer.ReadPlain(ctIndex, dbPlain) })
+		assert.Panics(t, func() { pirServer.ReadPlain(ctIndex, nil) })
+	})
+}
+
+func BenchmarkPIRRead(b *testing.B) {
+	indexBits := 4
+	db := make([]tfhe.GLWECiphertext[uint64], 1<<indexBits)
+	for i := range db {
+		db[i] = pirClient.EncryptGLWE([]int{i & 1})
+	}
+	ctIndex := pirServer.ExpandIndex(pirClient.EncryptIndex(3, indexBits))
+	ctOut := pirClient.EncryptGLWE([]int{0})
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pirServer.ReadTo(ctOut, ctIndex, db)
+	}
+}
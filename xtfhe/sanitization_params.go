@@ -91,6 +91,11 @@ func (p SanitizationParameters[T]) BaseParams() tfhe.Parameters[T] {
 	return p.baseParams
 }
 
+// LogQ returns log(Q), where Q is the modulus of the ciphertext.
+func (p SanitizationParameters[T]) LogQ() int {
+	return p.baseParams.LogQ()
+}
+
 // RandSigma returns the standard deviation used for
 // Discrete Gaussian sampling in Rand operation.
 //
@@ -0,0 +1,224 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// TranscipherEvaluator converts data encrypted with a stream cipher to TFHE ciphertexts,
+// using [tfhe.BinaryEvaluator].
+// This is meant to be public, usually for servers.
+//
+// In transciphering, the client encrypts data with a stream cipher from [StreamCipher.NewStream],
+// and sends the key encrypted bitwise with [tfhe.BinaryEncryptor] or [tfhe.BinaryPublicEncryptor] only once.
+// Bit i of the key is the (i%8)-th least significant bit of the (i/8)-th byte,
+// so that each byte can be encrypted using EncryptLWEBits.
+// Then, the server regenerates the keystream homomorphically and XORs it away,
+// which reduces the upload bandwidth from one LWE ciphertext per bit to one bit per bit.
+//
+// The keystream circuits depend on the IV, which is public.
+// They are built from [TranscipherCircuit], and compiled using [circuit.Compile],
+// so that the parts of the initialization depending only on the IV are evaluated in plaintext.
+// Gates of each level are evaluated in parallel.
+//
+// TranscipherEvaluator is not safe for concurrent use.
+// Use [TranscipherEvaluator.SafeCopy] to get a safe copy.
+type TranscipherEvaluator[T tfhe.TorusInt] struct {
+	// Evaluator is a circuit Evaluator for this TranscipherEvaluator.
+	Evaluator *circuit.Evaluator[T]
+	// Params is the parameter set for this TranscipherEvaluator.
+	Params tfhe.Parameters[T]
+	// Cipher is the stream cipher for this TranscipherEvaluator.
+	Cipher StreamCipher
+}
+
+// NewTranscipherEvaluator creates a new [TranscipherEvaluator].
+// This does not copy evaluation keys, since they are large.
+func NewTranscipherEvaluator[T tfhe.TorusInt](params tfhe.Parameters[T], evk tfhe.EvaluationKey[T], c StreamCipher) *TranscipherEvaluator[T] {
+	return &TranscipherEvaluator[T]{
+		Evaluator: circuit.NewEvaluator(params, evk),
+		Params:    params,
+		Cipher:    c,
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *TranscipherEvaluator[T]) SafeCopy() *TranscipherEvaluator[T] {
+	return &TranscipherEvaluator[T]{
+		Evaluator: e.Evaluator.SafeCopy(),
+		Params:    e.Params,
+		Cipher:    e.Cipher,
+	}
+}
+
+// KeyStream returns the first bits of the keystream for the encrypted key and iv.
+//
+// Panics if len(ctKey) != 8*KeySize or len(iv) != IVSize.
+func (e *TranscipherEvaluator[T]) KeyStream(ctKey []tfhe.LWECiphertext[T], iv []byte, bits int) []tfhe.LWECiphertext[T] {
+	ctOut := make([]tfhe.LWECiphertext[T], bits)
+	for i := range ctOut {
+		ctOut[i] = tfhe.NewLWECiphertext(e.Params)
+	}
+	e.KeyStreamTo(ctOut, ctKey, iv)
+	return ctOut
+}
+
+// KeyStreamTo computes the first len(ctOut) bits of the keystream for the encrypted key and iv,
+// and writes them to ctOut.
+//
+// Panics if len(ctKey) != 8*KeySize or len(iv) != IVSize.
+func (e *TranscipherEvaluator[T]) KeyStreamTo(ctOut, ctKey []tfhe.LWECiphertext[T], iv []byte) {
+	e.evaluateTo(ctOut, ctKey, transcipherCircuit(e.Cipher, iv, make([]bool, len(ctOut))))
+}
+
+// Transcipher decrypts data encrypted with the stream cipher homomorphically,
+// and returns the encrypted bits of the plaintext.
+// Bit i of the output is the (i%8)-th least significant bit of the (i/8)-th byte,
+// so that each byte can be decrypted using DecryptLWEBits.
+//
+// Panics if len(ctKey) != 8*KeySize or len(iv) != IVSize.
+func (e *TranscipherEvaluator[T]) Transcipher(ctKey []tfhe.LWECiphertext[T], iv, data []byte) []tfhe.LWECiphertext[T] {
+	ctOut := make([]tfhe.LWECiphertext[T], 8*len(data))
+	for i := range ctOut {
+		ctOut[i] = tfhe.NewLWECiphertext(e.Params)
+	}
+	e.TranscipherTo(ctOut, ctKey, iv, data)
+	return ctOut
+}
+
+// TranscipherTo decrypts data encrypted with the stream cipher homomorphically,
+// and writes the encrypted bits of the plaintext to ctOut.
+// See [TranscipherEvaluator.Transcipher] for details.
+//
+// Panics if len(ctKey) != 8*KeySize, len(iv) != IVSize, or len(ctOut) != 8*len(data).
+func (e *TranscipherEvaluator[T]) TranscipherTo(ctOut, ctKey []tfhe.LWECiphertext[T], iv, data []byte) {
+	e.evaluateTo(ctOut, ctKey, TranscipherCircuit(e.Cipher, iv, data))
+}
+
+// evaluateTo compiles and evaluates c on ctKey, and writes the output to ctOut.
+func (e *TranscipherEvaluator[T]) evaluateTo(ctOut, ctKey []tfhe.LWECiphertext[T], c circuit.Circuit) {
+	e.Evaluator.EvaluateProgramParallelTo([][]tfhe.LWECiphertext[T]{ctOut}, circuit.Compile(c), [][]tfhe.LWECiphertext[T]{ctKey})
+}
+
+// TranscipherCircuit returns a boolean circuit that decrypts data encrypted with the stream cipher c
+// under the IV iv.
+// It has one input "key" of 8*KeySize bits, and one output "message" of 8*len(data) bits,
+// both from the least significant bit of the first byte.
+//
+// Panics if len(iv) != IVSize.
+func TranscipherCircuit(c StreamCipher, iv, data []byte) circuit.Circuit {
+	dataBits := make([]bool, 8*len(data))
+	for i := range dataBits {
+		dataBits[i] = (data[i/8]>>(i%8))&1 == 1
+	}
+	return transcipherCircuit(c, iv, dataBits)
+}
+
+// transcipherCircuit returns a boolean circuit that XORs the keystream of c to dataBits.
+func transcipherCircuit(c StreamCipher, iv []byte, dataBits []bool) circuit.Circuit {
+	if len(iv) != c.IVSize() {
+		panic("IV size mismatch")
+	}
+
+	n := 8 * c.KeySize()
+	b := newCircuitBuilder(n)
+	s := &streamCircuitState{circuitBuilder: b, cipher: c}
+	for i := 0; i < n; i++ {
+		s.keyReg[i] = i
+		s.ivReg[i] = b.constant((iv[i/8]>>(i%8))&1 == 1)
+	}
+	for i := 1; i <= streamStateBits; i++ {
+		s.set(i, b.constant(false))
+	}
+
+	switch c {
+	case StreamCipherTrivium:
+		for i := 1; i <= n; i++ {
+			s.set(i, s.keyReg[n-i])
+			s.set(93+i, s.ivReg[n-i])
+		}
+		s.set(286, b.constant(true))
+		s.set(287, b.constant(true))
+		s.set(288, b.constant(true))
+	case StreamCipherKreyvium:
+		for i := 1; i <= 93; i++ {
+			s.set(i, s.keyReg[n-i])
+		}
+		for i := 1; i <= n; i++ {
+			s.set(93+i, s.ivReg[n-i])
+		}
+		for i := 94 + n; i < streamStateBits; i++ {
+			s.set(i, b.constant(true))
+		}
+	}
+
+	for i := 0; i < streamWarmUpRounds; i++ {
+		s.step(false)
+	}
+
+	message := make([]int, len(dataBits))
+	for i := range message {
+		message[i] = s.step(true)
+		if dataBits[i] {
+			message[i] = b.not(message[i])
+		}
+	}
+
+	return b.build([]string{"key"}, []string{"message"}, message)
+}
+
+// streamCircuitState is the state of Trivium or Kreyvium as wires.
+// See [streamCipherState] for the layout.
+type streamCircuitState struct {
+	*circuitBuilder
+
+	cipher StreamCipher
+
+	state [streamStateBits]int
+	head  int
+
+	keyReg  [128]int
+	ivReg   [128]int
+	regHead int
+}
+
+// get returns the i-th bit of the state, starting from 1.
+func (s *streamCircuitState) get(i int) int {
+	return s.state[(s.head+i-1)%streamStateBits]
+}
+
+// set sets the i-th bit of the state, starting from 1.
+func (s *streamCircuitState) set(i int, w int) {
+	s.state[(s.head+i-1)%streamStateBits] = w
+}
+
+// step updates the state, and returns the keystream bit if output is true.
+// Otherwise, it returns -1.
+func (s *streamCircuitState) step(output bool) int {
+	t1 := s.xor(s.get(66), s.get(93))
+	t2 := s.xor(s.get(162), s.get(177))
+	t3 := s.xor(s.get(243), s.get(288))
+	if s.cipher == StreamCipherKreyvium {
+		t3 = s.xor(t3, s.keyReg[s.regHead])
+	}
+
+	z := -1
+	if output {
+		z = s.xor(s.xor(t1, t2), t3)
+	}
+
+	t1 = s.xor(s.xor(t1, s.and(s.get(91), s.get(92))), s.get(171))
+	t2 = s.xor(s.xor(t2, s.and(s.get(175), s.get(176))), s.get(264))
+	t3 = s.xor(s.xor(t3, s.and(s.get(286), s.get(287))), s.get(69))
+	if s.cipher == StreamCipherKreyvium {
+		t1 = s.xor(t1, s.ivReg[s.regHead])
+		s.regHead = (s.regHead + 1) % len(s.keyReg)
+	}
+
+	s.head = (s.head + streamStateBits - 1) % streamStateBits
+	s.set(1, t3)
+	s.set(94, t1)
+	s.set(178, t2)
+
+	return z
+}
@@ -0,0 +1,173 @@
+package xtfhe
+
+import (
+	"crypto/cipher"
+)
+
+// StreamCipher is an enum type for FHE-friendly stream ciphers used in transciphering.
+type StreamCipher int
+
+const (
+	// StreamCipherTrivium is the Trivium stream cipher,
+	// with 80-bit keys and IVs.
+	StreamCipherTrivium StreamCipher = iota
+	// StreamCipherKreyvium is the Kreyvium stream cipher,
+	// with 128-bit keys and IVs.
+	StreamCipherKreyvium
+)
+
+const (
+	// streamStateBits is the number of bits in the state of Trivium and Kreyvium.
+	streamStateBits = 288
+	// streamWarmUpRounds is the number of rounds of the initialization of Trivium and Kreyvium.
+	streamWarmUpRounds = 4 * streamStateBits
+)
+
+// String implements the [fmt.Stringer] interface.
+func (c StreamCipher) String() string {
+	switch c {
+	case StreamCipherTrivium:
+		return "Trivium"
+	case StreamCipherKreyvium:
+		return "Kreyvium"
+	}
+	return "UNKNOWN"
+}
+
+// KeySize returns the key size of this stream cipher in bytes.
+func (c StreamCipher) KeySize() int {
+	switch c {
+	case StreamCipherTrivium:
+		return 10
+	case StreamCipherKreyvium:
+		return 16
+	}
+	panic("invalid stream cipher")
+}
+
+// IVSize returns the IV size of this stream cipher in bytes.
+func (c StreamCipher) IVSize() int {
+	return c.KeySize()
+}
+
+// NewStream returns a [cipher.Stream] of this stream cipher,
+// which is used by clients to encrypt data for transciphering.
+//
+// The bits of key and iv are numbered from the least significant bit of the first byte,
+// and the bit i is the (n-i)-th bit in the specification, where n is the number of bits.
+// The keystream is output from the least significant bit of each byte.
+// This is compatible with the eSTREAM reference implementation.
+//
+// Panics if len(key) != KeySize or len(iv) != IVSize.
+func (c StreamCipher) NewStream(key, iv []byte) cipher.Stream {
+	if len(key) != c.KeySize() {
+		panic("Key size mismatch")
+	}
+	if len(iv) != c.IVSize() {
+		panic("IV size mismatch")
+	}
+
+	s := &streamCipherState{cipher: c}
+	for i := 0; i < 8*len(key); i++ {
+		s.keyReg[i] = (key[i/8] >> (i % 8)) & 1
+		s.ivReg[i] = (iv[i/8] >> (i % 8)) & 1
+	}
+
+	n := 8 * len(key)
+	switch c {
+	case StreamCipherTrivium:
+		for i := 1; i <= n; i++ {
+			s.set(i, s.keyReg[n-i])
+			s.set(93+i, s.ivReg[n-i])
+		}
+		s.set(286, 1)
+		s.set(287, 1)
+		s.set(288, 1)
+	case StreamCipherKreyvium:
+		for i := 1; i <= 93; i++ {
+			s.set(i, s.keyReg[n-i])
+		}
+		for i := 1; i <= n; i++ {
+			s.set(93+i, s.ivReg[n-i])
+		}
+		for i := 94 + n; i < 288; i++ {
+			s.set(i, 1)
+		}
+	}
+
+	for i := 0; i < streamWarmUpRounds; i++ {
+		s.step()
+	}
+	return s
+}
+
+// streamCipherState is the state of Trivium or Kreyvium.
+// It implements [cipher.Stream].
+type streamCipherState struct {
+	// cipher is the type of the stream cipher.
+	cipher StreamCipher
+
+	// state is the 288-bit state, stored as a ring buffer.
+	// The i-th bit of the state is stored at state[(head+i-1)%288].
+	state [streamStateBits]byte
+	head  int
+
+	// keyReg and ivReg are the key and IV registers of Kreyvium, stored as ring buffers.
+	// The next bit to use is stored at index regHead.
+	keyReg  [128]byte
+	ivReg   [128]byte
+	regHead int
+}
+
+// get returns the i-th bit of the state, starting from 1.
+func (s *streamCipherState) get(i int) byte {
+	return s.state[(s.head+i-1)%streamStateBits]
+}
+
+// set sets the i-th bit of the state, starting from 1.
+func (s *streamCipherState) set(i int, b byte) {
+	s.state[(s.head+i-1)%streamStateBits] = b
+}
+
+// step updates the state and returns the keystream bit.
+func (s *streamCipherState) step() byte {
+	t1 := s.get(66) ^ s.get(93)
+	t2 := s.get(162) ^ s.get(177)
+	t3 := s.get(243) ^ s.get(288)
+	if s.cipher == StreamCipherKreyvium {
+		t3 ^= s.keyReg[s.regHead]
+	}
+	z := t1 ^ t2 ^ t3
+
+	t1 ^= s.get(91)&s.get(92) ^ s.get(171)
+	t2 ^= s.get(175)&s.get(176) ^ s.get(264)
+	t3 ^= s.get(286)&s.get(287) ^ s.get(69)
+	if s.cipher == StreamCipherKreyvium {
+		t1 ^= s.ivReg[s.regHead]
+		s.regHead = (s.regHead + 1) % len(s.keyReg)
+	}
+
+	// Shifting the whole state by one moves the last bits of each register
+	// to the first bits of the next register, which are overwritten.
+	s.head = (s.head + streamStateBits - 1) % streamStateBits
+	s.set(1, t3)
+	s.set(94, t1)
+	s.set(178, t2)
+
+	return z
+}
+
+// XORKeyStream implements the [cipher.Stream] interface.
+func (s *streamCipherState) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("Output smaller than input")
+	}
+
+	for i := range src {
+		var k byte
+		for j := 0; j < 8; j++ {
+			k |= s.step() << j
+		}
+		dst[i] = src[i] ^ k
+	}
+}
@@ -0,0 +1,156 @@
+package xtfhe_test
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/tfhe"
+	"github.com/sp301415/tfhe-go/xtfhe"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	transcipherEval = xtfhe.NewTranscipherEvaluator(binaryParams, binaryEvk, xtfhe.StreamCipherTrivium)
+)
+
+// streamCipherTestVectors are the first 64 bytes of the keystream.
+// Trivium test vectors are from eSTREAM.
+var streamCipherTestVectors = []struct {
+	cipher    xtfhe.StreamCipher
+	key       string
+	iv        string
+	keyStream string
+}{
+	{
+		cipher:    xtfhe.StreamCipherTrivium,
+		key:       "80000000000000000000",
+		iv:        "00000000000000000000",
+		keyStream: "38eb86ff730d7a9caf8df13a4420540dbb7b651464c87501552041c249f29a64d2fbf515610921ebe06c8f92cecf7f8098ff20cccc6a62b97be8ef7454fc80f9",
+	},
+	{
+		cipher:    xtfhe.StreamCipherTrivium,
+		key:       "0053a6f94c9ff24598eb",
+		iv:        "0d74db42a91077de45ac",
+		keyStream: "f4cd954a717f26a7d6930830c4e7cf0819f80e03f25f342c64adc66aba7f8a8e6eaa49f23632ae3cd41a7bd290a0132f81c6d4043b6e397d7388f3a03b5fe358",
+	},
+	{
+		cipher:    xtfhe.StreamCipherKreyvium,
+		key:       "00000000000000000000000000000000",
+		iv:        "00000000000000000000000000000000",
+		keyStream: "26dcf1f4bc0f1922f8b5532fe584ce98e32617ce4c2a9c6101613b794a3b0e26532f7a638f84fb7b16bfe472f5edee68e02233ddfd4472a756709da253de6979",
+	},
+}
+
+func TestStreamCipher(t *testing.T) {
+	t.Run("TestVector", func(t *testing.T) {
+		for _, tv := range streamCipherTestVectors {
+			key, _ := hex.DecodeString(tv.key)
+			iv, _ := hex.DecodeString(tv.iv)
+
+			keyStream := make([]byte, 64)
+			tv.cipher.NewStream(key, iv).XORKeyStream(keyStream, keyStream)
+			assert.Equal(t, hex.EncodeToString(keyStream), tv.keyStream)
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		for _, c := range []xtfhe.StreamCipher{xtfhe.StreamCipherTrivium, xtfhe.StreamCipherKreyvium} {
+			key := make([]byte, c.KeySize())
+			iv := make([]byte, c.IVSize())
+			rand.Read(key)
+			rand.Read(iv)
+
+			msg := make([]byte, 32)
+			rand.Read(msg)
+
+			ct := make([]byte, len(msg))
+			stream := c.NewStream(key, iv)
+			stream.XORKeyStream(ct[:5], msg[:5])
+			stream.XORKeyStream(ct[5:], msg[5:])
+
+			msgOut := make([]byte, len(msg))
+			c.NewStream(key, iv).XORKeyStream(msgOut, ct)
+			assert.Equal(t, msgOut, msg)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		assert.Panics(t, func() { xtfhe.StreamCipherTrivium.NewStream(make([]byte, 16), make([]byte, 10)) })
+		assert.Panics(t, func() { xtfhe.StreamCipherKreyvium.NewStream(make([]byte, 16), make([]byte, 10)) })
+	})
+}
+
+func TestTranscipher(t *testing.T) {
+	t.Run("Circuit", func(t *testing.T) {
+		for _, c := range []xtfhe.StreamCipher{xtfhe.StreamCipherTrivium, xtfhe.StreamCipherKreyvium} {
+			key := make([]byte, c.KeySize())
+			iv := make([]byte, c.IVSize())
+			rand.Read(key)
+			rand.Read(iv)
+
+			msg := make([]byte, 16)
+			rand.Read(msg)
+			ct := make([]byte, len(msg))
+			c.NewStream(key, iv).XORKeyStream(ct, msg)
+
+			p := circuit.Compile(xtfhe.TranscipherCircuit(c, iv, ct))
+			assert.Equal(t, evaluateBytes(p, key), msg)
+		}
+	})
+
+	t.Run("Homomorphic", func(t *testing.T) {
+		for _, c := range []xtfhe.StreamCipher{xtfhe.StreamCipherTrivium, xtfhe.StreamCipherKreyvium} {
+			eval := xtfhe.NewTranscipherEvaluator(binaryParams, binaryEvk, c)
+
+			key := make([]byte, c.KeySize())
+			iv := make([]byte, c.IVSize())
+			rand.Read(key)
+			rand.Read(iv)
+
+			msg := make([]byte, 2)
+			rand.Read(msg)
+			ct := make([]byte, len(msg))
+			c.NewStream(key, iv).XORKeyStream(ct, msg)
+
+			ctMsg := eval.Transcipher(encryptBytes(binaryEnc, key), iv, ct)
+			assert.Equal(t, decryptBytes(binaryEnc, ctMsg), msg)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		assert.Panics(t, func() { xtfhe.TranscipherCircuit(xtfhe.StreamCipherTrivium, make([]byte, 16), nil) })
+		assert.Panics(t, func() { transcipherEval.Transcipher(encryptBytes(binaryEnc, make([]byte, 16)), make([]byte, 10), nil) })
+	})
+}
+
+func BenchmarkTranscipher(b *testing.B) {
+	enc, evk := benchmarkBinaryKeys()
+	eval := xtfhe.NewTranscipherEvaluator(enc.Params, evk, xtfhe.StreamCipherTrivium)
+
+	key := make([]byte, xtfhe.StreamCipherTrivium.KeySize())
+	iv := make([]byte, xtfhe.StreamCipherTrivium.IVSize())
+	rand.Read(key)
+	rand.Read(iv)
+
+	msg := []byte("tfhe-go!")
+	ct := make([]byte, len(msg))
+	xtfhe.StreamCipherTrivium.NewStream(key, iv).XORKeyStream(ct, msg)
+
+	ctKey := encryptBytes(enc, key)
+	ctOut := make([]tfhe.LWECiphertext[uint32], 8*len(msg))
+	for i := range ctOut {
+		ctOut[i] = tfhe.NewLWECiphertext(enc.Params)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		eval.TranscipherTo(ctOut, ctKey, iv, ct)
+	}
+	b.StopTimer()
+
+	if string(decryptBytes(enc, ctOut)) != string(msg) {
+		b.Fatal("Transcipher output mismatch")
+	}
+}
@@ -0,0 +1,303 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/math/num"
+	"github.com/sp301415/tfhe-go/math/poly"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// WoPBootstrapper wraps around [CircuitBootstrapper], and implements Without-Padding Programmable Bootstrapping (WoP-PBS).
+// For more details, see https://eprint.iacr.org/2022/704.
+//
+// WoP-PBS evaluates lookup tables on messages of InputBits bits as follows:
+//
+//  1. Each bit of the message is extracted to a binary LWE ciphertext, using PBSManyLUT.
+//  2. Each bit is circuit bootstrapped to a GGSW ciphertext.
+//  3. The lookup table is evaluated by vertical packing:
+//     the upper bits select the packed polynomial using a CMux tree,
+//     and the lower bits blind rotate the selected polynomial.
+//
+// Input and output ciphertexts are LWE ciphertexts of GLWEDimension encrypted under LWELargeKey,
+// such as the ones from [WoPEncryptor].
+//
+// WoPBootstrapper is not safe for concurrent use.
+// Use [WoPBootstrapper.SafeCopy] to get a safe copy.
+type WoPBootstrapper[T tfhe.TorusInt] struct {
+	// CircuitBootstrapper is an embedded CircuitBootstrapper for this WoPBootstrapper.
+	*CircuitBootstrapper[T]
+
+	// Params is the parameter set for this WoPBootstrapper.
+	Params WoPBootstrapParameters[T]
+
+	buf wopBootstrapBuffer[T]
+}
+
+// wopBootstrapBuffer is a buffer for WoPBootstrapper.
+type wopBootstrapBuffer[T tfhe.TorusInt] struct {
+	// fs are the functions for the bit extraction LUT.
+	fs []func(x int) T
+	// lutExtract is a LUT for bit extraction.
+	lutExtract tfhe.LookUpTable[T]
+	// lut is an empty WoP LUT.
+	lut WoPLookUpTable[T]
+
+	// ctRemain is the input ciphertext with extracted bits subtracted.
+	ctRemain tfhe.LWECiphertext[T]
+	// ctShift is ctRemain with the current bit shifted to the most significant bit.
+	ctShift tfhe.LWECiphertext[T]
+	// ctKeySwitch is the LWEDimension-sized ciphertext from keyswitching for bit extraction.
+	ctKeySwitch tfhe.LWECiphertext[T]
+	// ctRotate is a blind rotated GLWE ciphertext for bit extraction.
+	ctRotate tfhe.GLWECiphertext[T]
+	// ctExtract is the extracted LWE ciphertext after Blind Rotation.
+	ctExtract tfhe.LWECiphertext[T]
+
+	// ctBits are the extracted bits.
+	ctBits []tfhe.LWECiphertext[T]
+	// ctFFTGGSWBits are the circuit bootstrapped bits.
+	ctFFTGGSWBits []tfhe.FFTGGSWCiphertext[T]
+
+	// ctAcc is the output GLWE ciphertext of vertical packing.
+	ctAcc tfhe.GLWECiphertext[T]
+}
+
+// NewWoPBootstrapper creates a new [WoPBootstrapper].
+// The circuit bootstrapping key can be generated by [CircuitBootstrapKeyGenerator]
+// using the base CircuitBootstrapParams.
+func NewWoPBootstrapper[T tfhe.TorusInt](params WoPBootstrapParameters[T], evk tfhe.EvaluationKey[T], cbk CircuitBootstrapKey[T]) *WoPBootstrapper[T] {
+	return &WoPBootstrapper[T]{
+		CircuitBootstrapper: NewCircuitBootstrapper(params.circuitBootstrapParameters, evk, cbk),
+
+		Params: params,
+
+		buf: newWoPBootstrapBuffer(params),
+	}
+}
+
+// newWoPBootstrapBuffer creates a new [wopBootstrapBuffer].
+func newWoPBootstrapBuffer[T tfhe.TorusInt](params WoPBootstrapParameters[T]) wopBootstrapBuffer[T] {
+	ctBits := make([]tfhe.LWECiphertext[T], params.inputBits)
+	ctFFTGGSWBits := make([]tfhe.FFTGGSWCiphertext[T], params.inputBits)
+	for i := 0; i < params.inputBits; i++ {
+		ctBits[i] = newWoPBitCiphertext(params)
+		ctFFTGGSWBits[i] = tfhe.NewFFTGGSWCiphertext(params.BaseParams(), params.circuitBootstrapParameters.outputParameters)
+	}
+
+	return wopBootstrapBuffer[T]{
+		fs:         make([]func(x int) T, 2),
+		lutExtract: tfhe.NewLUT(params.BaseParams()),
+		lut:        NewWoPLUT(params),
+
+		ctRemain:    tfhe.NewLWECiphertextCustom[T](params.BaseParams().GLWEDimension()),
+		ctShift:     tfhe.NewLWECiphertextCustom[T](params.BaseParams().GLWEDimension()),
+		ctKeySwitch: tfhe.NewLWECiphertextCustom[T](params.BaseParams().LWEDimension()),
+		ctRotate:    tfhe.NewGLWECiphertext(params.BaseParams()),
+		ctExtract:   tfhe.NewLWECiphertextCustom[T](params.BaseParams().GLWEDimension()),
+
+		ctBits:        ctBits,
+		ctFFTGGSWBits: ctFFTGGSWBits,
+
+		ctAcc: tfhe.NewGLWECiphertext(params.BaseParams()),
+	}
+}
+
+// newWoPBitCiphertext allocates an LWE ciphertext for an extracted bit,
+// which is an input of circuit bootstrapping.
+func newWoPBitCiphertext[T tfhe.TorusInt](params WoPBootstrapParameters[T]) tfhe.LWECiphertext[T] {
+	if params.BaseParams().BootstrapOrder() == tfhe.OrderBlindRotateKeySwitch {
+		return tfhe.NewLWECiphertextCustom[T](params.BaseParams().LWEDimension())
+	}
+	return tfhe.NewLWECiphertextCustom[T](params.BaseParams().GLWEDimension())
+}
+
+// SafeCopy creates a shallow copy of this WoPBootstrapper.
+// Returned WoPBootstrapper is safe for concurrent use.
+func (e *WoPBootstrapper[T]) SafeCopy() *WoPBootstrapper[T] {
+	return &WoPBootstrapper[T]{
+		CircuitBootstrapper: e.CircuitBootstrapper.SafeCopy(),
+
+		Params: e.Params,
+
+		buf: newWoPBootstrapBuffer(e.Params),
+	}
+}
+
+// WoPLookUpTable is a lookup table for WoP-PBS.
+// The table is packed into polynomials of PolyRank,
+// where the i-th entry of the table is stored in the (i % PolyRank)-th coefficient
+// of the (i / PolyRank)-th polynomial.
+type WoPLookUpTable[T tfhe.TorusInt] struct {
+	// Value has length PackedPolyCount.
+	Value []poly.Poly[T]
+}
+
+// NewWoPLUT creates a new [WoPLookUpTable].
+func NewWoPLUT[T tfhe.TorusInt](params WoPBootstrapParameters[T]) WoPLookUpTable[T] {
+	lut := WoPLookUpTable[T]{Value: make([]poly.Poly[T], params.PackedPolyCount())}
+	for i := range lut.Value {
+		lut.Value[i] = poly.NewPoly[T](params.BaseParams().PolyRank())
+	}
+	return lut
+}
+
+// Copy returns a copy of the LUT.
+func (lut WoPLookUpTable[T]) Copy() WoPLookUpTable[T] {
+	lutCopy := WoPLookUpTable[T]{Value: make([]poly.Poly[T], len(lut.Value))}
+	for i := range lut.Value {
+		lutCopy.Value[i] = lut.Value[i].Copy()
+	}
+	return lutCopy
+}
+
+// CopyFrom copies values from the LUT.
+func (lut *WoPLookUpTable[T]) CopyFrom(lutIn WoPLookUpTable[T]) {
+	for i := range lut.Value {
+		lut.Value[i].CopyFrom(lutIn.Value[i])
+	}
+}
+
+// Clear clears the LUT.
+func (lut *WoPLookUpTable[T]) Clear() {
+	for i := range lut.Value {
+		lut.Value[i].Clear()
+	}
+}
+
+// GenWoPLUT generates a lookup table for WoP-PBS based on table,
+// so that the input message x is mapped to table[x].
+// Output of table is cut by 2^OutputBits.
+//
+// Panics if len(table) != 2^InputBits.
+func (e *WoPBootstrapper[T]) GenWoPLUT(table []int) WoPLookUpTable[T] {
+	lutOut := NewWoPLUT(e.Params)
+	e.GenWoPLUTTo(lutOut, table)
+	return lutOut
+}
+
+// GenWoPLUTTo generates a lookup table for WoP-PBS based on table and writes it to lutOut,
+// so that the input message x is mapped to table[x].
+// Output of table is cut by 2^OutputBits.
+//
+// Panics if len(table) != 2^InputBits.
+func (e *WoPBootstrapper[T]) GenWoPLUTTo(lutOut WoPLookUpTable[T], table []int) {
+	if len(table) != e.Params.TableSize() {
+		panic("Table size mismatch")
+	}
+
+	lowBits := num.Min(e.Params.inputBits, e.Params.BaseParams().LogPolyRank())
+	lutOut.Clear()
+	for x := range table {
+		lutOut.Value[x>>lowBits].Coeffs[x&(1<<lowBits-1)] = e.EncodeLWECustom(table[x], 1<<e.Params.outputBits, e.Params.OutputScale()).Value
+	}
+}
+
+// BootstrapWoPTable returns a bootstrapped LWE ciphertext with respect to the given table using WoP-PBS.
+//
+// Panics if len(table) != 2^InputBits.
+func (e *WoPBootstrapper[T]) BootstrapWoPTable(ct tfhe.LWECiphertext[T], table []int) tfhe.LWECiphertext[T] {
+	e.GenWoPLUTTo(e.buf.lut, table)
+	return e.BootstrapWoPLUT(ct, e.buf.lut)
+}
+
+// BootstrapWoPTableTo bootstraps LWE ciphertext with respect to the given table using WoP-PBS and writes it to ctOut.
+//
+// Panics if len(table) != 2^InputBits.
+func (e *WoPBootstrapper[T]) BootstrapWoPTableTo(ctOut, ct tfhe.LWECiphertext[T], table []int) {
+	e.GenWoPLUTTo(e.buf.lut, table)
+	e.BootstrapWoPLUTTo(ctOut, ct, e.buf.lut)
+}
+
+// BootstrapWoPLUT returns a bootstrapped LWE ciphertext with respect to the given LUT using WoP-PBS.
+func (e *WoPBootstrapper[T]) BootstrapWoPLUT(ct tfhe.LWECiphertext[T], lut WoPLookUpTable[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertextCustom[T](e.Params.BaseParams().GLWEDimension())
+	e.BootstrapWoPLUTTo(ctOut, ct, lut)
+	return ctOut
+}
+
+// BootstrapWoPLUTTo bootstraps LWE ciphertext with respect to the given LUT using WoP-PBS and writes it to ctOut.
+func (e *WoPBootstrapper[T]) BootstrapWoPLUTTo(ctOut, ct tfhe.LWECiphertext[T], lut WoPLookUpTable[T]) {
+	e.ExtractBitsTo(e.buf.ctBits, ct)
+	for i := 0; i < e.Params.inputBits; i++ {
+		e.CircuitBootstrapTo(e.buf.ctFFTGGSWBits[i], e.buf.ctBits[i])
+	}
+	e.VerticalPackingTo(ctOut, e.buf.ctFFTGGSWBits, lut)
+}
+
+// ExtractBits extracts the bits of the message of ct, from the least significant bit.
+// The i-th output encrypts the i-th bit of the message,
+// and can be used as an input of [CircuitBootstrapper.CircuitBootstrap].
+func (e *WoPBootstrapper[T]) ExtractBits(ct tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := make([]tfhe.LWECiphertext[T], e.Params.inputBits)
+	for i := range ctOut {
+		ctOut[i] = newWoPBitCiphertext(e.Params)
+	}
+	e.ExtractBitsTo(ctOut, ct)
+	return ctOut
+}
+
+// ExtractBitsTo extracts the bits of the message of ct and writes them to ctOut, from the least significant bit.
+// The i-th output encrypts the i-th bit of the message,
+// and can be used as an input of [CircuitBootstrapper.CircuitBootstrap].
+//
+// Panics if len(ctOut) != InputBits.
+func (e *WoPBootstrapper[T]) ExtractBitsTo(ctOut []tfhe.LWECiphertext[T], ct tfhe.LWECiphertext[T]) {
+	if len(ctOut) != e.Params.inputBits {
+		panic("Bit count mismatch")
+	}
+
+	logQ := e.Params.BaseParams().LogQ()
+	logScale := logQ - e.Params.inputBits
+
+	// Bootstrapping a bit b at the most significant bit with constant -c gives c * (2b - 1),
+	// so we add c to get 2cb.
+	// The first LUT gives the input of circuit bootstrapping, and the second LUT gives the bit to subtract.
+	bitConst := T(1) << (logQ - 3)
+	e.buf.fs[0] = func(x int) T { return -bitConst }
+
+	e.buf.ctRemain.CopyFrom(ct)
+	for i := 0; i < e.Params.inputBits; i++ {
+		subConst := T(1) << (logScale + i - 1)
+		e.buf.fs[1] = func(x int) T { return -subConst }
+		e.GenLUTFullTo(e.buf.lutExtract, e.buf.fs)
+
+		e.ScalarMulLWETo(e.buf.ctShift, e.buf.ctRemain, T(1)<<(e.Params.inputBits-i-1))
+		e.buf.ctShift.Value[0] += bitConst
+		e.DefaultKeySwitchTo(e.buf.ctKeySwitch, e.buf.ctShift)
+		e.BlindRotateTo(e.buf.ctRotate, e.buf.ctKeySwitch, e.buf.lutExtract)
+
+		e.buf.ctRotate.AsLWECiphertextTo(0, e.buf.ctExtract)
+		e.buf.ctExtract.Value[0] += bitConst
+		switch e.Params.BaseParams().BootstrapOrder() {
+		case tfhe.OrderBlindRotateKeySwitch:
+			e.DefaultKeySwitchTo(ctOut[i], e.buf.ctExtract)
+		case tfhe.OrderKeySwitchBlindRotate:
+			ctOut[i].CopyFrom(e.buf.ctExtract)
+		}
+
+		if i < e.Params.inputBits-1 {
+			e.buf.ctRotate.AsLWECiphertextTo(1, e.buf.ctExtract)
+			e.buf.ctExtract.Value[0] += subConst
+			e.SubLWETo(e.buf.ctRemain, e.buf.ctRemain, e.buf.ctExtract)
+		}
+	}
+}
+
+// VerticalPacking evaluates lut on the message whose bits are encrypted in ctBits, from the least significant bit.
+func (e *WoPBootstrapper[T]) VerticalPacking(ctBits []tfhe.FFTGGSWCiphertext[T], lut WoPLookUpTable[T]) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertextCustom[T](e.Params.BaseParams().GLWEDimension())
+	e.VerticalPackingTo(ctOut, ctBits, lut)
+	return ctOut
+}
+
+// VerticalPackingTo evaluates lut on the message whose bits are encrypted in ctBits and writes it to ctOut.
+// ctBits should be ordered from the least significant bit.
+//
+// Panics if len(ctBits) != InputBits.
+func (e *WoPBootstrapper[T]) VerticalPackingTo(ctOut tfhe.LWECiphertext[T], ctBits []tfhe.FFTGGSWCiphertext[T], lut WoPLookUpTable[T]) {
+	if len(ctBits) != e.Params.inputBits {
+		panic("Bit count mismatch")
+	}
+
+	e.tableLookupGLWETo(e.buf.ctAcc, ctBits, lut.Value, 0)
+	e.buf.ctAcc.AsLWECiphertextTo(0, ctOut)
+}
@@ -0,0 +1,107 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// WoPBootstrapParametersLiteral is a structure for WoP-PBS Parameters.
+type WoPBootstrapParametersLiteral[T tfhe.TorusInt] struct {
+	// CircuitBootstrapParams is a base CircuitBootstrapParams for this WoPBootstrapParametersLiteral.
+	CircuitBootstrapParams CircuitBootstrapParametersLiteral[T]
+
+	// InputBits is the bit length of the input message.
+	// Input messages are encoded with scale Q / 2^InputBits, without the padding bit.
+	InputBits int
+	// OutputBits is the bit length of the output message.
+	// Output messages are encoded with scale Q / 2^OutputBits, without the padding bit.
+	OutputBits int
+}
+
+// Compile transforms ParametersLiteral to read-only Parameters.
+// If there is any invalid parameter in the literal, it panics.
+// Default parameters are guaranteed to compile without panicking.
+func (p WoPBootstrapParametersLiteral[T]) Compile() WoPBootstrapParameters[T] {
+	circuitBootstrapParams := p.CircuitBootstrapParams.Compile()
+	baseParams := circuitBootstrapParams.BaseParams()
+
+	switch {
+	case baseParams.MessageModulus() != 2:
+		panic("MessageModulus not 2")
+	case circuitBootstrapParams.manyLUTParameters.lutCount < 2:
+		panic("LUTCount smaller than 2")
+	case p.InputBits <= 0:
+		panic("InputBits smaller than one")
+	case p.OutputBits <= 0:
+		panic("OutputBits smaller than one")
+	case p.InputBits >= baseParams.LogQ():
+		panic("InputBits not smaller than LogQ")
+	case p.OutputBits > baseParams.LogQ():
+		panic("OutputBits larger than LogQ")
+	}
+
+	return WoPBootstrapParameters[T]{
+		circuitBootstrapParameters: circuitBootstrapParams,
+
+		inputBits:  p.InputBits,
+		outputBits: p.OutputBits,
+	}
+}
+
+// WoPBootstrapParameters is a parameter set for WoP-PBS.
+type WoPBootstrapParameters[T tfhe.TorusInt] struct {
+	// circuitBootstrapParameters is a base CircuitBootstrapParameters for this WoPBootstrapParameters.
+	circuitBootstrapParameters CircuitBootstrapParameters[T]
+
+	// inputBits is the bit length of the input message.
+	inputBits int
+	// outputBits is the bit length of the output message.
+	outputBits int
+}
+
+// CircuitBootstrapParams returns the base CircuitBootstrapParams for this WoPBootstrapParameters.
+func (p WoPBootstrapParameters[T]) CircuitBootstrapParams() CircuitBootstrapParameters[T] {
+	return p.circuitBootstrapParameters
+}
+
+// BaseParams returns the base parameters for this WoPBootstrapParameters.
+func (p WoPBootstrapParameters[T]) BaseParams() tfhe.Parameters[T] {
+	return p.circuitBootstrapParameters.BaseParams()
+}
+
+// LogQ returns log(Q), where Q is the modulus of the ciphertext.
+func (p WoPBootstrapParameters[T]) LogQ() int {
+	return p.circuitBootstrapParameters.LogQ()
+}
+
+// InputBits returns the bit length of the input message.
+func (p WoPBootstrapParameters[T]) InputBits() int {
+	return p.inputBits
+}
+
+// OutputBits returns the bit length of the output message.
+func (p WoPBootstrapParameters[T]) OutputBits() int {
+	return p.outputBits
+}
+
+// InputScale returns the scale of the input message, Q / 2^InputBits.
+func (p WoPBootstrapParameters[T]) InputScale() T {
+	return 1 << (p.BaseParams().LogQ() - p.inputBits)
+}
+
+// OutputScale returns the scale of the output message, Q / 2^OutputBits.
+func (p WoPBootstrapParameters[T]) OutputScale() T {
+	return 1 << (p.BaseParams().LogQ() - p.outputBits)
+}
+
+// TableSize returns the size of the lookup table, 2^InputBits.
+func (p WoPBootstrapParameters[T]) TableSize() int {
+	return 1 << p.inputBits
+}
+
+// PackedPolyCount returns the number of polynomials the lookup table is packed into.
+func (p WoPBootstrapParameters[T]) PackedPolyCount() int {
+	if p.inputBits <= p.BaseParams().LogPolyRank() {
+		return 1
+	}
+	return 1 << (p.inputBits - p.BaseParams().LogPolyRank())
+}
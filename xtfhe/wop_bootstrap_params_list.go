@@ -0,0 +1,74 @@
+package xtfhe
+
+import "github.com/sp301415/tfhe-go/tfhe"
+
+var (
+	// ParamsWoPBootstrapMedium is a default WoP-PBS parameter set
+	// for 8-bit inputs and 6-bit outputs.
+	//
+	// The table has 2^8 entries, which fit in a single packed polynomial,
+	// so vertical packing is a single blind rotation.
+	ParamsWoPBootstrapMedium = WoPBootstrapParametersLiteral[uint64]{
+		CircuitBootstrapParams: paramsWoPCircuitBootstrap,
+
+		InputBits:  8,
+		OutputBits: 6,
+	}
+
+	// ParamsWoPBootstrapLarge is a default WoP-PBS parameter set
+	// for 16-bit inputs and 6-bit outputs.
+	//
+	// The table has 2^16 entries packed into 2^16 / PolyRank = 32 polynomials,
+	// which are selected by a CMux tree of depth 5 before the blind rotation.
+	ParamsWoPBootstrapLarge = WoPBootstrapParametersLiteral[uint64]{
+		CircuitBootstrapParams: paramsWoPCircuitBootstrap,
+
+		InputBits:  16,
+		OutputBits: 6,
+	}
+
+	// paramsWoPCircuitBootstrap is a circuit bootstrapping parameter set used in WoP-PBS.
+	//
+	// It is based on ParamsCircuitBootstrapLarge, with finer gadget decompositions
+	// to reduce the noise of circuit bootstrapped GGSW ciphertexts.
+	paramsWoPCircuitBootstrap = CircuitBootstrapParametersLiteral[uint64]{
+		ManyLUTParams: ManyLUTParametersLiteral[uint64]{
+			BaseParams: tfhe.ParametersLiteral[uint64]{
+				LWEDimension: 571,
+				GLWERank:     1,
+				PolyRank:     2048,
+
+				LWEStdDev:  0.00016996595057126976,
+				GLWEStdDev: 0.0000000000000003472576015484159,
+
+				MessageModulus: 1 << 1,
+
+				BlindRotateParams: tfhe.GadgetParametersLiteral[uint64]{
+					Base:  1 << 12,
+					Level: 3,
+				},
+				KeySwitchParams: tfhe.GadgetParametersLiteral[uint64]{
+					Base:  1 << 2,
+					Level: 5,
+				},
+
+				BootstrapOrder: tfhe.OrderBlindRotateKeySwitch,
+			},
+
+			LUTCount: 8,
+		},
+
+		SchemeSwitchParams: tfhe.GadgetParametersLiteral[uint64]{
+			Base:  1 << 7,
+			Level: 6,
+		},
+		TraceKeySwitchParams: tfhe.GadgetParametersLiteral[uint64]{
+			Base:  1 << 8,
+			Level: 6,
+		},
+		OutputParams: tfhe.GadgetParametersLiteral[uint64]{
+			Base:  1 << 3,
+			Level: 8,
+		},
+	}
+)
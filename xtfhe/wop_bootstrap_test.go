@@ -0,0 +1,119 @@
+package xtfhe_test
+
+import (
+	"testing"
+
+	"github.com/sp301415/tfhe-go/tfhe"
+	"github.com/sp301415/tfhe-go/xtfhe"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	wopParams = xtfhe.ParamsWoPBootstrapMedium.Compile()
+	wopEnc    = xtfhe.NewWoPEncryptor(wopParams)
+	wopKeyGen = xtfhe.NewCircuitBootstrapKeyGenerator(wopParams.CircuitBootstrapParams(), wopEnc.SecretKey)
+	wopEvk    = wopEnc.GenEvalKeyParallel()
+	wopCBK    = wopKeyGen.GenCircuitBootstrapKey()
+	wopEval   = xtfhe.NewWoPBootstrapper(wopParams, wopEvk, wopCBK)
+)
+
+func TestWoPBootstrap(t *testing.T) {
+	msgs := []int{0, 1, 85, 128, 255}
+
+	table := make([]int, wopParams.TableSize())
+	for x := range table {
+		table[x] = x*x + 3
+	}
+
+	t.Run("ExtractBits", func(t *testing.T) {
+		for _, m := range msgs {
+			ctBits := wopEval.ExtractBits(wopEnc.EncryptWoP(m))
+			for i := range ctBits {
+				assert.Equal(t, wopEnc.DecryptLWE(ctBits[i]), (m>>i)&1)
+			}
+		}
+	})
+
+	t.Run("VerticalPacking", func(t *testing.T) {
+		lut := wopEval.GenWoPLUT(table)
+		for _, m := range msgs {
+			ctBits := make([]tfhe.FFTGGSWCiphertext[uint64], wopParams.InputBits())
+			for i := range ctBits {
+				ctBits[i] = wopEnc.EncryptFFTGGSW([]int{(m >> i) & 1}, wopParams.CircuitBootstrapParams().OutputParams())
+			}
+			ctOut := wopEval.VerticalPacking(ctBits, lut)
+			assert.Equal(t, wopEnc.DecryptWoP(ctOut), table[m]%(1<<wopParams.OutputBits()))
+		}
+	})
+
+	t.Run("BootstrapWoPTable", func(t *testing.T) {
+		for _, m := range msgs {
+			ctOut := wopEval.BootstrapWoPTable(wopEnc.EncryptWoP(m), table)
+			assert.Equal(t, wopEnc.DecryptWoP(ctOut), table[m]%(1<<wopParams.OutputBits()))
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		assert.Panics(t, func() { wopEval.GenWoPLUT(table[:1]) })
+	})
+}
+
+func TestWoPBootstrapLarge(t *testing.T) {
+	// ParamsWoPBootstrapLarge shares its circuit bootstrapping parameters with ParamsWoPBootstrapMedium,
+	// so the keys of wopEval can be reused.
+	paramsLarge := xtfhe.ParamsWoPBootstrapLarge.Compile()
+	encLarge := xtfhe.NewWoPEncryptorWithKey(paramsLarge, wopEnc.SecretKey)
+	evalLarge := xtfhe.NewWoPBootstrapper(paramsLarge, wopEvk, wopCBK)
+
+	table := make([]int, paramsLarge.TableSize())
+	for x := range table {
+		table[x] = 7*x + 5
+	}
+
+	for _, m := range []int{0, 1, 0x1234, 0x8000, 0xffff} {
+		ctOut := evalLarge.BootstrapWoPTable(encLarge.EncryptWoP(m), table)
+		assert.Equal(t, encLarge.DecryptWoP(ctOut), table[m]%(1<<paramsLarge.OutputBits()))
+	}
+}
+
+func TestWoPBootstrapParams(t *testing.T) {
+	t.Run("Compile", func(t *testing.T) {
+		assert.NotPanics(t, func() { xtfhe.ParamsWoPBootstrapMedium.Compile() })
+		assert.NotPanics(t, func() { xtfhe.ParamsWoPBootstrapLarge.Compile() })
+	})
+
+	t.Run("Compile/Bits", func(t *testing.T) {
+		paramsLiteral := xtfhe.ParamsWoPBootstrapMedium
+		paramsLiteral.InputBits = 0
+		assert.Panics(t, func() { paramsLiteral.Compile() })
+
+		paramsLiteral = xtfhe.ParamsWoPBootstrapMedium
+		paramsLiteral.InputBits = 64
+		assert.Panics(t, func() { paramsLiteral.Compile() })
+
+		paramsLiteral = xtfhe.ParamsWoPBootstrapMedium
+		paramsLiteral.OutputBits = 0
+		assert.Panics(t, func() { paramsLiteral.Compile() })
+	})
+
+	t.Run("Hash", func(t *testing.T) {
+		assert.Equal(t, wopParams.Hash(), xtfhe.ParamsWoPBootstrapMedium.Compile().Hash())
+		assert.NotEqual(t, wopParams.Hash(), xtfhe.ParamsWoPBootstrapLarge.Compile().Hash())
+		assert.NotEqual(t, wopParams.Hash(), wopParams.CircuitBootstrapParams().Hash())
+	})
+}
+
+func BenchmarkWoPBootstrap(b *testing.B) {
+	table := make([]int, wopParams.TableSize())
+	for x := range table {
+		table[x] = x + 1
+	}
+	lut := wopEval.GenWoPLUT(table)
+	ct := wopEnc.EncryptWoP(3)
+	ctOut := wopEnc.EncryptWoP(0)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		wopEval.BootstrapWoPLUTTo(ctOut, ct, lut)
+	}
+}
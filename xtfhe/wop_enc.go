@@ -0,0 +1,64 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/math/vec"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// WoPEncryptor wraps around [tfhe.Encryptor] and implements encryption for WoP-PBS.
+//
+// Messages are encrypted to LWE ciphertexts of GLWEDimension under LWELargeKey,
+// which have small enough noise to hold InputBits bits without the padding bit.
+type WoPEncryptor[T tfhe.TorusInt] struct {
+	// Encryptor is an embedded [tfhe.Encryptor] for this WoPEncryptor.
+	*tfhe.Encryptor[T]
+
+	// Params is the parameter set for this WoPEncryptor.
+	Params WoPBootstrapParameters[T]
+}
+
+// NewWoPEncryptor creates a new [WoPEncryptor].
+func NewWoPEncryptor[T tfhe.TorusInt](params WoPBootstrapParameters[T]) *WoPEncryptor[T] {
+	return &WoPEncryptor[T]{
+		Encryptor: tfhe.NewEncryptor(params.BaseParams()),
+		Params:    params,
+	}
+}
+
+// NewWoPEncryptorWithKey creates a new [WoPEncryptor] with given parameters and secret key.
+func NewWoPEncryptorWithKey[T tfhe.TorusInt](params WoPBootstrapParameters[T], sk tfhe.SecretKey[T]) *WoPEncryptor[T] {
+	return &WoPEncryptor[T]{
+		Encryptor: tfhe.NewEncryptorWithKey(params.BaseParams(), sk),
+		Params:    params,
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *WoPEncryptor[T]) SafeCopy() *WoPEncryptor[T] {
+	return &WoPEncryptor[T]{
+		Encryptor: e.Encryptor.SafeCopy(),
+		Params:    e.Params,
+	}
+}
+
+// EncryptWoP encodes and encrypts integer message in [0, 2^InputBits) to LWE ciphertext for WoP-PBS.
+func (e *WoPEncryptor[T]) EncryptWoP(message int) tfhe.LWECiphertext[T] {
+	ctOut := tfhe.NewLWECiphertextCustom[T](e.Params.BaseParams().GLWEDimension())
+	e.EncryptWoPTo(ctOut, message)
+	return ctOut
+}
+
+// EncryptWoPTo encodes and encrypts integer message in [0, 2^InputBits) to LWE ciphertext for WoP-PBS
+// and writes it to ctOut.
+func (e *WoPEncryptor[T]) EncryptWoPTo(ctOut tfhe.LWECiphertext[T], message int) {
+	ctOut.Value[0] = e.EncodeLWECustom(message, 1<<e.Params.inputBits, e.Params.InputScale()).Value
+	e.UniformSampler.SampleVecTo(ctOut.Value[1:])
+	ctOut.Value[0] += -vec.Dot(ctOut.Value[1:], e.SecretKey.LWELargeKey.Value)
+	ctOut.Value[0] += e.GaussianSampler.Sample(e.Params.BaseParams().GLWEStdDevQ())
+}
+
+// DecryptWoP decrypts and decodes LWE ciphertext from WoP-PBS to integer message in [0, 2^OutputBits).
+func (e *WoPEncryptor[T]) DecryptWoP(ct tfhe.LWECiphertext[T]) int {
+	pt := tfhe.LWEPlaintext[T]{Value: ct.Value[0] + vec.Dot(ct.Value[1:], e.SecretKey.LWELargeKey.Value)}
+	return e.DecodeLWECustom(pt, 1<<e.Params.outputBits, e.Params.OutputScale())
+}
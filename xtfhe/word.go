@@ -0,0 +1,344 @@
+package xtfhe
+
+import (
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+// WordBits is the number of bits in a word.
+const WordBits = 32
+
+// WordEvaluator evaluates operations on 32-bit encrypted words using [tfhe.BinaryEvaluator].
+// This is meant to be public, usually for servers.
+//
+// A word is a slice of WordBits LWE ciphertexts encrypted with [tfhe.BinaryEncryptor],
+// from the least significant bit, which can be encrypted using EncryptLWEBits(x, WordBits).
+// Rotations and shifts are free, since they only move ciphertexts around.
+// Bitwise operations and additions are compiled to [circuit.Program],
+// so that the gates of each level are bootstrapped in parallel.
+// Additions use the Brent-Kung carry-lookahead adder, which has logarithmic depth.
+//
+// WordEvaluator is not safe for concurrent use.
+// Use [WordEvaluator.SafeCopy] to get a safe copy.
+type WordEvaluator[T tfhe.TorusInt] struct {
+	// Evaluator is a circuit Evaluator for this WordEvaluator.
+	Evaluator *circuit.Evaluator[T]
+	// Params is the parameter set for this WordEvaluator.
+	Params tfhe.Parameters[T]
+
+	// add, xor and and are the compiled programs of the binary word operations.
+	add *circuit.Program
+	xor *circuit.Program
+	and *circuit.Program
+
+	buf wordEvaluatorBuffer[T]
+}
+
+// wordEvaluatorBuffer is a buffer for WordEvaluator.
+type wordEvaluatorBuffer[T tfhe.TorusInt] struct {
+	// ctWord is the copy of the input word for rotations and shifts.
+	ctWord []tfhe.LWECiphertext[T]
+}
+
+// NewWordEvaluator creates a new [WordEvaluator].
+// This does not copy evaluation keys, since they are large.
+func NewWordEvaluator[T tfhe.TorusInt](params tfhe.Parameters[T], evk tfhe.EvaluationKey[T]) *WordEvaluator[T] {
+	return &WordEvaluator[T]{
+		Evaluator: circuit.NewEvaluator(params, evk),
+		Params:    params,
+
+		add: circuit.Compile(wordCircuit((*circuitBuilder).addWord)),
+		xor: circuit.Compile(wordCircuit((*circuitBuilder).xorWord)),
+		and: circuit.Compile(wordCircuit((*circuitBuilder).andWord)),
+
+		buf: newWordEvaluatorBuffer(params),
+	}
+}
+
+// newWordEvaluatorBuffer creates a new [wordEvaluatorBuffer].
+func newWordEvaluatorBuffer[T tfhe.TorusInt](params tfhe.Parameters[T]) wordEvaluatorBuffer[T] {
+	ctWord := make([]tfhe.LWECiphertext[T], WordBits)
+	for i := range ctWord {
+		ctWord[i] = tfhe.NewLWECiphertext(params)
+	}
+
+	return wordEvaluatorBuffer[T]{
+		ctWord: ctWord,
+	}
+}
+
+// SafeCopy returns a thread-safe copy.
+func (e *WordEvaluator[T]) SafeCopy() *WordEvaluator[T] {
+	return &WordEvaluator[T]{
+		Evaluator: e.Evaluator.SafeCopy(),
+		Params:    e.Params,
+
+		add: e.add,
+		xor: e.xor,
+		and: e.and,
+
+		buf: newWordEvaluatorBuffer(e.Params),
+	}
+}
+
+// newWord allocates a new word.
+func (e *WordEvaluator[T]) newWord() []tfhe.LWECiphertext[T] {
+	ct := make([]tfhe.LWECiphertext[T], WordBits)
+	for i := range ct {
+		ct[i] = tfhe.NewLWECiphertext(e.Params)
+	}
+	return ct
+}
+
+// checkWord panics if the length of any ct is not WordBits.
+func checkWord[T tfhe.TorusInt](ct ...[]tfhe.LWECiphertext[T]) {
+	for i := range ct {
+		if len(ct[i]) != WordBits {
+			panic("Word size mismatch")
+		}
+	}
+}
+
+// Add returns ct0 + ct1 mod 2^32.
+func (e *WordEvaluator[T]) Add(ct0, ct1 []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newWord()
+	e.AddTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// AddTo computes ctOut = ct0 + ct1 mod 2^32.
+func (e *WordEvaluator[T]) AddTo(ctOut, ct0, ct1 []tfhe.LWECiphertext[T]) {
+	e.evaluateTo(ctOut, e.add, ct0, ct1)
+}
+
+// XOR returns ct0 XOR ct1.
+func (e *WordEvaluator[T]) XOR(ct0, ct1 []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newWord()
+	e.XORTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// XORTo computes ctOut = ct0 XOR ct1.
+func (e *WordEvaluator[T]) XORTo(ctOut, ct0, ct1 []tfhe.LWECiphertext[T]) {
+	e.evaluateTo(ctOut, e.xor, ct0, ct1)
+}
+
+// AND returns ct0 AND ct1.
+func (e *WordEvaluator[T]) AND(ct0, ct1 []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newWord()
+	e.ANDTo(ctOut, ct0, ct1)
+	return ctOut
+}
+
+// ANDTo computes ctOut = ct0 AND ct1.
+func (e *WordEvaluator[T]) ANDTo(ctOut, ct0, ct1 []tfhe.LWECiphertext[T]) {
+	e.evaluateTo(ctOut, e.and, ct0, ct1)
+}
+
+// NOT returns NOT ct.
+func (e *WordEvaluator[T]) NOT(ct []tfhe.LWECiphertext[T]) []tfhe.LWECiphertext[T] {
+	ctOut := e.newWord()
+	e.NOTTo(ctOut, ct)
+	return ctOut
+}
+
+// NOTTo computes ctOut = NOT ct.
+func (e *WordEvaluator[T]) NOTTo(ctOut, ct []tfhe.LWECiphertext[T]) {
+	checkWord(ctOut, ct)
+
+	for i := 0; i < WordBits; i++ {
+		e.Evaluator.BinaryEvaluator.NOTTo(ctOut[i], ct[i])
+	}
+}
+
+// RotateRight returns ct rotated right by n bits.
+func (e *WordEvaluator[T]) RotateRight(ct []tfhe.LWECiphertext[T], n int) []tfhe.LWECiphertext[T] {
+	ctOut := e.newWord()
+	e.RotateRightTo(ctOut, ct, n)
+	return ctOut
+}
+
+// RotateRightTo computes ctOut = ct rotated right by n bits.
+func (e *WordEvaluator[T]) RotateRightTo(ctOut, ct []tfhe.LWECiphertext[T], n int) {
+	checkWord(ctOut, ct)
+
+	for i := 0; i < WordBits; i++ {
+		e.buf.ctWord[i].CopyFrom(ct[i])
+	}
+
+	n = ((n % WordBits) + WordBits) % WordBits
+	for i := 0; i < WordBits; i++ {
+		ctOut[i].CopyFrom(e.buf.ctWord[(i+n)%WordBits])
+	}
+}
+
+// ShiftRight returns ct shifted right by n bits.
+//
+// Panics if n < 0.
+func (e *WordEvaluator[T]) ShiftRight(ct []tfhe.LWECiphertext[T], n int) []tfhe.LWECiphertext[T] {
+	ctOut := e.newWord()
+	e.ShiftRightTo(ctOut, ct, n)
+	return ctOut
+}
+
+// ShiftRightTo computes ctOut = ct shifted right by n bits.
+//
+// Panics if n < 0.
+func (e *WordEvaluator[T]) ShiftRightTo(ctOut, ct []tfhe.LWECiphertext[T], n int) {
+	checkWord(ctOut, ct)
+	if n < 0 {
+		panic("Negative shift")
+	}
+
+	for i := 0; i < WordBits; i++ {
+		e.buf.ctWord[i].CopyFrom(ct[i])
+	}
+
+	for i := 0; i < WordBits; i++ {
+		if i+n < WordBits {
+			ctOut[i].CopyFrom(e.buf.ctWord[i+n])
+		} else {
+			ctOut[i].Clear()
+			ctOut[i].Value[0] = e.Evaluator.BinaryEvaluator.EncodeLWEBool(false).Value
+		}
+	}
+}
+
+// evaluateTo evaluates the binary word operation p on ct0 and ct1, and writes the result to ctOut.
+func (e *WordEvaluator[T]) evaluateTo(ctOut []tfhe.LWECiphertext[T], p *circuit.Program, ct0, ct1 []tfhe.LWECiphertext[T]) {
+	checkWord(ctOut, ct0, ct1)
+	e.Evaluator.EvaluateProgramParallelTo([][]tfhe.LWECiphertext[T]{ctOut}, p, [][]tfhe.LWECiphertext[T]{ct0, ct1})
+}
+
+// word is a 32-bit word of wires, from the least significant bit.
+type word [WordBits]int
+
+// wordCircuit returns a circuit of a binary word operation op.
+func wordCircuit(op func(b *circuitBuilder, x, y word) word) circuit.Circuit {
+	b := newCircuitBuilder(WordBits, WordBits)
+	z := op(b, b.inputWord(0), b.inputWord(WordBits))
+	return b.build([]string{"x", "y"}, []string{"z"}, z[:])
+}
+
+// inputWord returns the word of input wires starting from offset.
+func (b *circuitBuilder) inputWord(offset int) word {
+	var x word
+	for i := range x {
+		x[i] = offset + i
+	}
+	return x
+}
+
+// constWord returns a word of constant c.
+func (b *circuitBuilder) constWord(c uint32) word {
+	var x word
+	for i := range x {
+		x[i] = b.constant((c>>i)&1 == 1)
+	}
+	return x
+}
+
+// xorWord returns x XOR y.
+func (b *circuitBuilder) xorWord(x, y word) word {
+	var z word
+	for i := range z {
+		z[i] = b.xor(x[i], y[i])
+	}
+	return z
+}
+
+// andWord returns x AND y.
+func (b *circuitBuilder) andWord(x, y word) word {
+	var z word
+	for i := range z {
+		z[i] = b.and(x[i], y[i])
+	}
+	return z
+}
+
+// notWord returns NOT x.
+func (b *circuitBuilder) notWord(x word) word {
+	var z word
+	for i := range z {
+		z[i] = b.not(x[i])
+	}
+	return z
+}
+
+// chooseWord returns x ? y : z bitwise.
+func (b *circuitBuilder) chooseWord(x, y, z word) word {
+	var w word
+	for i := range w {
+		w[i] = b.mux(x[i], y[i], z[i])
+	}
+	return w
+}
+
+// majorityWord returns the bitwise majority of x, y and z.
+func (b *circuitBuilder) majorityWord(x, y, z word) word {
+	var w word
+	for i := range w {
+		w[i] = b.maj(x[i], y[i], z[i])
+	}
+	return w
+}
+
+// rotateRightWord returns x rotated right by n bits.
+func rotateRightWord(x word, n int) word {
+	var z word
+	for i := range z {
+		z[i] = x[(i+n)%WordBits]
+	}
+	return z
+}
+
+// shiftRightWord returns x shifted right by n bits.
+func (b *circuitBuilder) shiftRightWord(x word, n int) word {
+	var z word
+	for i := range z {
+		if i+n < WordBits {
+			z[i] = x[i+n]
+		} else {
+			z[i] = b.constant(false)
+		}
+	}
+	return z
+}
+
+// addWord returns x + y mod 2^32 using the Brent-Kung adder.
+func (b *circuitBuilder) addWord(x, y word) word {
+	// g[i] and t[i] are the generate and transmit bits,
+	// which become the group generate and transmit bits of [0, i] after the prefix computation.
+	var g, t, s word
+	for i := range g {
+		g[i] = b.and(x[i], y[i])
+		t[i] = b.or(x[i], y[i])
+		s[i] = b.xor(x[i], y[i])
+	}
+
+	// (g, t) o (g', t') = (g OR (t AND g'), g OR (t AND t')).
+	// Since g implies t, g OR (t AND g') = MAJ(g, t, g'), which takes a single bootstrapping.
+	// The group transmit bit also includes g, so that g still implies t.
+	combine := func(i, j int) {
+		g[i], t[i] = b.maj(g[i], t[i], g[j]), b.maj(g[i], t[i], t[j])
+	}
+
+	d := 1
+	for ; 2*d <= WordBits; d *= 2 {
+		for i := 2*d - 1; i < WordBits; i += 2 * d {
+			combine(i, i-d)
+		}
+	}
+	for d /= 2; d >= 1; d /= 2 {
+		for i := 3*d - 1; i < WordBits; i += 2 * d {
+			combine(i, i-d)
+		}
+	}
+
+	var z word
+	z[0] = s[0]
+	for i := 1; i < WordBits; i++ {
+		z[i] = b.xor(s[i], g[i-1])
+	}
+	return z
+}
@@ -0,0 +1,57 @@
+package xtfhe_test
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/sp301415/tfhe-go/xtfhe"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	wordEval = xtfhe.NewWordEvaluator(binaryParams, binaryEvk)
+)
+
+func TestWordEvaluator(t *testing.T) {
+	x, y := rand.Uint32(), rand.Uint32()
+	ctX := binaryEnc.EncryptLWEBits(int(x), xtfhe.WordBits)
+	ctY := binaryEnc.EncryptLWEBits(int(y), xtfhe.WordBits)
+
+	t.Run("Add", func(t *testing.T) {
+		assert.Equal(t, uint32(binaryEnc.DecryptLWEBits(wordEval.Add(ctX, ctY))), x+y)
+	})
+
+	t.Run("XOR", func(t *testing.T) {
+		assert.Equal(t, uint32(binaryEnc.DecryptLWEBits(wordEval.XOR(ctX, ctY))), x^y)
+	})
+
+	t.Run("AND", func(t *testing.T) {
+		assert.Equal(t, uint32(binaryEnc.DecryptLWEBits(wordEval.AND(ctX, ctY))), x&y)
+	})
+
+	t.Run("NOT", func(t *testing.T) {
+		assert.Equal(t, uint32(binaryEnc.DecryptLWEBits(wordEval.NOT(ctX))), ^x)
+	})
+
+	t.Run("RotateRight", func(t *testing.T) {
+		for _, n := range []int{0, 7, 31, 40} {
+			assert.Equal(t, uint32(binaryEnc.DecryptLWEBits(wordEval.RotateRight(ctX, n))), bits.RotateLeft32(x, -n))
+		}
+
+		ctOut := wordEval.NOT(ctX)
+		wordEval.RotateRightTo(ctOut, ctOut, 3)
+		assert.Equal(t, uint32(binaryEnc.DecryptLWEBits(ctOut)), bits.RotateLeft32(^x, -3))
+	})
+
+	t.Run("ShiftRight", func(t *testing.T) {
+		for _, n := range []int{0, 7, 31, 40} {
+			assert.Equal(t, uint32(binaryEnc.DecryptLWEBits(wordEval.ShiftRight(ctX, n))), x>>n)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		assert.Panics(t, func() { wordEval.XOR(ctX, ctY[:16]) })
+		assert.Panics(t, func() { wordEval.ShiftRight(ctX, -1) })
+	})
+}
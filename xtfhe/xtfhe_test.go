@@ -0,0 +1,100 @@
+package xtfhe_test
+
+import (
+	"sync"
+
+	"github.com/sp301415/tfhe-go/circuit"
+	"github.com/sp301415/tfhe-go/tfhe"
+)
+
+var (
+	// binaryParams is an INSECURE parameter set for binary circuits, only used in tests.
+	// Circuits such as AES and SHA-256 have 10^4 to 10^5 bootstrapping gates,
+	// which takes too long with ParamsBinary.
+	binaryParams = tfhe.ParametersLiteral[uint32]{
+		LWEDimension: 16,
+		GLWERank:     1,
+		PolyRank:     128,
+		LUTSize:      128,
+
+		LWEStdDev:  0.000001,
+		GLWEStdDev: 0.00000003,
+
+		MessageModulus: 1 << 1,
+
+		BlindRotateParams: tfhe.GadgetParametersLiteral[uint32]{
+			Base:  1 << 8,
+			Level: 2,
+		},
+		KeySwitchParams: tfhe.GadgetParametersLiteral[uint32]{
+			Base:  1 << 4,
+			Level: 4,
+		},
+
+		BootstrapOrder: tfhe.OrderBlindRotateKeySwitch,
+	}.Compile()
+	binaryEnc = tfhe.NewBinaryEncryptor(binaryParams)
+	binaryEvk = binaryEnc.GenEvalKeyParallel()
+)
+
+var (
+	benchmarkBinaryOnce sync.Once
+	benchmarkBinaryEnc  *tfhe.BinaryEncryptor[uint32]
+	benchmarkBinaryEvk  tfhe.EvaluationKey[uint32]
+)
+
+// benchmarkBinaryKeys returns the encryptor and evaluation key of ParamsBinary for benchmarks.
+// They are generated on the first call, so that tests do not pay for the key generation.
+func benchmarkBinaryKeys() (*tfhe.BinaryEncryptor[uint32], tfhe.EvaluationKey[uint32]) {
+	benchmarkBinaryOnce.Do(func() {
+		benchmarkBinaryEnc = tfhe.NewBinaryEncryptor(tfhe.ParamsBinary.Compile())
+		benchmarkBinaryEvk = benchmarkBinaryEnc.GenEvalKeyParallel()
+	})
+	return benchmarkBinaryEnc, benchmarkBinaryEvk
+}
+
+func bytesToBools(b []byte) []bool {
+	bits := make([]bool, 8*len(b))
+	for i := range bits {
+		bits[i] = (b[i/8]>>(i%8))&1 == 1
+	}
+	return bits
+}
+
+func boolsToBytes(bits []bool) []byte {
+	b := make([]byte, len(bits)/8)
+	for i := range bits {
+		if bits[i] {
+			b[i/8] |= 1 << (i % 8)
+		}
+	}
+	return b
+}
+
+// encryptBytes encrypts b bitwise, starting from the least significant bit of b[0].
+func encryptBytes(enc *tfhe.BinaryEncryptor[uint32], b []byte) []tfhe.LWECiphertext[uint32] {
+	ct := make([]tfhe.LWECiphertext[uint32], 0, 8*len(b))
+	for i := range b {
+		ct = append(ct, enc.EncryptLWEBits(int(b[i]), 8)...)
+	}
+	return ct
+}
+
+// decryptBytes decrypts ct encrypted by encryptBytes.
+func decryptBytes(enc *tfhe.BinaryEncryptor[uint32], ct []tfhe.LWECiphertext[uint32]) []byte {
+	b := make([]byte, len(ct)/8)
+	for i := range b {
+		b[i] = byte(enc.DecryptLWEBits(ct[8*i : 8*i+8]))
+	}
+	return b
+}
+
+// evaluateBytes evaluates p on plaintext inputs and returns the first output.
+// Inputs and outputs are in the same bit order as encryptBytes.
+func evaluateBytes(p *circuit.Program, inputs ...[]byte) []byte {
+	bits := make([][]bool, len(inputs))
+	for i := range inputs {
+		bits[i] = bytesToBools(inputs[i])
+	}
+	return boolsToBytes(p.EvaluateBool(bits)[0])
+}